@@ -5,25 +5,39 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"avito-intership-2025/internal/http/handlers"
+	absenceh "avito-intership-2025/internal/http/handlers/absence"
+	codeownersh "avito-intership-2025/internal/http/handlers/codeowners"
+	exclusionh "avito-intership-2025/internal/http/handlers/exclusion"
+	historyh "avito-intership-2025/internal/http/handlers/history"
+	ingesth "avito-intership-2025/internal/http/handlers/ingest"
 	prh "avito-intership-2025/internal/http/handlers/pr"
 	statsh "avito-intership-2025/internal/http/handlers/stats"
 	teamh "avito-intership-2025/internal/http/handlers/team"
 	userh "avito-intership-2025/internal/http/handlers/user"
+	webhookh "avito-intership-2025/internal/http/handlers/webhook"
 	mw "avito-intership-2025/internal/http/middleware"
 	"avito-intership-2025/internal/lib/config"
 	"avito-intership-2025/internal/lib/sl"
 	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/absence"
+	"avito-intership-2025/internal/service/codeowners"
+	"avito-intership-2025/internal/service/exclusion"
+	"avito-intership-2025/internal/service/history"
+	"avito-intership-2025/internal/service/ingest"
 	"avito-intership-2025/internal/service/pr"
 	"avito-intership-2025/internal/service/stats"
 	"avito-intership-2025/internal/service/team"
 	"avito-intership-2025/internal/service/user"
+	"avito-intership-2025/internal/service/webhook"
 
 	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
@@ -71,16 +85,48 @@ func main() {
 	userRepo := repo.NewUserRepo(db, trmsqlx.DefaultCtxGetter)
 	prRepo := repo.NewPullRequestRepo(db, trmsqlx.DefaultCtxGetter, trManager)
 	statsRepo := repo.NewStatisticsRepo(db)
+	codeOwnersRepo := repo.NewCodeOwnersRepo(db, trmsqlx.DefaultCtxGetter)
+	absenceRepo := repo.NewAbsenceRepo(db, trmsqlx.DefaultCtxGetter)
+	historyRepo := repo.NewHistoryRepo(db, trmsqlx.DefaultCtxGetter)
+	webhookRepo := repo.NewWebhookRepo(db, trmsqlx.DefaultCtxGetter)
+	identityRepo := repo.NewIdentityRepo(db, trmsqlx.DefaultCtxGetter)
+	exclusionRepo := repo.NewExclusionRepo(db, trmsqlx.DefaultCtxGetter)
+
+	selector, err := setupSelector(cfg.Reviewers, prRepo, teamRepo)
+	if err != nil {
+		log.Error("failed to setup reviewer selector", sl.Err(err))
+		os.Exit(1) //nolint:gocritic
+	}
 
-	teamService := team.NewTeamService(trManager, teamRepo, userRepo)
-	userService := user.NewUserService(trManager, prRepo, userRepo, teamRepo)
-	prService := pr.NewPullRequestService(trManager, prRepo, prRepo, userRepo)
+	prService := pr.NewPullRequestService(trManager, prRepo, prRepo, userRepo, teamRepo, selector, pr.Options{
+		CodeOwners: codeOwnersRepo,
+		Events:     historyRepo,
+		Outbox:     webhookRepo,
+		Exclusions: exclusionRepo,
+		Capacities: userRepo,
+	})
+	teamService := team.NewTeamService(trManager, teamRepo, userRepo, prService, historyRepo, prRepo)
+	userService := user.NewUserService(trManager, prRepo, userRepo, teamRepo, historyRepo)
 	statsService := stats.NewStatsService(trManager, statsRepo)
+	codeOwnersService := codeowners.NewCodeOwnersService(trManager, codeOwnersRepo, userRepo, teamRepo)
+	absenceService := absence.NewAbsenceService(trManager, absenceRepo, userRepo, prService)
+	historyService := history.NewHistoryService(trManager, historyRepo, prRepo, userRepo)
+	webhookService := webhook.NewWebhookService(trManager, webhookRepo)
+	ingestService := ingest.NewIngestService(trManager, identityRepo, userRepo, prService)
+	exclusionService := exclusion.NewExclusionService(trManager, exclusionRepo, userRepo)
 
 	teamHandler := teamh.NewTeamHandler(log, teamService)
 	userHandler := userh.NewUserHandler(log, userService)
 	prHandler := prh.NewPrHandler(log, prService)
 	statsHandler := statsh.NewStatsHandler(log, statsService)
+	codeOwnersHandler := codeownersh.NewCodeOwnersHandler(log, codeOwnersService)
+	absenceHandler := absenceh.NewAbsenceHandler(log, absenceService)
+	historyHandler := historyh.NewHistoryHandler(log, historyService)
+	webhookHandler := webhookh.NewWebhookHandler(log, webhookService)
+	ingestHandler := ingesth.NewIngestHandler(
+		log, ingestService, os.Getenv("GITHUB_WEBHOOK_SECRET"), os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+	)
+	exclusionHandler := exclusionh.NewExclusionHandler(log, exclusionService)
 
 	router := chi.NewRouter()
 
@@ -94,13 +140,28 @@ func main() {
 	router.Get("/health", handlers.Healthcheck())
 	router.Post("/team/add", teamHandler.Add)
 
+	// входящие вебхуки проверяются секретом системы, а не JWT
+	router.Post("/webhooks/github", ingestHandler.GitHub)
+	router.Post("/webhooks/gitlab", ingestHandler.GitLab)
+
 	// user methods
 	router.Group(func(r chi.Router) {
 		r.Use(mw.AuthMiddleware)
 
 		r.Get("/team/get", teamHandler.Get)
+		r.Get("/team/settings", teamHandler.GetSettings)
+		r.Get("/team/pairings", teamHandler.GetPairings)
 		r.Get("/users/getReview", userHandler.GetReview)
+		r.Get("/users/history", historyHandler.UserHistory)
+		r.Get("/pullRequest/history", historyHandler.PrHistory)
+		r.Post("/pullRequest/review", prHandler.Review)
+		r.Post("/pullRequest/previewAssignment", prHandler.PreviewAssignment)
+		r.Get("/pullRequest/get", prHandler.Get)
+		r.Get("/pullRequest/list", prHandler.List)
+		r.Get("/pullRequest/search", prHandler.Search)
 		r.Get("/stats", statsHandler.GetStatistics)
+		r.Get("/codeOwners/list", codeOwnersHandler.List)
+		r.Get("/absences/list", absenceHandler.List)
 	})
 
 	// admin methods
@@ -108,10 +169,41 @@ func main() {
 		r.Use(mw.AuthMiddleware)
 		r.Use(mw.AdminOnly)
 
+		r.Post("/team/settings", teamHandler.SetSettings)
+		r.Post("/team/deactivateUsers", teamHandler.DeactivateUsers)
 		r.Post("/users/setIsActive", userHandler.SetIsActive)
+		r.Post("/users/setSkills", userHandler.SetSkills)
+		r.Post("/users/setSeniority", userHandler.SetSeniority)
+		r.Post("/users/setMaxOpenReviews", userHandler.SetMaxOpenReviews)
 		r.Post("/pullRequest/create", prHandler.Create)
 		r.Post("/pullRequest/merge", prHandler.Merge)
 		r.Post("/pullRequest/reassign", prHandler.Reassign)
+		r.Post("/pullRequest/ready", prHandler.Ready)
+		r.Post("/pullRequest/close", prHandler.Close)
+		r.Post("/pullRequest/reopen", prHandler.Reopen)
+		r.Post("/pullRequest/topUpReviewers", prHandler.TopUpReviewers)
+		r.Post("/pullRequest/addReviewer", prHandler.AddReviewer)
+		r.Post("/pullRequest/removeReviewer", prHandler.RemoveReviewer)
+		r.Post("/pullRequest/setLabels", prHandler.SetLabels)
+		r.Post("/codeOwners/set", codeOwnersHandler.SetRule)
+		r.Post("/codeOwners/delete", codeOwnersHandler.DeleteRule)
+		r.Post("/codeOwners/import", codeOwnersHandler.Import)
+		r.Post("/absences/add", absenceHandler.Add)
+		r.Post("/absences/delete", absenceHandler.Delete)
+		r.Post("/absences/import", absenceHandler.Import)
+		r.Get("/webhooks/list", webhookHandler.List)
+		r.Post("/webhooks/add", webhookHandler.Add)
+		r.Post("/webhooks/delete", webhookHandler.Delete)
+		r.Get("/webhooks/deliveries", webhookHandler.Deliveries)
+		r.Post("/webhooks/replay", webhookHandler.Replay)
+		r.Get("/identities/list", ingestHandler.ListIdentities)
+		r.Post("/identities/set", ingestHandler.SetIdentity)
+		r.Post("/identities/delete", ingestHandler.DeleteIdentity)
+		r.Get("/exclusions/list", exclusionHandler.List)
+		r.Post("/exclusions/addPair", exclusionHandler.AddPair)
+		r.Post("/exclusions/deletePair", exclusionHandler.DeletePair)
+		r.Post("/exclusions/setGroup", exclusionHandler.SetGroup)
+		r.Post("/exclusions/deleteGroup", exclusionHandler.DeleteGroup)
 	})
 
 	srv := &http.Server{
@@ -122,6 +214,37 @@ func main() {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
+	// background workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	if cfg.Absences.CheckInterval > 0 {
+		absenceWorker := absence.NewWorker(log, absenceService, cfg.Absences.CheckInterval)
+		workers.Go(func() { absenceWorker.Run(workersCtx) })
+	}
+
+	slaPolicy := pr.SLAPolicy{Default: cfg.ReviewSLA.Default, Teams: cfg.ReviewSLA.Teams}
+	if cfg.ReviewSLA.ScanInterval > 0 && slaPolicy.Enabled() {
+		slaWorker := pr.NewSLAWorker(log, prService, slaPolicy, cfg.ReviewSLA.ScanInterval)
+		workers.Go(func() { slaWorker.Run(workersCtx) })
+	}
+
+	if cfg.Webhooks.DispatchInterval > 0 {
+		dispatcher := webhook.NewDispatcher(
+			log,
+			webhookRepo,
+			webhook.RetryPolicy{
+				MaxAttempts:    cfg.Webhooks.MaxAttempts,
+				InitialBackoff: cfg.Webhooks.InitialBackoff,
+				MaxBackoff:     cfg.Webhooks.MaxBackoff,
+			},
+			cfg.Webhooks.BatchSize,
+			cfg.Webhooks.Timeout,
+			cfg.Webhooks.DispatchInterval,
+		)
+		workers.Go(func() { dispatcher.Run(workersCtx) })
+	}
+
 	serverErrCh := make(chan error, 1)
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -154,6 +277,10 @@ func main() {
 		log.Info("http server exited", slog.Any("err", err))
 	}
 
+	stopWorkers()
+	workers.Wait()
+	log.Info("background workers stopped")
+
 	log.Info("application shutdown complete")
 }
 
@@ -172,6 +299,28 @@ func setupLogger(env string) *slog.Logger {
 	return log
 }
 
+func setupSelector(
+	cfg config.Reviewers,
+	loads pr.LoadProvider,
+	teamNames pr.TeamNameProvider,
+) (pr.ReviewerSelector, error) {
+	def, err := pr.NewSelector(cfg.Strategy, loads)
+	if err != nil {
+		return nil, err
+	}
+
+	byTeam := make(map[string]pr.ReviewerSelector, len(cfg.Teams))
+	for teamName, strategy := range cfg.Teams {
+		selector, err := pr.NewSelector(strategy, loads)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", teamName, err)
+		}
+		byTeam[teamName] = selector
+	}
+
+	return pr.NewTeamSelector(teamNames, def, byTeam), nil
+}
+
 func runMigrations(dsn string, log *slog.Logger) error {
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
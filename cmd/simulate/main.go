@@ -0,0 +1,126 @@
+// Симулятор стратегий назначения ревьюеров на исторических данных.
+//
+//	go run ./cmd/simulate -strategy least_loaded,history
+//	go run ./cmd/simulate -snapshot snapshot.json -strategy all -json
+//
+// Без -snapshot данные читаются из базы по DATABASE_URL, -dump сохраняет их в файл для повторных прогонов.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/simulate"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	strategies := flag.String("strategy", "all",
+		"comma-separated strategies: "+strings.Join(simulate.Strategies, ", ")+" or all")
+	snapshotPath := flag.String("snapshot", "", "JSON snapshot to replay instead of the database")
+	dumpPath := flag.String("dump", "", "write the loaded snapshot to this file")
+	window := flag.Int("window", simulate.DefaultRotationWindow, "rotation window in author's last PRs for the history strategy")
+	asJSON := flag.Bool("json", false, "print reports as JSON")
+	flag.Parse()
+
+	if err := run(*strategies, *snapshotPath, *dumpPath, *window, *asJSON); err != nil {
+		fmt.Fprintln(os.Stderr, "simulate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(strategies, snapshotPath, dumpPath string, window int, asJSON bool) error {
+	ctx := context.Background()
+
+	names := simulate.Strategies
+	if strategies != "all" {
+		names = strings.Split(strategies, ",")
+		for _, name := range names {
+			if !slices.Contains(simulate.Strategies, name) {
+				return fmt.Errorf("unknown strategy %q", name)
+			}
+		}
+	}
+
+	snapshot, err := loadSnapshot(ctx, snapshotPath)
+	if err != nil {
+		return err
+	}
+
+	if dumpPath != "" {
+		if err := writeJSON(dumpPath, snapshot); err != nil {
+			return err
+		}
+	}
+
+	reports := make([]*simulate.Report, 0, len(names))
+	for _, name := range names {
+		report, err := simulate.Run(ctx, snapshot, simulate.Options{Strategy: name, RotationWindow: window})
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		reports = append(reports, report)
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	}
+
+	for i, report := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := report.WriteText(os.Stdout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSnapshot читает снапшот из файла, а без него - из базы по DATABASE_URL.
+func loadSnapshot(ctx context.Context, path string) (*models.Snapshot, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var snapshot models.Snapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return nil, fmt.Errorf("parse snapshot: %w", err)
+		}
+		return &snapshot, nil
+	}
+
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return nil, fmt.Errorf("either -snapshot or DATABASE_URL is required")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return repo.NewSnapshotRepo(db).Load(ctx)
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -2,17 +2,23 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // makeToken предоставляет удобную генерацию валидных JWT.
-func makeToken(secret string, role string) string {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+// subject - id пользователя, от имени которого оставляются вердикты, может быть пустым.
+func makeToken(secret string, role string, subject string) string {
+	claims := jwt.MapClaims{
 		"role": role,
 		"exp":  time.Now().Add(365 * 24 * time.Hour).Unix(),
-	})
+	}
+	if subject != "" {
+		claims["sub"] = subject
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	s, err := t.SignedString([]byte(secret))
 	if err != nil {
@@ -25,6 +31,12 @@ func main() {
 	adminSecret := "admin_secret_key"
 	userSecret := "user_secret_key"
 
-	fmt.Println("ADMIN_TOKEN=" + makeToken(adminSecret, "admin"))
-	fmt.Println("USER_TOKEN=" + makeToken(userSecret, "user"))
+	// первый аргумент - id пользователя для subject токенов
+	subject := ""
+	if len(os.Args) > 1 {
+		subject = os.Args[1]
+	}
+
+	fmt.Println("ADMIN_TOKEN=" + makeToken(adminSecret, "admin", subject))
+	fmt.Println("USER_TOKEN=" + makeToken(userSecret, "user", subject))
 }
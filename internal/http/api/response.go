@@ -17,12 +17,23 @@ const (
 	ErrCodePRMerged    = "PR_MERGED"
 	ErrCodeNotAssigned = "NOT_ASSIGNED"
 	ErrCodeNoCandidate = "NO_CANDIDATE"
+	ErrCodePRClosed    = "PR_CLOSED"
+	ErrCodeTransition  = "INVALID_TRANSITION"
+	ErrCodeNoApprovals = "NOT_ENOUGH_APPROVALS"
+	ErrCodeForbidden   = "FORBIDDEN"
+
+	ErrCodeInvalidSignature = "INVALID_SIGNATURE"
+	ErrCodeExcludedByRules  = "EXCLUDED_BY_RULES"
 )
 
 type TeamResponse struct {
 	Team TeamSchema `json:"team"`
 }
 
+type TeamSettingsResponse struct {
+	Settings TeamSettingsSchema `json:"settings"`
+}
+
 type UserResponse struct {
 	User UserSchema `json:"user"`
 }
@@ -31,9 +42,32 @@ type PrResponse struct {
 	PullRequest PullRequestSchema `json:"pr"`
 }
 
+type TopUpResponse struct {
+	PullRequests []PullRequestSchema `json:"pull_requests"`
+}
+
+type PrSearchResponse struct {
+	Query        string           `json:"query"`
+	PullRequests []PrSearchResult `json:"pull_requests"`
+}
+
+// PrSearchResult - найденный PR, Rank - релевантность названия запросу, больше - выше в выдаче.
+type PrSearchResult struct {
+	PullRequestSchema
+	Rank float64 `json:"rank"`
+}
+
+type PrListResponse struct {
+	PullRequests []PullRequestSchema `json:"pull_requests"`
+	// NextCursor - курсор следующей страницы, пустой на последней странице
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 type GetReviewResponse struct {
-	UserID       string             `json:"user_id"`
-	PullRequests []PullRequestShort `json:"pull_requests"`
+	UserID         string             `json:"user_id"`
+	PullRequests   []PullRequestShort `json:"pull_requests"`
+	OpenReviews    int                `json:"open_reviews"`
+	MaxOpenReviews *int               `json:"max_open_reviews"`
 }
 
 type ReassignResponse struct {
@@ -41,6 +75,86 @@ type ReassignResponse struct {
 	ReplacedBy  string            `json:"replaced_by"`
 }
 
+type DeactivateUsersResponse struct {
+	TeamName         string              `json:"team_name"`
+	DeactivatedUsers []string            `json:"deactivated_users"`
+	Reassigned       []ReviewReplacement `json:"reassigned"`
+	// Understaffed - PR, у которых для снятого ревьюера не нашлось замены
+	Understaffed []string `json:"understaffed_pull_requests"`
+}
+
+type CodeOwnersResponse struct {
+	Rules []CodeOwnerRuleSchema `json:"rules"`
+}
+
+type CodeOwnersImportResponse struct {
+	Rules []CodeOwnerRuleSchema `json:"rules"`
+	// UnknownOwners - владельцы из файла, которых нет среди пользователей и команд
+	UnknownOwners []string `json:"unknown_owners"`
+}
+
+type AbsencesResponse struct {
+	Absences []AbsenceSchema `json:"absences"`
+}
+
+type AbsenceResponse struct {
+	Absence AbsenceSchema `json:"absence"`
+	// Reassigned и Understaffed заполняются, если отсутствие уже началось и ревью сняты сразу
+	Reassigned   []ReviewReplacement `json:"reassigned,omitempty"`
+	Understaffed []string            `json:"understaffed_pull_requests,omitempty"`
+}
+
+type AbsenceImportResponse struct {
+	Absences []AbsenceSchema `json:"absences"`
+	// Skipped - число уже закончившихся событий календаря, они не импортируются
+	Skipped      int                 `json:"skipped"`
+	Reassigned   []ReviewReplacement `json:"reassigned,omitempty"`
+	Understaffed []string            `json:"understaffed_pull_requests,omitempty"`
+}
+
+type PrHistoryResponse struct {
+	PullRequestID string              `json:"pull_request_id"`
+	Events        []ReviewEventSchema `json:"events"`
+}
+
+type UserHistoryResponse struct {
+	UserID string              `json:"user_id"`
+	Events []ReviewEventSchema `json:"events"`
+}
+
+type WebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscriptionSchema `json:"subscriptions"`
+}
+
+type WebhookSubscriptionResponse struct {
+	Subscription WebhookSubscriptionSchema `json:"subscription"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliverySchema `json:"deliveries"`
+}
+
+type WebhookReplayResponse struct {
+	Replayed int `json:"replayed"`
+}
+
+type IdentitiesResponse struct {
+	Identities []ExternalIdentitySchema `json:"identities"`
+}
+
+// IngestResponse - результат обработки входящего вебхука. PullRequest пуст, если событие пропущено.
+type IngestResponse struct {
+	// Action - что сделано: created, merged, closed, reopened, ready или ignored
+	Action      string             `json:"action"`
+	PullRequest *PullRequestSchema `json:"pr,omitempty"`
+}
+
+// ExclusionsResponse - все действующие правила исключения ревьюеров.
+type ExclusionsResponse struct {
+	Pairs  []ReviewExclusionSchema `json:"pairs"`
+	Groups []ExclusionGroupSchema  `json:"groups"`
+}
+
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
 }
@@ -50,6 +164,35 @@ type ErrorDetail struct {
 	Message string `json:"message"`
 }
 
+// ExcludedCandidate - участник команды, не попавший в пул кандидатов, и причина.
+type ExcludedCandidate struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+// AssignmentPreviewResponse - результат подбора ревьюеров без записи. Error заполнен,
+// если настоящий вызов завершился бы ошибкой подбора (NO_CANDIDATE, EXCLUDED_BY_RULES).
+type AssignmentPreviewResponse struct {
+	AuthorID          string              `json:"author_id"`
+	ReplacedReviewer  string              `json:"replaced_reviewer,omitempty"`
+	Eligible          []string            `json:"eligible"`
+	Excluded          []ExcludedCandidate `json:"excluded"`
+	CodeOwners        []string            `json:"code_owners,omitempty"`
+	Picked            []string            `json:"picked"`
+	ReviewerLoads     map[string]int      `json:"reviewer_loads,omitempty"`
+	NeedMoreReviewers bool                `json:"need_more_reviewers"`
+	Error             *ErrorDetail        `json:"error,omitempty"`
+}
+
+// PairingMatrixResponse - Matrix[author][reviewer] - сколько PR автора ревьюил ревьюер.
+type PairingMatrixResponse struct {
+	TeamName string                    `json:"team_name"`
+	Days     *int                      `json:"days,omitempty"`
+	Members  []string                  `json:"members"`
+	Matrix   map[string]map[string]int `json:"matrix"`
+	Total    int                       `json:"total"`
+}
+
 type StatsResponse struct {
 	Pr   PrStats     `json:"pr"`
 	User []UserStats `json:"users"`
@@ -59,12 +202,17 @@ type UserStats struct {
 	UserID          string `json:"user_id"`
 	Username        string `json:"username"`
 	AssignmentCount int    `json:"assignment_count"`
+	OpenReviewCount int    `json:"open_review_count"`
+	MaxOpenReviews  *int   `json:"max_open_reviews"`
+	OverLimit       bool   `json:"over_limit"`
 }
 
 type PrStats struct {
 	PrCount   int `json:"pr_count"`
 	OpenPrs   int `json:"open_pr_count"`
 	MergedPrs int `json:"merged_pr_count"`
+	ClosedPrs int `json:"closed_pr_count"`
+	DraftPrs  int `json:"draft_pr_count"`
 }
 
 func Error(code string, msg string) ErrorResponse {
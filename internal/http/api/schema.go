@@ -1,12 +1,18 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type UserSchema struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	TeamName       string   `json:"team_name"`
+	IsActive       bool     `json:"is_active"`
+	Skills         []string `json:"skills,omitempty"`
+	Seniority      string   `json:"seniority,omitempty"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
 }
 
 type TeamSchema struct {
@@ -18,6 +24,42 @@ type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"  validate:"max=16"`
 	IsActive bool   `json:"is_active"`
+	// Seniority - junior, middle или senior; пустой при добавлении - middle для нового пользователя
+	Seniority string `json:"seniority,omitempty" validate:"omitempty,oneof=junior middle senior"`
+}
+
+type TeamSettingsSchema struct {
+	TeamName          string `json:"team_name"`
+	ReviewersCount    int    `json:"reviewers_count"`
+	MaxOpenReviews    *int   `json:"max_open_reviews"`
+	RequiredApprovals *int   `json:"required_approvals"`
+
+	MinSeniorReviewers     *int `json:"min_senior_reviewers"`
+	MinJuniorReviewers     *int `json:"min_junior_reviewers"`
+	MaxJuniorReviewers     *int `json:"max_junior_reviewers"`
+	SeniorForJuniorAuthors bool `json:"senior_for_junior_authors"`
+
+	RotationWindowPRs  *int `json:"rotation_window_prs"`
+	RotationWindowDays *int `json:"rotation_window_days"`
+}
+
+// TeamSettingsUpdate - частичное обновление настроек команды: nil оставляет сохраненное значение,
+// Reset перечисляет поля (по имени в JSON), которые сбрасываются к значению по умолчанию.
+type TeamSettingsUpdate struct {
+	TeamName          string
+	ReviewersCount    *int
+	MaxOpenReviews    *int
+	RequiredApprovals *int
+
+	MinSeniorReviewers     *int
+	MinJuniorReviewers     *int
+	MaxJuniorReviewers     *int
+	SeniorForJuniorAuthors *bool
+
+	RotationWindowPRs  *int
+	RotationWindowDays *int
+
+	Reset []string
 }
 
 type PullRequestSchema struct {
@@ -26,12 +68,129 @@ type PullRequestSchema struct {
 	AuthorID          string     `json:"author_id"`
 	Status            string     `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
+	NeedMoreReviewers bool       `json:"need_more_reviewers"`
 	MergedAt          *time.Time `json:"merged_at,omitempty"`
+	ClosedAt          *time.Time `json:"closed_at,omitempty"`
+	Labels            []string   `json:"labels,omitempty"`
+	// ReviewerLoads - число открытых ревью у назначенных ревьюеров на момент назначения
+	ReviewerLoads map[string]int `json:"reviewer_loads,omitempty"`
+	// Verdicts - текущие вердикты ревьюеров, ревьюеров без вердикта в нем нет
+	Verdicts map[string]VerdictSchema `json:"verdicts,omitempty"`
+}
+
+type VerdictSchema struct {
+	Verdict     string     `json:"verdict"`
+	Comment     string     `json:"comment,omitempty"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+}
+
+type ReviewReplacement struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	ReplacedBy    string `json:"replaced_by"`
 }
 
 type PullRequestShort struct {
-	ID       string `json:"pull_request_id"`
-	Name     string `json:"pull_request_name"`
-	AuthorID string `json:"author_id"`
-	Status   string `json:"status"`
+	ID       string   `json:"pull_request_id"`
+	Name     string   `json:"pull_request_name"`
+	AuthorID string   `json:"author_id"`
+	Status   string   `json:"status"`
+	Labels   []string `json:"labels,omitempty"`
+}
+
+// CodeOwnerRuleSchema - правило CODEOWNERS: шаблон пути и его владельцы.
+type CodeOwnerRuleSchema struct {
+	Pattern   string   `json:"pattern"`
+	UserIDs   []string `json:"user_ids"`
+	TeamNames []string `json:"team_names"`
+}
+
+// AbsenceSchema - отсутствие пользователя, даты в формате YYYY-MM-DD включительно.
+type AbsenceSchema struct {
+	ID              int    `json:"absence_id"`
+	UserID          string `json:"user_id"`
+	StartsOn        string `json:"starts_on"`
+	EndsOn          string `json:"ends_on"`
+	Reason          string `json:"reason,omitempty"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+	// ReviewsReassignedAt - когда пользователь был снят с открытых ревью по этому отсутствию
+	ReviewsReassignedAt *time.Time `json:"reviews_reassigned_at,omitempty"`
+}
+
+// ReviewEventSchema - запись журнала изменений ревью. Заполнены только поля, относящиеся к типу события.
+type ReviewEventSchema struct {
+	ID            int64     `json:"event_id"`
+	Type          string    `json:"event_type"`
+	PullRequestID string    `json:"pull_request_id,omitempty"`
+	UserID        string    `json:"user_id,omitempty"`
+	OldReviewerID string    `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string    `json:"new_reviewer_id,omitempty"`
+	Status        string    `json:"status,omitempty"`
+	IsActive      *bool     `json:"is_active,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+	Actor         string    `json:"actor"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// WebhookSubscriptionSchema - подписка на вебхуки. Secret возвращается только при создании.
+type WebhookSubscriptionSchema struct {
+	ID         int        `json:"subscription_id"`
+	URL        string     `json:"url"`
+	Secret     string     `json:"secret,omitempty"`
+	EventTypes []string   `json:"event_types"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+}
+
+type WebhookDeliverySchema struct {
+	ID             int64      `json:"delivery_id"`
+	EventID        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	SubscriptionID int        `json:"subscription_id"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+}
+
+// WebhookEvent - тело запроса, которое получает подписчик. Одно событие может прийти
+// повторно, подписчику стоит отбрасывать дубли по EventID.
+type WebhookEvent struct {
+	EventID   int64           `json:"event_id"`
+	EventType string          `json:"event_type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// PrEventData - данные событий о PR: PR после изменения и, для переназначения, замена.
+type PrEventData struct {
+	PullRequest   PullRequestSchema `json:"pull_request"`
+	OldReviewerID string            `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string            `json:"new_reviewer_id,omitempty"`
+	Reason        string            `json:"reason,omitempty"`
+}
+
+// ExternalIdentitySchema - привязка логина во внешней системе к пользователю сервиса.
+type ExternalIdentitySchema struct {
+	Provider  string     `json:"provider"`
+	Login     string     `json:"login"`
+	UserID    string     `json:"user_id"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// ReviewExclusionSchema - запрет назначать ReviewerID ревьюером на PR автора AuthorID.
+type ReviewExclusionSchema struct {
+	AuthorID   string     `json:"author_id"`
+	ReviewerID string     `json:"reviewer_id"`
+	Reason     string     `json:"reason,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+}
+
+// ExclusionGroupSchema - подгруппа, участники которой не ревьюят PR друг друга.
+type ExclusionGroupSchema struct {
+	Name      string     `json:"name"`
+	Reason    string     `json:"reason,omitempty"`
+	UserIDs   []string   `json:"user_ids"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
 }
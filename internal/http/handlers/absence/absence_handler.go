@@ -0,0 +1,203 @@
+package absence
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/sl"
+	repo "avito-intership-2025/internal/repository"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type absenceService interface {
+	List(ctx context.Context, userID string) ([]api.AbsenceSchema, error)
+	Add(ctx context.Context, input *api.AbsenceSchema) (*api.AbsenceResponse, error)
+	Delete(ctx context.Context, id int) ([]api.AbsenceSchema, error)
+	Import(ctx context.Context, userID string, content string, reassignReviews bool) (*api.AbsenceImportResponse, error)
+}
+
+type AbsenceHandler struct {
+	log     *slog.Logger
+	service absenceService
+}
+
+func NewAbsenceHandler(log *slog.Logger, s absenceService) *AbsenceHandler {
+	return &AbsenceHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+func (h *AbsenceHandler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.absence.List"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	// user_id необязателен: без него возвращаются отсутствия всех пользователей
+	userID := r.URL.Query().Get("user_id")
+
+	absences, err := h.service.List(r.Context(), userID)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	render.JSON(w, r, api.AbsencesResponse{Absences: absences})
+}
+
+type AddRequest struct {
+	UserID   string `json:"user_id"   validate:"required"`
+	StartsOn string `json:"starts_on" validate:"required,datetime=2006-01-02"`
+	EndsOn   string `json:"ends_on"   validate:"required,datetime=2006-01-02"`
+	Reason   string `json:"reason"    validate:"max=255"`
+	// ReassignReviews - снять пользователя с открытых ревью, когда отсутствие начнется
+	ReassignReviews bool `json:"reassign_reviews"`
+}
+
+func (h *AbsenceHandler) Add(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.absence.Add"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input AddRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	resp, err := h.service.Add(ctx, &api.AbsenceSchema{
+		UserID:          input.UserID,
+		StartsOn:        input.StartsOn,
+		EndsOn:          input.EndsOn,
+		Reason:          input.Reason,
+		ReassignReviews: input.ReassignReviews,
+	})
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("absence added",
+		slog.Int("absence_id", resp.Absence.ID),
+		slog.String("user_id", input.UserID),
+		slog.Int("reassigned", len(resp.Reassigned)),
+	)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+type DeleteRequest struct {
+	AbsenceID int `json:"absence_id" validate:"required"`
+}
+
+func (h *AbsenceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.absence.Delete"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input DeleteRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	absences, err := h.service.Delete(ctx, input.AbsenceID)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("absence deleted", slog.Int("absence_id", input.AbsenceID))
+	render.JSON(w, r, api.AbsencesResponse{Absences: absences})
+}
+
+type ImportRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+	// Content - содержимое календаря .ics
+	Content         string `json:"content" validate:"required"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+func (h *AbsenceHandler) Import(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.absence.Import"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input ImportRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	resp, err := h.service.Import(ctx, input.UserID, input.Content, input.ReassignReviews)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("absences imported",
+		slog.String("user_id", input.UserID),
+		slog.Int("absences", len(resp.Absences)),
+		slog.Int("skipped", resp.Skipped),
+	)
+	render.JSON(w, r, resp)
+}
+
+// decode читает и валидирует тело запроса, при ошибке сам пишет ответ.
+func (h *AbsenceHandler) decode(w http.ResponseWriter, r *http.Request, log *slog.Logger, input any) bool {
+	if err := render.DecodeJSON(r.Body, input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return false
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return false
+	}
+
+	return true
+}
+
+func (h *AbsenceHandler) renderError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, repo.ErrInvalidAbsence):
+		log.Info("invalid absence", sl.Err(err))
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, err.Error()))
+
+	case errors.Is(err, repo.ErrNotFound):
+		log.Info("resource not found", sl.Err(err))
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+	default:
+		log.Error("error while handling absences", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+	}
+}
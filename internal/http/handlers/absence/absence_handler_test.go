@@ -0,0 +1,173 @@
+package absence_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/http/handlers"
+	"avito-intership-2025/internal/http/handlers/absence"
+	"avito-intership-2025/internal/http/handlers/mocks"
+	repo "avito-intership-2025/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAbsenceHandler_List_Success(t *testing.T) {
+	mockService := mocks.NewMockAbsenceService(t)
+	h := absence.NewAbsenceHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/absences/list?user_id=u1", nil)
+	w := httptest.NewRecorder()
+
+	expected := []api.AbsenceSchema{
+		{ID: 1, UserID: "u1", StartsOn: "2025-07-01", EndsOn: "2025-07-14", Reason: "vacation"},
+	}
+	mockService.On("List", mock.Anything, "u1").Return(expected, nil)
+
+	h.List(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.AbsencesResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp.Absences)
+}
+
+func TestAbsenceHandler_Add_Success(t *testing.T) {
+	mockService := mocks.NewMockAbsenceService(t)
+	h := absence.NewAbsenceHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","starts_on":"2025-07-01","ends_on":"2025-07-14","reassign_reviews":true}`)
+	req := httptest.NewRequest(http.MethodPost, "/absences/add", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expected := &api.AbsenceResponse{
+		Absence: api.AbsenceSchema{
+			ID:              1,
+			UserID:          "u1",
+			StartsOn:        "2025-07-01",
+			EndsOn:          "2025-07-14",
+			ReassignReviews: true,
+		},
+	}
+	mockService.On("Add", mock.Anything, &api.AbsenceSchema{
+		UserID:          "u1",
+		StartsOn:        "2025-07-01",
+		EndsOn:          "2025-07-14",
+		ReassignReviews: true,
+	}).Return(expected, nil)
+
+	h.Add(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp api.AbsenceResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp)
+}
+
+func TestAbsenceHandler_Add_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockAbsenceService(t)
+	h := absence.NewAbsenceHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","starts_on":"01.07.2025","ends_on":"2025-07-14"}`)
+	req := httptest.NewRequest(http.MethodPost, "/absences/add", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.Add(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestAbsenceHandler_Add_Errors(t *testing.T) {
+	cases := []struct {
+		err      error
+		wantCode int
+		wantErr  string
+	}{
+		{fmt.Errorf("%w: ends_on is before starts_on", repo.ErrInvalidAbsence), http.StatusBadRequest, api.ErrBadRequest},
+		{repo.ErrNotFound, http.StatusNotFound, api.ErrCodeNotFound},
+		{errors.New("db error"), http.StatusInternalServerError, api.ErrInternalErr},
+	}
+
+	for _, tc := range cases {
+		mockService := mocks.NewMockAbsenceService(t)
+		h := absence.NewAbsenceHandler(handlers.NewLogger(), mockService)
+
+		body := []byte(`{"user_id":"u1","starts_on":"2025-07-14","ends_on":"2025-07-01"}`)
+		req := httptest.NewRequest(http.MethodPost, "/absences/add", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		mockService.On("Add", mock.Anything, mock.AnythingOfType("*api.AbsenceSchema")).Return(nil, tc.err)
+
+		h.Add(w, req)
+
+		assert.Equal(t, tc.wantCode, w.Code)
+		resp := handlers.DecodeErrorResponse(t, w.Body)
+		assert.Equal(t, tc.wantErr, resp.Error.Code)
+	}
+}
+
+func TestAbsenceHandler_Delete_NotFound(t *testing.T) {
+	mockService := mocks.NewMockAbsenceService(t)
+	h := absence.NewAbsenceHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"absence_id":42}`)
+	req := httptest.NewRequest(http.MethodPost, "/absences/delete", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("Delete", mock.Anything, 42).Return(nil, repo.ErrNotFound)
+
+	h.Delete(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
+
+func TestAbsenceHandler_Import_Success(t *testing.T) {
+	mockService := mocks.NewMockAbsenceService(t)
+	h := absence.NewAbsenceHandler(handlers.NewLogger(), mockService)
+
+	content := "BEGIN:VCALENDAR\nEND:VCALENDAR"
+	body, _ := json.Marshal(absence.ImportRequest{UserID: "u1", Content: content})
+	req := httptest.NewRequest(http.MethodPost, "/absences/import", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expected := &api.AbsenceImportResponse{Absences: []api.AbsenceSchema{}, Skipped: 2}
+	mockService.On("Import", mock.Anything, "u1", content, false).Return(expected, nil)
+
+	h.Import(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.AbsenceImportResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp)
+}
+
+func TestAbsenceHandler_Import_InvalidCalendar(t *testing.T) {
+	mockService := mocks.NewMockAbsenceService(t)
+	h := absence.NewAbsenceHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","content":"garbage"}`)
+	req := httptest.NewRequest(http.MethodPost, "/absences/import", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("Import", mock.Anything, "u1", "garbage", false).
+		Return(nil, fmt.Errorf("%w: line 1: invalid content line", repo.ErrInvalidAbsence))
+
+	h.Import(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrBadRequest, resp.Error.Code)
+}
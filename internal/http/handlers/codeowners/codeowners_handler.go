@@ -0,0 +1,189 @@
+package codeowners
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/sl"
+	repo "avito-intership-2025/internal/repository"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type codeOwnersService interface {
+	List(ctx context.Context) ([]api.CodeOwnerRuleSchema, error)
+	SetRule(ctx context.Context, rule *api.CodeOwnerRuleSchema) ([]api.CodeOwnerRuleSchema, error)
+	DeleteRule(ctx context.Context, pattern string) ([]api.CodeOwnerRuleSchema, error)
+	Import(ctx context.Context, content string) (*api.CodeOwnersImportResponse, error)
+}
+
+type CodeOwnersHandler struct {
+	log     *slog.Logger
+	service codeOwnersService
+}
+
+func NewCodeOwnersHandler(log *slog.Logger, s codeOwnersService) *CodeOwnersHandler {
+	return &CodeOwnersHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+func (h *CodeOwnersHandler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.codeowners.List"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	rules, err := h.service.List(r.Context())
+	if err != nil {
+		log.Error("error while retrieving code owners", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	render.JSON(w, r, api.CodeOwnersResponse{Rules: rules})
+}
+
+type SetRuleRequest struct {
+	Pattern   string   `json:"pattern"    validate:"required,max=255"`
+	UserIDs   []string `json:"user_ids"   validate:"omitempty,dive,required"`
+	TeamNames []string `json:"team_names" validate:"omitempty,dive,required,max=16"`
+}
+
+func (h *CodeOwnersHandler) SetRule(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.codeowners.SetRule"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input SetRuleRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	rules, err := h.service.SetRule(ctx, &api.CodeOwnerRuleSchema{
+		Pattern:   input.Pattern,
+		UserIDs:   input.UserIDs,
+		TeamNames: input.TeamNames,
+	})
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("code owners rule saved", slog.String("pattern", input.Pattern))
+	render.JSON(w, r, api.CodeOwnersResponse{Rules: rules})
+}
+
+type DeleteRuleRequest struct {
+	Pattern string `json:"pattern" validate:"required"`
+}
+
+func (h *CodeOwnersHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.codeowners.DeleteRule"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input DeleteRuleRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	rules, err := h.service.DeleteRule(ctx, input.Pattern)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("code owners rule deleted", slog.String("pattern", input.Pattern))
+	render.JSON(w, r, api.CodeOwnersResponse{Rules: rules})
+}
+
+type ImportRequest struct {
+	// Content - содержимое файла CODEOWNERS
+	Content string `json:"content" validate:"required"`
+}
+
+func (h *CodeOwnersHandler) Import(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.codeowners.Import"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input ImportRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	resp, err := h.service.Import(ctx, input.Content)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("code owners imported",
+		slog.Int("rules", len(resp.Rules)),
+		slog.Int("unknown_owners", len(resp.UnknownOwners)),
+	)
+	render.JSON(w, r, resp)
+}
+
+// decode читает и валидирует тело запроса, при ошибке сам пишет ответ.
+func (h *CodeOwnersHandler) decode(w http.ResponseWriter, r *http.Request, log *slog.Logger, input any) bool {
+	if err := render.DecodeJSON(r.Body, input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return false
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return false
+	}
+
+	return true
+}
+
+func (h *CodeOwnersHandler) renderError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, repo.ErrInvalidPattern):
+		log.Info("invalid pattern", sl.Err(err))
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, err.Error()))
+
+	case errors.Is(err, repo.ErrNotFound):
+		log.Info("resource not found", sl.Err(err))
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+	default:
+		log.Error("error while changing code owners", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+	}
+}
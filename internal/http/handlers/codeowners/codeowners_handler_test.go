@@ -0,0 +1,164 @@
+package codeowners_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/http/handlers"
+	"avito-intership-2025/internal/http/handlers/codeowners"
+	"avito-intership-2025/internal/http/handlers/mocks"
+	repo "avito-intership-2025/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCodeOwnersHandler_List_Success(t *testing.T) {
+	mockService := mocks.NewMockCodeOwnersService(t)
+	h := codeowners.NewCodeOwnersHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/codeOwners/list", nil)
+	w := httptest.NewRecorder()
+
+	expected := []api.CodeOwnerRuleSchema{
+		{Pattern: "*", UserIDs: []string{}, TeamNames: []string{"backend"}},
+	}
+	mockService.On("List", mock.Anything).Return(expected, nil)
+
+	h.List(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.CodeOwnersResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp.Rules)
+}
+
+func TestCodeOwnersHandler_List_InternalError(t *testing.T) {
+	mockService := mocks.NewMockCodeOwnersService(t)
+	h := codeowners.NewCodeOwnersHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/codeOwners/list", nil)
+	w := httptest.NewRecorder()
+
+	mockService.On("List", mock.Anything).Return(nil, errors.New("db error"))
+
+	h.List(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrInternalErr, resp.Error.Code)
+}
+
+func TestCodeOwnersHandler_SetRule_Success(t *testing.T) {
+	mockService := mocks.NewMockCodeOwnersService(t)
+	h := codeowners.NewCodeOwnersHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pattern":"/docs/","user_ids":["u1"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/codeOwners/set", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	rule := &api.CodeOwnerRuleSchema{Pattern: "/docs/", UserIDs: []string{"u1"}}
+	expected := []api.CodeOwnerRuleSchema{{Pattern: "/docs/", UserIDs: []string{"u1"}, TeamNames: []string{}}}
+	mockService.On("SetRule", mock.Anything, rule).Return(expected, nil)
+
+	h.SetRule(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.CodeOwnersResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp.Rules)
+}
+
+func TestCodeOwnersHandler_SetRule_Errors(t *testing.T) {
+	cases := []struct {
+		err      error
+		wantCode int
+		wantErr  string
+	}{
+		{fmt.Errorf("team ghost: %w", repo.ErrNotFound), http.StatusNotFound, api.ErrCodeNotFound},
+		{repo.ErrInvalidPattern, http.StatusBadRequest, api.ErrBadRequest},
+	}
+
+	for _, tc := range cases {
+		mockService := mocks.NewMockCodeOwnersService(t)
+		h := codeowners.NewCodeOwnersHandler(handlers.NewLogger(), mockService)
+
+		body := []byte(`{"pattern":"*","team_names":["ghost"]}`)
+		req := httptest.NewRequest(http.MethodPost, "/codeOwners/set", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		mockService.On("SetRule", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		h.SetRule(w, req)
+
+		assert.Equal(t, tc.wantCode, w.Code)
+		resp := handlers.DecodeErrorResponse(t, w.Body)
+		assert.Equal(t, tc.wantErr, resp.Error.Code)
+	}
+}
+
+func TestCodeOwnersHandler_SetRule_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockCodeOwnersService(t)
+	h := codeowners.NewCodeOwnersHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_ids":["u1"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/codeOwners/set", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetRule(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestCodeOwnersHandler_DeleteRule_NotFound(t *testing.T) {
+	mockService := mocks.NewMockCodeOwnersService(t)
+	h := codeowners.NewCodeOwnersHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pattern":"/nope/"}`)
+	req := httptest.NewRequest(http.MethodPost, "/codeOwners/delete", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("DeleteRule", mock.Anything, "/nope/").Return(nil, repo.ErrNotFound)
+
+	h.DeleteRule(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
+
+func TestCodeOwnersHandler_Import_Success(t *testing.T) {
+	mockService := mocks.NewMockCodeOwnersService(t)
+	h := codeowners.NewCodeOwnersHandler(handlers.NewLogger(), mockService)
+
+	content := "*  @acme/backend\n/docs/ @u1 @ghost\n"
+	body, _ := json.Marshal(codeowners.ImportRequest{Content: content})
+	req := httptest.NewRequest(http.MethodPost, "/codeOwners/import", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expected := &api.CodeOwnersImportResponse{
+		Rules: []api.CodeOwnerRuleSchema{
+			{Pattern: "*", UserIDs: []string{}, TeamNames: []string{"backend"}},
+			{Pattern: "/docs/", UserIDs: []string{"u1"}, TeamNames: []string{}},
+		},
+		UnknownOwners: []string{"@ghost"},
+	}
+	mockService.On("Import", mock.Anything, content).Return(expected, nil)
+
+	h.Import(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.CodeOwnersImportResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp)
+}
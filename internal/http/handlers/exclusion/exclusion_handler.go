@@ -0,0 +1,215 @@
+package exclusion
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/sl"
+	repo "avito-intership-2025/internal/repository"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type exclusionService interface {
+	List(ctx context.Context) (*api.ExclusionsResponse, error)
+	AddPair(ctx context.Context, input *api.ReviewExclusionSchema, mutual bool) (*api.ExclusionsResponse, error)
+	DeletePair(ctx context.Context, authorID, reviewerID string, mutual bool) (*api.ExclusionsResponse, error)
+	SetGroup(ctx context.Context, input *api.ExclusionGroupSchema) (*api.ExclusionsResponse, error)
+	DeleteGroup(ctx context.Context, name string) (*api.ExclusionsResponse, error)
+}
+
+type ExclusionHandler struct {
+	log     *slog.Logger
+	service exclusionService
+}
+
+func NewExclusionHandler(log *slog.Logger, s exclusionService) *ExclusionHandler {
+	return &ExclusionHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+func (h *ExclusionHandler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.exclusion.List"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	resp, err := h.service.List(r.Context())
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	render.JSON(w, r, resp)
+}
+
+type PairRequest struct {
+	AuthorID   string `json:"author_id"   validate:"required"`
+	ReviewerID string `json:"reviewer_id" validate:"required"`
+	Reason     string `json:"reason"      validate:"max=255"`
+	// Mutual - запрет в обе стороны
+	Mutual bool `json:"mutual"`
+}
+
+func (h *ExclusionHandler) AddPair(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.exclusion.AddPair"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input PairRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	resp, err := h.service.AddPair(r.Context(), &api.ReviewExclusionSchema{
+		AuthorID:   input.AuthorID,
+		ReviewerID: input.ReviewerID,
+		Reason:     input.Reason,
+	}, input.Mutual)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("exclusion pair added", slog.String("author_id", input.AuthorID), slog.String("reviewer_id", input.ReviewerID))
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+type DeletePairRequest struct {
+	AuthorID   string `json:"author_id"   validate:"required"`
+	ReviewerID string `json:"reviewer_id" validate:"required"`
+	Mutual     bool   `json:"mutual"`
+}
+
+func (h *ExclusionHandler) DeletePair(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.exclusion.DeletePair"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input DeletePairRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	resp, err := h.service.DeletePair(r.Context(), input.AuthorID, input.ReviewerID, input.Mutual)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("exclusion pair deleted", slog.String("author_id", input.AuthorID), slog.String("reviewer_id", input.ReviewerID))
+	render.JSON(w, r, resp)
+}
+
+type SetGroupRequest struct {
+	Name    string   `json:"name"     validate:"required,max=255"`
+	Reason  string   `json:"reason"   validate:"max=255"`
+	UserIDs []string `json:"user_ids" validate:"required,min=2,dive,required"`
+}
+
+func (h *ExclusionHandler) SetGroup(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.exclusion.SetGroup"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input SetGroupRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	resp, err := h.service.SetGroup(r.Context(), &api.ExclusionGroupSchema{
+		Name:    input.Name,
+		Reason:  input.Reason,
+		UserIDs: input.UserIDs,
+	})
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("exclusion group saved", slog.String("name", input.Name), slog.Int("users", len(input.UserIDs)))
+	render.JSON(w, r, resp)
+}
+
+type DeleteGroupRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func (h *ExclusionHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.exclusion.DeleteGroup"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input DeleteGroupRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	resp, err := h.service.DeleteGroup(r.Context(), input.Name)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("exclusion group deleted", slog.String("name", input.Name))
+	render.JSON(w, r, resp)
+}
+
+// decode читает и валидирует тело запроса, при ошибке сам пишет ответ.
+func (h *ExclusionHandler) decode(w http.ResponseWriter, r *http.Request, log *slog.Logger, input any) bool {
+	if err := render.DecodeJSON(r.Body, input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return false
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return false
+	}
+
+	return true
+}
+
+func (h *ExclusionHandler) renderError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, repo.ErrInvalidExclusion):
+		log.Info("invalid exclusion rule", sl.Err(err))
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, err.Error()))
+
+	case errors.Is(err, repo.ErrNotFound):
+		log.Info("resource not found", sl.Err(err))
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+	default:
+		log.Error("error while handling exclusion rules", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+	}
+}
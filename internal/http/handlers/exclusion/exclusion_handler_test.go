@@ -0,0 +1,110 @@
+package exclusion_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/http/handlers"
+	"avito-intership-2025/internal/http/handlers/exclusion"
+	"avito-intership-2025/internal/http/handlers/mocks"
+	repo "avito-intership-2025/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExclusionHandler_AddPair_Success(t *testing.T) {
+	mockService := mocks.NewMockExclusionService(t)
+	h := exclusion.NewExclusionHandler(handlers.NewLogger(), mockService)
+
+	body, _ := json.Marshal(exclusion.PairRequest{AuthorID: "u1", ReviewerID: "m1", Reason: "manager", Mutual: true})
+	req := httptest.NewRequest(http.MethodPost, "/exclusions/addPair", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expected := &api.ExclusionsResponse{
+		Pairs: []api.ReviewExclusionSchema{
+			{AuthorID: "m1", ReviewerID: "u1", Reason: "manager"},
+			{AuthorID: "u1", ReviewerID: "m1", Reason: "manager"},
+		},
+		Groups: []api.ExclusionGroupSchema{},
+	}
+	mockService.On("AddPair", mock.Anything, &api.ReviewExclusionSchema{
+		AuthorID:   "u1",
+		ReviewerID: "m1",
+		Reason:     "manager",
+	}, true).Return(expected, nil)
+
+	h.AddPair(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp api.ExclusionsResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp)
+}
+
+func TestExclusionHandler_AddPair_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockErr     error
+		wantStatus  int
+		wantErrCode string
+	}{
+		{"SameUser", repo.ErrInvalidExclusion, http.StatusBadRequest, api.ErrBadRequest},
+		{"UserNotFound", repo.ErrNotFound, http.StatusNotFound, api.ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewMockExclusionService(t)
+			h := exclusion.NewExclusionHandler(handlers.NewLogger(), mockService)
+
+			body, _ := json.Marshal(exclusion.PairRequest{AuthorID: "u1", ReviewerID: "u2"})
+			req := httptest.NewRequest(http.MethodPost, "/exclusions/addPair", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			mockService.On("AddPair", mock.Anything, mock.Anything, false).Return(nil, tt.mockErr)
+
+			h.AddPair(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			resp := handlers.DecodeErrorResponse(t, w.Body)
+			assert.Equal(t, tt.wantErrCode, resp.Error.Code)
+		})
+	}
+}
+
+func TestExclusionHandler_SetGroup_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockExclusionService(t)
+	h := exclusion.NewExclusionHandler(handlers.NewLogger(), mockService)
+
+	body, _ := json.Marshal(exclusion.SetGroupRequest{Name: "billing-v2", UserIDs: []string{"u1"}})
+	req := httptest.NewRequest(http.MethodPost, "/exclusions/setGroup", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetGroup(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestExclusionHandler_DeleteGroup_NotFound(t *testing.T) {
+	mockService := mocks.NewMockExclusionService(t)
+	h := exclusion.NewExclusionHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/exclusions/deleteGroup", bytes.NewReader([]byte(`{"name":"ghost"}`)))
+	w := httptest.NewRecorder()
+
+	mockService.On("DeleteGroup", mock.Anything, "ghost").Return(nil, repo.ErrNotFound)
+
+	h.DeleteGroup(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
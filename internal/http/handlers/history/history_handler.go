@@ -0,0 +1,92 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/sl"
+	repo "avito-intership-2025/internal/repository"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type historyService interface {
+	PrHistory(ctx context.Context, prID string) (*api.PrHistoryResponse, error)
+	UserHistory(ctx context.Context, userID string) (*api.UserHistoryResponse, error)
+}
+
+type HistoryHandler struct {
+	log     *slog.Logger
+	service historyService
+}
+
+func NewHistoryHandler(log *slog.Logger, s historyService) *HistoryHandler {
+	return &HistoryHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+func (h *HistoryHandler) PrHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.history.PrHistory"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "pull_request_id is required"))
+		return
+	}
+
+	resp, err := h.service.PrHistory(r.Context(), prID)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	render.JSON(w, r, resp)
+}
+
+func (h *HistoryHandler) UserHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.history.UserHistory"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "user_id is required"))
+		return
+	}
+
+	resp, err := h.service.UserHistory(r.Context(), userID)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	render.JSON(w, r, resp)
+}
+
+func (h *HistoryHandler) renderError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		log.Info("resource not found", sl.Err(err))
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+	default:
+		log.Error("error while retrieving review history", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+	}
+}
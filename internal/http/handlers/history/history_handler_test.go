@@ -0,0 +1,82 @@
+package history_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/http/handlers"
+	"avito-intership-2025/internal/http/handlers/history"
+	"avito-intership-2025/internal/http/handlers/mocks"
+	repo "avito-intership-2025/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHistoryHandler_PrHistory_Success(t *testing.T) {
+	mockService := mocks.NewMockHistoryService(t)
+	h := history.NewHistoryHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/history?pull_request_id=pr1", nil)
+	w := httptest.NewRecorder()
+
+	expected := &api.PrHistoryResponse{
+		PullRequestID: "pr1",
+		Events: []api.ReviewEventSchema{
+			{ID: 1, Type: "ASSIGNED", PullRequestID: "pr1", NewReviewerID: "u1", Actor: "admin"},
+		},
+	}
+	mockService.On("PrHistory", mock.Anything, "pr1").Return(expected, nil)
+
+	h.PrHistory(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PrHistoryResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp)
+}
+
+func TestHistoryHandler_PrHistory_MissingID(t *testing.T) {
+	mockService := mocks.NewMockHistoryService(t)
+	h := history.NewHistoryHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/history", nil)
+	w := httptest.NewRecorder()
+
+	h.PrHistory(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrBadRequest, resp.Error.Code)
+}
+
+func TestHistoryHandler_UserHistory_Errors(t *testing.T) {
+	cases := []struct {
+		err      error
+		wantCode int
+		wantErr  string
+	}{
+		{repo.ErrNotFound, http.StatusNotFound, api.ErrCodeNotFound},
+		{errors.New("db error"), http.StatusInternalServerError, api.ErrInternalErr},
+	}
+
+	for _, tc := range cases {
+		mockService := mocks.NewMockHistoryService(t)
+		h := history.NewHistoryHandler(handlers.NewLogger(), mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/history?user_id=u1", nil)
+		w := httptest.NewRecorder()
+
+		mockService.On("UserHistory", mock.Anything, "u1").Return(nil, tc.err)
+
+		h.UserHistory(w, req)
+
+		assert.Equal(t, tc.wantCode, w.Code)
+		resp := handlers.DecodeErrorResponse(t, w.Body)
+		assert.Equal(t, tc.wantErr, resp.Error.Code)
+	}
+}
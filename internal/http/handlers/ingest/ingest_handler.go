@@ -0,0 +1,285 @@
+package ingest
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/actor"
+	"avito-intership-2025/internal/lib/signature"
+	"avito-intership-2025/internal/lib/sl"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// maxPayloadSize - GitHub и GitLab не присылают тела больше 25 МБ.
+const maxPayloadSize = 25 << 20
+
+type ingestService interface {
+	HandleGitHub(ctx context.Context, event string, body []byte) (*api.IngestResponse, error)
+	HandleGitLab(ctx context.Context, event string, body []byte) (*api.IngestResponse, error)
+	ListIdentities(ctx context.Context, provider string) ([]api.ExternalIdentitySchema, error)
+	SetIdentity(ctx context.Context, input *api.ExternalIdentitySchema) ([]api.ExternalIdentitySchema, error)
+	DeleteIdentity(ctx context.Context, provider, login string) ([]api.ExternalIdentitySchema, error)
+}
+
+type IngestHandler struct {
+	log          *slog.Logger
+	service      ingestService
+	githubSecret string
+	gitlabToken  string
+}
+
+// NewIngestHandler создает обработчик входящих вебхуков. Пустой githubSecret или gitlabToken
+// отклоняет все вебхуки этой системы: без секрета запрос не проверить.
+func NewIngestHandler(log *slog.Logger, s ingestService, githubSecret, gitlabToken string) *IngestHandler {
+	return &IngestHandler{
+		log:          log,
+		service:      s,
+		githubSecret: githubSecret,
+		gitlabToken:  gitlabToken,
+	}
+}
+
+// GitHub принимает вебхуки GitHub, подписанные заголовком X-Hub-Signature-256.
+func (h *IngestHandler) GitHub(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.ingest.GitHub"
+	event := r.Header.Get("X-GitHub-Event")
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.String("delivery", r.Header.Get("X-GitHub-Delivery")),
+		slog.String("event", event),
+	)
+
+	body, ok := h.readBody(w, r, log)
+	if !ok {
+		return
+	}
+
+	if h.githubSecret == "" || !signature.Verify(h.githubSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+		log.Warn("invalid webhook signature")
+
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, api.Error(api.ErrCodeInvalidSignature, "invalid signature"))
+		return
+	}
+
+	// в журнал ревью изменения от вебхука попадают от имени внешней системы
+	ctx := actor.WithActor(r.Context(), models.ProviderGitHub)
+
+	resp, err := h.service.HandleGitHub(ctx, event, body)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("github webhook handled", slog.String("action", resp.Action))
+	render.JSON(w, r, resp)
+}
+
+// GitLab принимает вебхуки GitLab с секретом в заголовке X-Gitlab-Token.
+func (h *IngestHandler) GitLab(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.ingest.GitLab"
+	event := r.Header.Get("X-Gitlab-Event")
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.String("delivery", r.Header.Get("X-Gitlab-Event-UUID")),
+		slog.String("event", event),
+	)
+
+	body, ok := h.readBody(w, r, log)
+	if !ok {
+		return
+	}
+
+	token := r.Header.Get("X-Gitlab-Token")
+	if h.gitlabToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.gitlabToken)) != 1 {
+		log.Warn("invalid webhook token")
+
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, api.Error(api.ErrCodeInvalidSignature, "invalid token"))
+		return
+	}
+
+	ctx := actor.WithActor(r.Context(), models.ProviderGitLab)
+
+	resp, err := h.service.HandleGitLab(ctx, event, body)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("gitlab webhook handled", slog.String("action", resp.Action))
+	render.JSON(w, r, resp)
+}
+
+func (h *IngestHandler) ListIdentities(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.ingest.ListIdentities"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	// без provider возвращаются привязки всех систем
+	identities, err := h.service.ListIdentities(r.Context(), r.URL.Query().Get("provider"))
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	render.JSON(w, r, api.IdentitiesResponse{Identities: identities})
+}
+
+type SetIdentityRequest struct {
+	Provider string `json:"provider" validate:"required,oneof=github gitlab"`
+	Login    string `json:"login"    validate:"required,max=255"`
+	UserID   string `json:"user_id"  validate:"required"`
+}
+
+func (h *IngestHandler) SetIdentity(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.ingest.SetIdentity"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input SetIdentityRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	identities, err := h.service.SetIdentity(r.Context(), &api.ExternalIdentitySchema{
+		Provider: input.Provider,
+		Login:    input.Login,
+		UserID:   input.UserID,
+	})
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("identity linked", slog.String("provider", input.Provider), slog.String("user_id", input.UserID))
+	render.JSON(w, r, api.IdentitiesResponse{Identities: identities})
+}
+
+type DeleteIdentityRequest struct {
+	Provider string `json:"provider" validate:"required,oneof=github gitlab"`
+	Login    string `json:"login"    validate:"required"`
+}
+
+func (h *IngestHandler) DeleteIdentity(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.ingest.DeleteIdentity"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input DeleteIdentityRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	identities, err := h.service.DeleteIdentity(r.Context(), input.Provider, input.Login)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("identity unlinked", slog.String("provider", input.Provider))
+	render.JSON(w, r, api.IdentitiesResponse{Identities: identities})
+}
+
+// readBody читает тело вебхука целиком: подпись считается по исходным байтам.
+func (h *IngestHandler) readBody(w http.ResponseWriter, r *http.Request, log *slog.Logger) ([]byte, bool) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		log.Error("failed to read request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return nil, false
+	}
+	return body, true
+}
+
+// decode читает и валидирует тело запроса, при ошибке сам пишет ответ.
+func (h *IngestHandler) decode(w http.ResponseWriter, r *http.Request, log *slog.Logger, input any) bool {
+	if err := render.DecodeJSON(r.Body, input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return false
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return false
+	}
+
+	return true
+}
+
+func (h *IngestHandler) renderError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, repo.ErrInvalidPayload):
+		log.Info("invalid webhook payload", sl.Err(err))
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, err.Error()))
+
+	case errors.Is(err, repo.ErrNotFound):
+		log.Info("resource not found", sl.Err(err))
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+	case errors.Is(err, repo.ErrPRExists):
+		log.Info("pr already exists", sl.Err(err))
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, api.Error(api.ErrCodePRExists, err.Error()))
+
+	case errors.Is(err, repo.ErrPRMerged):
+		log.Info("pr is merged", sl.Err(err))
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, api.Error(api.ErrCodePRMerged, err.Error()))
+
+	case errors.Is(err, repo.ErrPRClosed):
+		log.Info("pr is closed", sl.Err(err))
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, api.Error(api.ErrCodePRClosed, err.Error()))
+
+	case errors.Is(err, repo.ErrTransition):
+		log.Info("transition not allowed", sl.Err(err))
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, api.Error(api.ErrCodeTransition, err.Error()))
+
+	case errors.Is(err, repo.ErrExcludedByRules):
+		log.Info("candidates excluded by rules", sl.Err(err))
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, api.Error(api.ErrCodeExcludedByRules, err.Error()))
+
+	case errors.Is(err, repo.ErrNoApprovals):
+		log.Info("not enough approvals", sl.Err(err))
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, api.Error(api.ErrCodeNoApprovals, err.Error()))
+
+	default:
+		log.Error("error while handling incoming webhook", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+	}
+}
@@ -0,0 +1,154 @@
+package ingest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/http/handlers"
+	"avito-intership-2025/internal/http/handlers/ingest"
+	"avito-intership-2025/internal/http/handlers/mocks"
+	"avito-intership-2025/internal/lib/actor"
+	"avito-intership-2025/internal/lib/signature"
+	repo "avito-intership-2025/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	secret = "github-webhook-secret"
+	token  = "gitlab-webhook-token"
+)
+
+func newGitHubRequest(body []byte, sig string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	req.Header.Set("X-Hub-Signature-256", sig)
+	return req
+}
+
+func TestIngestHandler_GitHub_Success(t *testing.T) {
+	mockService := mocks.NewMockIngestService(t)
+	h := ingest.NewIngestHandler(handlers.NewLogger(), mockService, secret, token)
+
+	body := []byte(`{"action":"reopened","number":42,"repository":{"full_name":"acme/review-service"}}`)
+	w := httptest.NewRecorder()
+
+	expected := &api.IngestResponse{
+		Action:      "reopened",
+		PullRequest: &api.PullRequestSchema{ID: "acme/review-service#42", Status: "OPEN", AssignedReviewers: []string{}},
+	}
+	mockService.On("HandleGitHub", mock.MatchedBy(func(ctx context.Context) bool {
+		return actor.FromContext(ctx) == "github"
+	}), "pull_request", body).Return(expected, nil)
+
+	h.GitHub(w, newGitHubRequest(body, signature.Sign(secret, body)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.IngestResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp)
+}
+
+func TestIngestHandler_GitHub_InvalidSignature(t *testing.T) {
+	mockService := mocks.NewMockIngestService(t)
+	h := ingest.NewIngestHandler(handlers.NewLogger(), mockService, secret, token)
+
+	body := []byte(`{"action":"opened"}`)
+	w := httptest.NewRecorder()
+
+	h.GitHub(w, newGitHubRequest(body, signature.Sign("other-secret", body)))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeInvalidSignature, resp.Error.Code)
+}
+
+func TestIngestHandler_GitHub_NoSecretConfigured(t *testing.T) {
+	mockService := mocks.NewMockIngestService(t)
+	h := ingest.NewIngestHandler(handlers.NewLogger(), mockService, "", "")
+
+	// тело, подписанное пустым ключом, не должно проходить проверку
+	body := []byte(`{"action":"opened"}`)
+	w := httptest.NewRecorder()
+
+	h.GitHub(w, newGitHubRequest(body, signature.Sign("", body)))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestIngestHandler_GitHub_UnknownLogin(t *testing.T) {
+	mockService := mocks.NewMockIngestService(t)
+	h := ingest.NewIngestHandler(handlers.NewLogger(), mockService, secret, token)
+
+	body := []byte(`{"action":"opened","number":42,"repository":{"full_name":"acme/review-service"}}`)
+	w := httptest.NewRecorder()
+
+	mockService.On("HandleGitHub", mock.Anything, "pull_request", body).
+		Return(nil, fmt.Errorf("github login octo-dev: %w", repo.ErrNotFound))
+
+	h.GitHub(w, newGitHubRequest(body, signature.Sign(secret, body)))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
+
+func TestIngestHandler_SetIdentity_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockIngestService(t)
+	h := ingest.NewIngestHandler(handlers.NewLogger(), mockService, secret, token)
+
+	body := []byte(`{"provider":"bitbucket","login":"octo-dev","user_id":"u1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/identities/set", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetIdentity(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestIngestHandler_GitLab_Success(t *testing.T) {
+	mockService := mocks.NewMockIngestService(t)
+	h := ingest.NewIngestHandler(handlers.NewLogger(), mockService, secret, token)
+
+	body := []byte(`{"object_kind":"merge_request","object_attributes":{"iid":7,"action":"close"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader(body))
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", token)
+	w := httptest.NewRecorder()
+
+	expected := &api.IngestResponse{Action: "closed"}
+	mockService.On("HandleGitLab", mock.MatchedBy(func(ctx context.Context) bool {
+		return actor.FromContext(ctx) == "gitlab"
+	}), "Merge Request Hook", body).Return(expected, nil)
+
+	h.GitLab(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestIngestHandler_GitLab_InvalidToken(t *testing.T) {
+	mockService := mocks.NewMockIngestService(t)
+	h := ingest.NewIngestHandler(handlers.NewLogger(), mockService, secret, token)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", "wrong")
+	w := httptest.NewRecorder()
+
+	h.GitLab(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeInvalidSignature, resp.Error.Code)
+}
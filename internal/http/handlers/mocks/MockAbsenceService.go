@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	api "avito-intership-2025/internal/http/api"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAbsenceService is an autogenerated mock type for the MockAbsenceService type
+type MockAbsenceService struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: ctx, input
+func (_m *MockAbsenceService) Add(ctx context.Context, input *api.AbsenceSchema) (*api.AbsenceResponse, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 *api.AbsenceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.AbsenceSchema) (*api.AbsenceResponse, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.AbsenceSchema) *api.AbsenceResponse); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.AbsenceResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.AbsenceSchema) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockAbsenceService) Delete(ctx context.Context, id int) ([]api.AbsenceSchema, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 []api.AbsenceSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]api.AbsenceSchema, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []api.AbsenceSchema); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.AbsenceSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: ctx, userID, content, reassignReviews
+func (_m *MockAbsenceService) Import(ctx context.Context, userID string, content string, reassignReviews bool) (*api.AbsenceImportResponse, error) {
+	ret := _m.Called(ctx, userID, content, reassignReviews)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 *api.AbsenceImportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (*api.AbsenceImportResponse, error)); ok {
+		return rf(ctx, userID, content, reassignReviews)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *api.AbsenceImportResponse); ok {
+		r0 = rf(ctx, userID, content, reassignReviews)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.AbsenceImportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, userID, content, reassignReviews)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *MockAbsenceService) List(ctx context.Context, userID string) ([]api.AbsenceSchema, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []api.AbsenceSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]api.AbsenceSchema, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []api.AbsenceSchema); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.AbsenceSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockAbsenceService creates a new instance of MockAbsenceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAbsenceService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAbsenceService {
+	mock := &MockAbsenceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
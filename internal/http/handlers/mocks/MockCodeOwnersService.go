@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	api "avito-intership-2025/internal/http/api"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCodeOwnersService is an autogenerated mock type for the MockCodeOwnersService type
+type MockCodeOwnersService struct {
+	mock.Mock
+}
+
+// DeleteRule provides a mock function with given fields: ctx, pattern
+func (_m *MockCodeOwnersService) DeleteRule(ctx context.Context, pattern string) ([]api.CodeOwnerRuleSchema, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRule")
+	}
+
+	var r0 []api.CodeOwnerRuleSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]api.CodeOwnerRuleSchema, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []api.CodeOwnerRuleSchema); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.CodeOwnerRuleSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: ctx, content
+func (_m *MockCodeOwnersService) Import(ctx context.Context, content string) (*api.CodeOwnersImportResponse, error) {
+	ret := _m.Called(ctx, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 *api.CodeOwnersImportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.CodeOwnersImportResponse, error)); ok {
+		return rf(ctx, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.CodeOwnersImportResponse); ok {
+		r0 = rf(ctx, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.CodeOwnersImportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockCodeOwnersService) List(ctx context.Context) ([]api.CodeOwnerRuleSchema, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []api.CodeOwnerRuleSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]api.CodeOwnerRuleSchema, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []api.CodeOwnerRuleSchema); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.CodeOwnerRuleSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRule provides a mock function with given fields: ctx, rule
+func (_m *MockCodeOwnersService) SetRule(ctx context.Context, rule *api.CodeOwnerRuleSchema) ([]api.CodeOwnerRuleSchema, error) {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRule")
+	}
+
+	var r0 []api.CodeOwnerRuleSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.CodeOwnerRuleSchema) ([]api.CodeOwnerRuleSchema, error)); ok {
+		return rf(ctx, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.CodeOwnerRuleSchema) []api.CodeOwnerRuleSchema); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.CodeOwnerRuleSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.CodeOwnerRuleSchema) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockCodeOwnersService creates a new instance of MockCodeOwnersService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCodeOwnersService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCodeOwnersService {
+	mock := &MockCodeOwnersService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,179 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	api "avito-intership-2025/internal/http/api"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockExclusionService is an autogenerated mock type for the exclusionService type
+type MockExclusionService struct {
+	mock.Mock
+}
+
+// AddPair provides a mock function with given fields: ctx, input, mutual
+func (_m *MockExclusionService) AddPair(ctx context.Context, input *api.ReviewExclusionSchema, mutual bool) (*api.ExclusionsResponse, error) {
+	ret := _m.Called(ctx, input, mutual)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPair")
+	}
+
+	var r0 *api.ExclusionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.ReviewExclusionSchema, bool) (*api.ExclusionsResponse, error)); ok {
+		return rf(ctx, input, mutual)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.ReviewExclusionSchema, bool) *api.ExclusionsResponse); ok {
+		r0 = rf(ctx, input, mutual)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ExclusionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.ReviewExclusionSchema, bool) error); ok {
+		r1 = rf(ctx, input, mutual)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, name
+func (_m *MockExclusionService) DeleteGroup(ctx context.Context, name string) (*api.ExclusionsResponse, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroup")
+	}
+
+	var r0 *api.ExclusionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.ExclusionsResponse, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.ExclusionsResponse); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ExclusionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePair provides a mock function with given fields: ctx, authorID, reviewerID, mutual
+func (_m *MockExclusionService) DeletePair(ctx context.Context, authorID string, reviewerID string, mutual bool) (*api.ExclusionsResponse, error) {
+	ret := _m.Called(ctx, authorID, reviewerID, mutual)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePair")
+	}
+
+	var r0 *api.ExclusionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (*api.ExclusionsResponse, error)); ok {
+		return rf(ctx, authorID, reviewerID, mutual)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *api.ExclusionsResponse); ok {
+		r0 = rf(ctx, authorID, reviewerID, mutual)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ExclusionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, authorID, reviewerID, mutual)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockExclusionService) List(ctx context.Context) (*api.ExclusionsResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *api.ExclusionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*api.ExclusionsResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *api.ExclusionsResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ExclusionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetGroup provides a mock function with given fields: ctx, input
+func (_m *MockExclusionService) SetGroup(ctx context.Context, input *api.ExclusionGroupSchema) (*api.ExclusionsResponse, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetGroup")
+	}
+
+	var r0 *api.ExclusionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.ExclusionGroupSchema) (*api.ExclusionsResponse, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.ExclusionGroupSchema) *api.ExclusionsResponse); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ExclusionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.ExclusionGroupSchema) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockExclusionService creates a new instance of MockExclusionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExclusionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExclusionService {
+	mock := &MockExclusionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
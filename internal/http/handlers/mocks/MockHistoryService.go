@@ -0,0 +1,89 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	api "avito-intership-2025/internal/http/api"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockHistoryService is an autogenerated mock type for the MockHistoryService type
+type MockHistoryService struct {
+	mock.Mock
+}
+
+// PrHistory provides a mock function with given fields: ctx, prID
+func (_m *MockHistoryService) PrHistory(ctx context.Context, prID string) (*api.PrHistoryResponse, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrHistory")
+	}
+
+	var r0 *api.PrHistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PrHistoryResponse, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PrHistoryResponse); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PrHistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserHistory provides a mock function with given fields: ctx, userID
+func (_m *MockHistoryService) UserHistory(ctx context.Context, userID string) (*api.UserHistoryResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserHistory")
+	}
+
+	var r0 *api.UserHistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.UserHistoryResponse, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.UserHistoryResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UserHistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockHistoryService creates a new instance of MockHistoryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHistoryService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHistoryService {
+	mock := &MockHistoryService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
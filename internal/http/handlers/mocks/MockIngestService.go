@@ -0,0 +1,179 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	api "avito-intership-2025/internal/http/api"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIngestService is an autogenerated mock type for the ingestService type
+type MockIngestService struct {
+	mock.Mock
+}
+
+// DeleteIdentity provides a mock function with given fields: ctx, provider, login
+func (_m *MockIngestService) DeleteIdentity(ctx context.Context, provider string, login string) ([]api.ExternalIdentitySchema, error) {
+	ret := _m.Called(ctx, provider, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdentity")
+	}
+
+	var r0 []api.ExternalIdentitySchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]api.ExternalIdentitySchema, error)); ok {
+		return rf(ctx, provider, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []api.ExternalIdentitySchema); ok {
+		r0 = rf(ctx, provider, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.ExternalIdentitySchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleGitHub provides a mock function with given fields: ctx, event, body
+func (_m *MockIngestService) HandleGitHub(ctx context.Context, event string, body []byte) (*api.IngestResponse, error) {
+	ret := _m.Called(ctx, event, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleGitHub")
+	}
+
+	var r0 *api.IngestResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) (*api.IngestResponse, error)); ok {
+		return rf(ctx, event, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) *api.IngestResponse); ok {
+		r0 = rf(ctx, event, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.IngestResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, event, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleGitLab provides a mock function with given fields: ctx, event, body
+func (_m *MockIngestService) HandleGitLab(ctx context.Context, event string, body []byte) (*api.IngestResponse, error) {
+	ret := _m.Called(ctx, event, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleGitLab")
+	}
+
+	var r0 *api.IngestResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) (*api.IngestResponse, error)); ok {
+		return rf(ctx, event, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) *api.IngestResponse); ok {
+		r0 = rf(ctx, event, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.IngestResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, event, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIdentities provides a mock function with given fields: ctx, provider
+func (_m *MockIngestService) ListIdentities(ctx context.Context, provider string) ([]api.ExternalIdentitySchema, error) {
+	ret := _m.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIdentities")
+	}
+
+	var r0 []api.ExternalIdentitySchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]api.ExternalIdentitySchema, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []api.ExternalIdentitySchema); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.ExternalIdentitySchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetIdentity provides a mock function with given fields: ctx, input
+func (_m *MockIngestService) SetIdentity(ctx context.Context, input *api.ExternalIdentitySchema) ([]api.ExternalIdentitySchema, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIdentity")
+	}
+
+	var r0 []api.ExternalIdentitySchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.ExternalIdentitySchema) ([]api.ExternalIdentitySchema, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.ExternalIdentitySchema) []api.ExternalIdentitySchema); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.ExternalIdentitySchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.ExternalIdentitySchema) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockIngestService creates a new instance of MockIngestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIngestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIngestService {
+	mock := &MockIngestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
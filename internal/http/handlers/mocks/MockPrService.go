@@ -7,16 +7,78 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "avito-intership-2025/internal/models"
 )
 
-// MockPrService is an autogenerated mock type for the MockPrService type
+// MockPrService is an autogenerated mock type for the prService type
 type MockPrService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, prID, prName, authorId
-func (_m *MockPrService) Create(ctx context.Context, prID string, prName string, authorId string) (*api.PullRequestSchema, error) {
-	ret := _m.Called(ctx, prID, prName, authorId)
+// AddReviewer provides a mock function with given fields: ctx, prID, userID
+func (_m *MockPrService) AddReviewer(ctx context.Context, prID string, userID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReviewer")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, prID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Close provides a mock function with given fields: ctx, prID
+func (_m *MockPrService) Close(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, prID, prName, authorId, files, labels
+func (_m *MockPrService) Create(ctx context.Context, prID string, prName string, authorId string, files []string, labels []string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID, prName, authorId, files, labels)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -24,19 +86,109 @@ func (_m *MockPrService) Create(ctx context.Context, prID string, prName string,
 
 	var r0 *api.PullRequestSchema
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*api.PullRequestSchema, error)); ok {
-		return rf(ctx, prID, prName, authorId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, []string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID, prName, authorId, files, labels)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, []string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID, prName, authorId, files, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string, []string) error); ok {
+		r1 = rf(ctx, prID, prName, authorId, files, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDraft provides a mock function with given fields: ctx, prID, prName, authorId, labels
+func (_m *MockPrService) CreateDraft(ctx context.Context, prID string, prName string, authorId string, labels []string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID, prName, authorId, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDraft")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID, prName, authorId, labels)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *api.PullRequestSchema); ok {
-		r0 = rf(ctx, prID, prName, authorId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID, prName, authorId, labels)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*api.PullRequestSchema)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, prID, prName, authorId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string) error); ok {
+		r1 = rf(ctx, prID, prName, authorId, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, prID
+func (_m *MockPrService) Get(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *MockPrService) List(ctx context.Context, filter *models.PrListFilter) (*api.PrListResponse, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *api.PrListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PrListFilter) (*api.PrListResponse, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PrListFilter) *api.PrListResponse); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PrListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PrListFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -74,6 +226,96 @@ func (_m *MockPrService) Merge(ctx context.Context, prID string) (*api.PullReque
 	return r0, r1
 }
 
+// PreviewCreate provides a mock function with given fields: ctx, authorID, files, labels
+func (_m *MockPrService) PreviewCreate(ctx context.Context, authorID string, files []string, labels []string) (*api.AssignmentPreviewResponse, error) {
+	ret := _m.Called(ctx, authorID, files, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewCreate")
+	}
+
+	var r0 *api.AssignmentPreviewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, []string) (*api.AssignmentPreviewResponse, error)); ok {
+		return rf(ctx, authorID, files, labels)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, []string) *api.AssignmentPreviewResponse); ok {
+		r0 = rf(ctx, authorID, files, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.AssignmentPreviewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, []string) error); ok {
+		r1 = rf(ctx, authorID, files, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreviewReassign provides a mock function with given fields: ctx, prID, oldRev
+func (_m *MockPrService) PreviewReassign(ctx context.Context, prID string, oldRev string) (*api.AssignmentPreviewResponse, error) {
+	ret := _m.Called(ctx, prID, oldRev)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewReassign")
+	}
+
+	var r0 *api.AssignmentPreviewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*api.AssignmentPreviewResponse, error)); ok {
+		return rf(ctx, prID, oldRev)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *api.AssignmentPreviewResponse); ok {
+		r0 = rf(ctx, prID, oldRev)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.AssignmentPreviewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, prID, oldRev)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ready provides a mock function with given fields: ctx, prID
+func (_m *MockPrService) Ready(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Reassign provides a mock function with given fields: ctx, prID, oldRev
 func (_m *MockPrService) Reassign(ctx context.Context, prID string, oldRev string) (*api.ReassignResponse, error) {
 	ret := _m.Called(ctx, prID, oldRev)
@@ -104,6 +346,186 @@ func (_m *MockPrService) Reassign(ctx context.Context, prID string, oldRev strin
 	return r0, r1
 }
 
+// RemoveReviewer provides a mock function with given fields: ctx, prID, userID
+func (_m *MockPrService) RemoveReviewer(ctx context.Context, prID string, userID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReviewer")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, prID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reopen provides a mock function with given fields: ctx, prID
+func (_m *MockPrService) Reopen(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, filter
+func (_m *MockPrService) Search(ctx context.Context, filter *models.PrSearchFilter) (*api.PrSearchResponse, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *api.PrSearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PrSearchFilter) (*api.PrSearchResponse, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PrSearchFilter) *api.PrSearchResponse); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PrSearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PrSearchFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetLabels provides a mock function with given fields: ctx, prID, labels
+func (_m *MockPrService) SetLabels(ctx context.Context, prID string, labels []string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLabels")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID, labels)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, prID, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitReview provides a mock function with given fields: ctx, prID, reviewerID, verdict, comment
+func (_m *MockPrService) SubmitReview(ctx context.Context, prID string, reviewerID string, verdict string, comment string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID, reviewerID, verdict, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitReview")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID, reviewerID, verdict, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID, reviewerID, verdict, comment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, prID, reviewerID, verdict, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TopUpReviewers provides a mock function with given fields: ctx
+func (_m *MockPrService) TopUpReviewers(ctx context.Context) ([]api.PullRequestSchema, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TopUpReviewers")
+	}
+
+	var r0 []api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]api.PullRequestSchema, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []api.PullRequestSchema); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockPrService creates a new instance of MockPrService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockPrService(t interface {
@@ -9,7 +9,7 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
-// MockTeamService is an autogenerated mock type for the MockTeamService type
+// MockTeamService is an autogenerated mock type for the teamService type
 type MockTeamService struct {
 	mock.Mock
 }
@@ -44,6 +44,36 @@ func (_m *MockTeamService) Add(ctx context.Context, teamName string, users []api
 	return r0, r1
 }
 
+// DeactivateUsers provides a mock function with given fields: ctx, teamName, userIDs
+func (_m *MockTeamService) DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*api.DeactivateUsersResponse, error) {
+	ret := _m.Called(ctx, teamName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateUsers")
+	}
+
+	var r0 *api.DeactivateUsersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*api.DeactivateUsersResponse, error)); ok {
+		return rf(ctx, teamName, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *api.DeactivateUsersResponse); ok {
+		r0 = rf(ctx, teamName, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.DeactivateUsersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, teamName, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, teamName
 func (_m *MockTeamService) Get(ctx context.Context, teamName string) (*api.TeamSchema, error) {
 	ret := _m.Called(ctx, teamName)
@@ -74,6 +104,96 @@ func (_m *MockTeamService) Get(ctx context.Context, teamName string) (*api.TeamS
 	return r0, r1
 }
 
+// GetPairings provides a mock function with given fields: ctx, teamName, days
+func (_m *MockTeamService) GetPairings(ctx context.Context, teamName string, days *int) (*api.PairingMatrixResponse, error) {
+	ret := _m.Called(ctx, teamName, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPairings")
+	}
+
+	var r0 *api.PairingMatrixResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) (*api.PairingMatrixResponse, error)); ok {
+		return rf(ctx, teamName, days)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) *api.PairingMatrixResponse); ok {
+		r0 = rf(ctx, teamName, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PairingMatrixResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = rf(ctx, teamName, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettings provides a mock function with given fields: ctx, teamName
+func (_m *MockTeamService) GetSettings(ctx context.Context, teamName string) (*api.TeamSettingsSchema, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *api.TeamSettingsSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.TeamSettingsSchema, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.TeamSettingsSchema); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.TeamSettingsSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSettings provides a mock function with given fields: ctx, settings
+func (_m *MockTeamService) SetSettings(ctx context.Context, settings *api.TeamSettingsUpdate) (*api.TeamSettingsSchema, error) {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSettings")
+	}
+
+	var r0 *api.TeamSettingsSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.TeamSettingsUpdate) (*api.TeamSettingsSchema, error)); ok {
+		return rf(ctx, settings)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.TeamSettingsUpdate) *api.TeamSettingsSchema); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.TeamSettingsSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.TeamSettingsUpdate) error); ok {
+		r1 = rf(ctx, settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockTeamService creates a new instance of MockTeamService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockTeamService(t interface {
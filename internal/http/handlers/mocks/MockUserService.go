@@ -9,7 +9,7 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
-// MockUserService is an autogenerated mock type for the MockUserService type
+// MockUserService is an autogenerated mock type for the userService type
 type MockUserService struct {
 	mock.Mock
 }
@@ -74,6 +74,96 @@ func (_m *MockUserService) SetIsActive(ctx context.Context, userID string, isAct
 	return r0, r1
 }
 
+// SetMaxOpenReviews provides a mock function with given fields: ctx, userID, limit
+func (_m *MockUserService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*api.UserSchema, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaxOpenReviews")
+	}
+
+	var r0 *api.UserSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) (*api.UserSchema, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) *api.UserSchema); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UserSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSeniority provides a mock function with given fields: ctx, userID, seniority
+func (_m *MockUserService) SetSeniority(ctx context.Context, userID string, seniority string) (*api.UserSchema, error) {
+	ret := _m.Called(ctx, userID, seniority)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSeniority")
+	}
+
+	var r0 *api.UserSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*api.UserSchema, error)); ok {
+		return rf(ctx, userID, seniority)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *api.UserSchema); ok {
+		r0 = rf(ctx, userID, seniority)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UserSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, seniority)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSkills provides a mock function with given fields: ctx, userID, skills
+func (_m *MockUserService) SetSkills(ctx context.Context, userID string, skills []string) (*api.UserSchema, error) {
+	ret := _m.Called(ctx, userID, skills)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSkills")
+	}
+
+	var r0 *api.UserSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*api.UserSchema, error)); ok {
+		return rf(ctx, userID, skills)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *api.UserSchema); ok {
+		r0 = rf(ctx, userID, skills)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UserSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, skills)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockUserService creates a new instance of MockUserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserService(t interface {
@@ -0,0 +1,177 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	api "avito-intership-2025/internal/http/api"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookService is an autogenerated mock type for the MockWebhookService type
+type MockWebhookService struct {
+	mock.Mock
+}
+
+// AddSubscription provides a mock function with given fields: ctx, input
+func (_m *MockWebhookService) AddSubscription(ctx context.Context, input *api.WebhookSubscriptionSchema) (*api.WebhookSubscriptionSchema, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSubscription")
+	}
+
+	var r0 *api.WebhookSubscriptionSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.WebhookSubscriptionSchema) (*api.WebhookSubscriptionSchema, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.WebhookSubscriptionSchema) *api.WebhookSubscriptionSchema); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.WebhookSubscriptionSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.WebhookSubscriptionSchema) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *MockWebhookService) DeleteSubscription(ctx context.Context, id int) ([]api.WebhookSubscriptionSchema, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 []api.WebhookSubscriptionSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]api.WebhookSubscriptionSchema, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []api.WebhookSubscriptionSchema); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.WebhookSubscriptionSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, status, subscriptionID
+func (_m *MockWebhookService) ListDeliveries(ctx context.Context, status string, subscriptionID int) ([]api.WebhookDeliverySchema, error) {
+	ret := _m.Called(ctx, status, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 []api.WebhookDeliverySchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]api.WebhookDeliverySchema, error)); ok {
+		return rf(ctx, status, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []api.WebhookDeliverySchema); ok {
+		r0 = rf(ctx, status, subscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.WebhookDeliverySchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, status, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSubscriptions provides a mock function with given fields: ctx
+func (_m *MockWebhookService) ListSubscriptions(ctx context.Context) ([]api.WebhookSubscriptionSchema, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubscriptions")
+	}
+
+	var r0 []api.WebhookSubscriptionSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]api.WebhookSubscriptionSchema, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []api.WebhookSubscriptionSchema); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.WebhookSubscriptionSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Replay provides a mock function with given fields: ctx, deliveryID, subscriptionID
+func (_m *MockWebhookService) Replay(ctx context.Context, deliveryID int64, subscriptionID int) (int, error) {
+	ret := _m.Called(ctx, deliveryID, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replay")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (int, error)); ok {
+		return rf(ctx, deliveryID, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) int); ok {
+		r0 = rf(ctx, deliveryID, subscriptionID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, deliveryID, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockWebhookService creates a new instance of MockWebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookService {
+	mock := &MockWebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
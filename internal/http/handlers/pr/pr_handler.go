@@ -5,9 +5,17 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/actor"
 	"avito-intership-2025/internal/lib/sl"
+	"avito-intership-2025/internal/models"
 	repo "avito-intership-2025/internal/repository"
 
 	"github.com/go-chi/chi/v5/middleware"
@@ -16,9 +24,23 @@ import (
 )
 
 type prService interface {
-	Create(ctx context.Context, prID, prName, authorId string) (*api.PullRequestSchema, error)
+	Create(ctx context.Context, prID, prName, authorId string, files, labels []string) (*api.PullRequestSchema, error)
+	CreateDraft(ctx context.Context, prID, prName, authorId string, labels []string) (*api.PullRequestSchema, error)
 	Merge(ctx context.Context, prID string) (*api.PullRequestSchema, error)
 	Reassign(ctx context.Context, prID, oldRev string) (*api.ReassignResponse, error)
+	TopUpReviewers(ctx context.Context) ([]api.PullRequestSchema, error)
+	Ready(ctx context.Context, prID string) (*api.PullRequestSchema, error)
+	Close(ctx context.Context, prID string) (*api.PullRequestSchema, error)
+	Reopen(ctx context.Context, prID string) (*api.PullRequestSchema, error)
+	SubmitReview(ctx context.Context, prID, reviewerID, verdict, comment string) (*api.PullRequestSchema, error)
+	AddReviewer(ctx context.Context, prID, userID string) (*api.PullRequestSchema, error)
+	RemoveReviewer(ctx context.Context, prID, userID string) (*api.PullRequestSchema, error)
+	SetLabels(ctx context.Context, prID string, labels []string) (*api.PullRequestSchema, error)
+	PreviewCreate(ctx context.Context, authorID string, files, labels []string) (*api.AssignmentPreviewResponse, error)
+	PreviewReassign(ctx context.Context, prID, oldRev string) (*api.AssignmentPreviewResponse, error)
+	Get(ctx context.Context, prID string) (*api.PullRequestSchema, error)
+	List(ctx context.Context, filter *models.PrListFilter) (*api.PrListResponse, error)
+	Search(ctx context.Context, filter *models.PrSearchFilter) (*api.PrSearchResponse, error)
 }
 
 type PrHandler struct {
@@ -37,6 +59,12 @@ type CreateRequest struct {
 	PrID     string `json:"pull_request_id"   validate:"required"`
 	PrName   string `json:"pull_request_name" validate:"required,min=5"`
 	AuthorId string `json:"author_id"         validate:"required"`
+	// Draft - создать PR в статусе DRAFT, ревьюеры назначаются после /pullRequest/ready
+	Draft bool `json:"draft"`
+	// Files - измененные пути, по ним подбираются владельцы из CODEOWNERS. Вместе с Draft не принимаются
+	Files []string `json:"files" validate:"omitempty,dive,required"`
+	// Labels - метки PR, ревьюеры с совпадающими навыками выбираются в первую очередь
+	Labels []string `json:"labels" validate:"omitempty,max=20,dive,required,max=32"`
 }
 
 func (h *PrHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -67,7 +95,24 @@ func (h *PrHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.service.Create(ctx, input.PrID, input.PrName, input.AuthorId)
+	// черновик не хранит файлы: молча отброшенные, они не дали бы владельцев при /pullRequest/ready
+	if input.Draft && len(input.Files) > 0 {
+		log.Info("files are not accepted for draft pr")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "files are not supported for draft PRs"))
+		return
+	}
+
+	var (
+		resp *api.PullRequestSchema
+		err  error
+	)
+	if input.Draft {
+		resp, err = h.service.CreateDraft(ctx, input.PrID, input.PrName, input.AuthorId, input.Labels)
+	} else {
+		resp, err = h.service.Create(ctx, input.PrID, input.PrName, input.AuthorId, input.Files, input.Labels)
+	}
 	if err != nil {
 		if errors.Is(err, repo.ErrPRExists) {
 			log.Info("pr already exists", sl.Err(err))
@@ -81,6 +126,12 @@ func (h *PrHandler) Create(w http.ResponseWriter, r *http.Request) {
 			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
 			return
 		}
+		if errors.Is(err, repo.ErrExcludedByRules) {
+			log.Info("candidates excluded by rules", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeExcludedByRules, err.Error()))
+			return
+		}
 		log.Error("error while creating pr", sl.Err(err))
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, api.InternalError())
@@ -93,6 +144,275 @@ func (h *PrHandler) Create(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+type PreviewAssignmentRequest struct {
+	AuthorID string   `json:"author_id" validate:"required"`
+	Files    []string `json:"files"     validate:"omitempty,dive,required"`
+	Labels   []string `json:"labels"    validate:"omitempty,max=20,dive,required,max=32"`
+}
+
+// PreviewAssignment показывает, кого назначил бы /pullRequest/create, ничего не создавая.
+func (h *PrHandler) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.pr.PreviewAssignment"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input PreviewAssignmentRequest
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := h.service.PreviewCreate(ctx, input.AuthorID, input.Files, input.Labels)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("resource not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while previewing assignment", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	render.JSON(w, r, resp)
+}
+
+func (h *PrHandler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.pr.Get"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "pull_request_id is required"))
+		return
+	}
+
+	resp, err := h.service.Get(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("pr not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while getting pr", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	render.JSON(w, r, api.PrResponse{PullRequest: *resp})
+}
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+var prStatuses = []string{"DRAFT", "OPEN", "MERGED", "CLOSED"}
+
+// List отдает страницу PR по фильтрам из query, следующая страница запрашивается по next_cursor.
+func (h *PrHandler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.pr.List"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	filter, msg := parseListFilter(r.URL.Query())
+	if msg != "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, msg))
+		return
+	}
+
+	resp, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, repo.ErrInvalidCursor) {
+			log.Info("invalid cursor", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, api.Error(api.ErrBadRequest, err.Error()))
+			return
+		}
+		log.Error("error while listing prs", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	render.JSON(w, r, resp)
+}
+
+// parseListFilter разбирает фильтры списка PR, при ошибке возвращает ее текст для ответа.
+func parseListFilter(q url.Values) (*models.PrListFilter, string) {
+	filter := &models.PrListFilter{
+		AuthorID:   q.Get("author_id"),
+		ReviewerID: q.Get("reviewer_id"),
+		TeamName:   q.Get("team_name"),
+		SortBy:     models.PrSortCreatedAt,
+		Desc:       true,
+		Cursor:     q.Get("cursor"),
+	}
+
+	var msg string
+	if filter.Status, msg = parseStatus(q); msg != "" {
+		return nil, msg
+	}
+	if filter.Limit, msg = parseLimit(q); msg != "" {
+		return nil, msg
+	}
+
+	switch q.Get("sort_by") {
+	case "", models.PrSortCreatedAt:
+	case models.PrSortMergedAt:
+		filter.SortBy = models.PrSortMergedAt
+	default:
+		return nil, "sort_by must be 'created_at' or 'merged_at'"
+	}
+
+	switch q.Get("order") {
+	case "", "desc":
+	case "asc":
+		filter.Desc = false
+	default:
+		return nil, "order must be 'desc' or 'asc'"
+	}
+
+	dates := []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created_from", &filter.CreatedFrom},
+		{"created_to", &filter.CreatedTo},
+		{"merged_from", &filter.MergedFrom},
+		{"merged_to", &filter.MergedTo},
+	}
+	for _, d := range dates {
+		raw := q.Get(d.name)
+		if raw == "" {
+			continue
+		}
+		t, err := parseTime(raw)
+		if err != nil {
+			return nil, d.name + " must be an RFC 3339 timestamp or a YYYY-MM-DD date"
+		}
+		*d.dst = &t
+	}
+
+	return filter, ""
+}
+
+func parseStatus(q url.Values) (string, string) {
+	status := q.Get("status")
+	if status != "" && !slices.Contains(prStatuses, status) {
+		return "", "status must be one of DRAFT, OPEN, MERGED, CLOSED"
+	}
+	return status, ""
+}
+
+func parseLimit(q url.Values) (int, string) {
+	raw := q.Get("limit")
+	if raw == "" {
+		return defaultListLimit, ""
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 || n > maxListLimit {
+		return 0, "limit must be an integer from 1 to 100"
+	}
+	return n, ""
+}
+
+// parseTime принимает метку времени RFC 3339 или дату, дата означает начало дня по UTC.
+func parseTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+const maxSearchQueryLen = 200
+
+// Search ищет PR по словам в названии, фильтры status и team_name работают как в списке.
+func (h *PrHandler) Search(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.pr.Search"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	q := r.URL.Query()
+	filter := &models.PrSearchFilter{
+		Query:    strings.TrimSpace(q.Get("q")),
+		TeamName: q.Get("team_name"),
+	}
+
+	if filter.Query == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "q is required"))
+		return
+	}
+	if utf8.RuneCountInString(filter.Query) > maxSearchQueryLen {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "q must be no more than 200 characters"))
+		return
+	}
+
+	var msg string
+	if filter.Status, msg = parseStatus(q); msg == "" {
+		filter.Limit, msg = parseLimit(q)
+	}
+	if msg != "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, msg))
+		return
+	}
+
+	resp, err := h.service.Search(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, repo.ErrInvalidQuery) {
+			log.Info("invalid search query", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, api.Error(api.ErrBadRequest, "q must contain at least one letter or digit"))
+			return
+		}
+		log.Error("error while searching prs", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	render.JSON(w, r, resp)
+}
+
 type MergeRequest struct {
 	PrID string `json:"pull_request_id" validate:"required"`
 }
@@ -133,6 +453,24 @@ func (h *PrHandler) Merge(w http.ResponseWriter, r *http.Request) {
 			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
 			return
 		}
+		if errors.Is(err, repo.ErrPRClosed) {
+			log.Info("pr is closed", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRClosed, err.Error()))
+			return
+		}
+		if errors.Is(err, repo.ErrTransition) {
+			log.Info("pr is not ready for merge", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeTransition, err.Error()))
+			return
+		}
+		if errors.Is(err, repo.ErrNoApprovals) {
+			log.Info("not enough approvals", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeNoApprovals, err.Error()))
+			return
+		}
 		log.Error("error while merging pr", sl.Err(err))
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, api.InternalError())
@@ -145,6 +483,8 @@ func (h *PrHandler) Merge(w http.ResponseWriter, r *http.Request) {
 type ReassignRequest struct {
 	PrID          string `json:"pull_request_id" validate:"required"`
 	OldReviewerID string `json:"old_reviewer_id" validate:"required"`
+	// Preview - только показать замену и причины отбора, ничего не меняя
+	Preview bool `json:"preview"`
 }
 
 func (h *PrHandler) Reassign(w http.ResponseWriter, r *http.Request) {
@@ -175,7 +515,15 @@ func (h *PrHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.service.Reassign(ctx, input.PrID, input.OldReviewerID)
+	var (
+		resp any
+		err  error
+	)
+	if input.Preview {
+		resp, err = h.service.PreviewReassign(ctx, input.PrID, input.OldReviewerID)
+	} else {
+		resp, err = h.service.Reassign(ctx, input.PrID, input.OldReviewerID)
+	}
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrNotFound):
@@ -183,6 +531,11 @@ func (h *PrHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 			render.Status(r, http.StatusNotFound)
 			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
 
+		case errors.Is(err, repo.ErrExcludedByRules):
+			log.Info("candidates excluded by rules", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeExcludedByRules, err.Error()))
+
 		case errors.Is(err, repo.ErrNoCandidate):
 			log.Info("no candidate", sl.Err(err))
 			render.Status(r, http.StatusConflict)
@@ -193,6 +546,11 @@ func (h *PrHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 			render.Status(r, http.StatusConflict)
 			render.JSON(w, r, api.Error(api.ErrCodePRMerged, err.Error()))
 
+		case errors.Is(err, repo.ErrPRClosed):
+			log.Info("pr is closed", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRClosed, err.Error()))
+
 		case errors.Is(err, repo.ErrNotAssigned):
 			log.Info("no candidate", sl.Err(err))
 			render.Status(r, http.StatusConflict)
@@ -208,3 +566,354 @@ func (h *PrHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 
 	render.JSON(w, r, resp)
 }
+
+func (h *PrHandler) TopUpReviewers(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.pr.TopUpReviewers"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	prs, err := h.service.TopUpReviewers(ctx)
+	if err != nil {
+		log.Error("error while topping up reviewers", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	log.Info("reviewers topped up", slog.Int("pr_count", len(prs)))
+	render.JSON(w, r, api.TopUpResponse{PullRequests: prs})
+}
+
+type StatusRequest struct {
+	PrID string `json:"pull_request_id" validate:"required"`
+}
+
+func (h *PrHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "handlers.pr.Ready", h.service.Ready)
+}
+
+func (h *PrHandler) Close(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "handlers.pr.Close", h.service.Close)
+}
+
+func (h *PrHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "handlers.pr.Reopen", h.service.Reopen)
+}
+
+// changeStatus - общий обработчик переходов между статусами PR.
+func (h *PrHandler) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	change func(ctx context.Context, prID string) (*api.PullRequestSchema, error),
+) {
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input StatusRequest
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := change(ctx, input.PrID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			log.Info("resource not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+		case errors.Is(err, repo.ErrPRMerged):
+			log.Info("pr is merged", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRMerged, err.Error()))
+
+		case errors.Is(err, repo.ErrPRClosed):
+			log.Info("pr is closed", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRClosed, err.Error()))
+
+		case errors.Is(err, repo.ErrTransition):
+			log.Info("transition not allowed", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeTransition, err.Error()))
+
+		default:
+			log.Error("error while changing pr status", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, api.InternalError())
+		}
+		return
+	}
+
+	log.Info("pr status changed", slog.String("status", resp.Status))
+	render.JSON(w, r, api.PrResponse{PullRequest: *resp})
+}
+
+type ReviewRequest struct {
+	PrID string `json:"pull_request_id" validate:"required"`
+	// ReviewerID необязателен: ревьюер - пользователь из subject токена,
+	// переданный id должен с ним совпадать
+	ReviewerID string `json:"reviewer_id"`
+	Verdict    string `json:"verdict"         validate:"required,oneof=APPROVED CHANGES_REQUESTED"`
+	Comment    string `json:"comment"         validate:"max=2000"`
+}
+
+func (h *PrHandler) Review(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.pr.Review"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input ReviewRequest
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	// вердикт можно оставить только от своего имени, иначе любой токен мог бы апрувить за других
+	reviewerID := actor.SubjectFromContext(ctx)
+	if reviewerID == "" {
+		log.Info("token has no subject")
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, api.Error(api.ErrCodeForbidden, "token has no subject to submit a review as"))
+		return
+	}
+	if input.ReviewerID != "" && input.ReviewerID != reviewerID {
+		log.Info("reviewer does not match token subject", slog.String("reviewer_id", input.ReviewerID))
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, api.Error(api.ErrCodeForbidden, "reviewer_id does not match the authenticated user"))
+		return
+	}
+
+	resp, err := h.service.SubmitReview(ctx, input.PrID, reviewerID, input.Verdict, input.Comment)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			log.Info("resource not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+		case errors.Is(err, repo.ErrPRMerged):
+			log.Info("pr is merged", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRMerged, err.Error()))
+
+		case errors.Is(err, repo.ErrPRClosed):
+			log.Info("pr is closed", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRClosed, err.Error()))
+
+		case errors.Is(err, repo.ErrNotAssigned):
+			log.Info("reviewer is not assigned", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeNotAssigned, err.Error()))
+
+		default:
+			log.Error("error while submitting review", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, api.InternalError())
+		}
+		return
+	}
+
+	log.Info("review submitted", slog.String("verdict", input.Verdict))
+	render.JSON(w, r, api.PrResponse{PullRequest: *resp})
+}
+
+type ReviewerRequest struct {
+	PrID   string `json:"pull_request_id" validate:"required"`
+	UserID string `json:"user_id"         validate:"required"`
+}
+
+func (h *PrHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	h.changeReviewer(w, r, "handlers.pr.AddReviewer", h.service.AddReviewer)
+}
+
+func (h *PrHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	h.changeReviewer(w, r, "handlers.pr.RemoveReviewer", h.service.RemoveReviewer)
+}
+
+// changeReviewer - общий обработчик ручного добавления и снятия ревьюера.
+func (h *PrHandler) changeReviewer(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	change func(ctx context.Context, prID, userID string) (*api.PullRequestSchema, error),
+) {
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input ReviewerRequest
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := change(ctx, input.PrID, input.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			log.Info("resource not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+		case errors.Is(err, repo.ErrExcludedByRules):
+			log.Info("user excluded by rules", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeExcludedByRules, err.Error()))
+
+		case errors.Is(err, repo.ErrNoCandidate):
+			log.Info("user can not be assigned", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeNoCandidate, err.Error()))
+
+		case errors.Is(err, repo.ErrPRMerged):
+			log.Info("pr is merged", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRMerged, err.Error()))
+
+		case errors.Is(err, repo.ErrPRClosed):
+			log.Info("pr is closed", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRClosed, err.Error()))
+
+		case errors.Is(err, repo.ErrTransition):
+			log.Info("pr is not open", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeTransition, err.Error()))
+
+		case errors.Is(err, repo.ErrNotAssigned):
+			log.Info("reviewer is not assigned", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodeNotAssigned, err.Error()))
+
+		default:
+			log.Error("error while changing pr reviewers", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, api.InternalError())
+		}
+		return
+	}
+
+	log.Info("pr reviewers changed", slog.Int("reviewers", len(resp.AssignedReviewers)))
+	render.JSON(w, r, api.PrResponse{PullRequest: *resp})
+}
+
+type SetLabelsRequest struct {
+	PrID   string   `json:"pull_request_id" validate:"required"`
+	Labels []string `json:"labels"          validate:"max=20,dive,required,max=32"`
+}
+
+func (h *PrHandler) SetLabels(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.pr.SetLabels"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input SetLabelsRequest
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := h.service.SetLabels(ctx, input.PrID, input.Labels)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			log.Info("resource not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+		case errors.Is(err, repo.ErrPRMerged):
+			log.Info("pr is merged", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRMerged, err.Error()))
+
+		case errors.Is(err, repo.ErrPRClosed):
+			log.Info("pr is closed", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, api.Error(api.ErrCodePRClosed, err.Error()))
+
+		default:
+			log.Error("error while setting pr labels", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, api.InternalError())
+		}
+		return
+	}
+
+	log.Info("pr labels set", slog.Int("labels", len(resp.Labels)))
+	render.JSON(w, r, api.PrResponse{PullRequest: *resp})
+}
@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"avito-intership-2025/internal/http/api"
 	"avito-intership-2025/internal/http/handlers"
 	"avito-intership-2025/internal/http/handlers/mocks"
 	"avito-intership-2025/internal/http/handlers/pr"
+	"avito-intership-2025/internal/lib/actor"
+	"avito-intership-2025/internal/models"
 	repo "avito-intership-2025/internal/repository"
 
 	"github.com/stretchr/testify/assert"
@@ -38,7 +41,7 @@ func TestPrHandler_Create_Success(t *testing.T) {
 		AuthorID: "u1",
 		Status:   "open",
 	}
-	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1").Return(expectedPR, nil)
+	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1", []string(nil), []string(nil)).Return(expectedPR, nil)
 
 	h.Create(w, req)
 
@@ -49,6 +52,23 @@ func TestPrHandler_Create_Success(t *testing.T) {
 	assert.Equal(t, *expectedPR, resp.PullRequest)
 }
 
+func TestPrHandler_Create_WithFiles(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","pull_request_name":"My PR","author_id":"u1","files":["docs/api.md","main.go"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/pr/create", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedPR := &api.PullRequestSchema{ID: "pr1", Status: "OPEN", AssignedReviewers: []string{"d1", "u2"}}
+	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1", []string{"docs/api.md", "main.go"}, []string(nil)).
+		Return(expectedPR, nil)
+
+	h.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
 func TestPrHandler_Create_BadJSON(t *testing.T) {
 	mockService := mocks.NewMockPrService(t)
 	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
@@ -92,7 +112,7 @@ func TestPrHandler_Create_PRExists(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/pr/create", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
-	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1").Return(nil, repo.ErrPRExists)
+	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1", []string(nil), []string(nil)).Return(nil, repo.ErrPRExists)
 
 	h.Create(w, req)
 
@@ -110,7 +130,7 @@ func TestPrHandler_Create_NotFound(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/pr/create", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
-	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1").Return(nil, repo.ErrNotFound)
+	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1", []string(nil), []string(nil)).Return(nil, repo.ErrNotFound)
 
 	h.Create(w, req)
 
@@ -119,6 +139,25 @@ func TestPrHandler_Create_NotFound(t *testing.T) {
 	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
 }
 
+func TestPrHandler_Create_ExcludedByRules(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	reqBody := pr.CreateRequest{PrID: "pr1", PrName: "My PR", AuthorId: "u1"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/pr/create", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1", []string(nil), []string(nil)).
+		Return(nil, repo.ErrExcludedByRules)
+
+	h.Create(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeExcludedByRules, resp.Error.Code)
+}
+
 func TestPrHandler_Create_InternalError(t *testing.T) {
 	mockService := mocks.NewMockPrService(t)
 	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
@@ -128,7 +167,7 @@ func TestPrHandler_Create_InternalError(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/pr/create", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
-	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1").Return(nil, errors.New("db error"))
+	mockService.On("Create", mock.Anything, "pr1", "My PR", "u1", []string(nil), []string(nil)).Return(nil, errors.New("db error"))
 
 	h.Create(w, req)
 
@@ -247,7 +286,76 @@ func TestPrHandler_Reassign_Success(t *testing.T) {
 	assert.Equal(t, expectedResp, &resp)
 }
 
-// Остальные кейсы Reassign: BadJSON, ValidationError, NotFound, NoCandidate, PRMerged, NotAssigned, InternalError
+func TestPrHandler_Reassign_Preview(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body, _ := json.Marshal(pr.ReassignRequest{PrID: "pr1", OldReviewerID: "u1", Preview: true})
+	req := httptest.NewRequest(http.MethodPost, "/pr/reassign", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expected := &api.AssignmentPreviewResponse{
+		AuthorID:         "a1",
+		ReplacedReviewer: "u1",
+		Eligible:         []string{"u2"},
+		Excluded:         []api.ExcludedCandidate{{UserID: "a1", Reason: "author"}},
+		Picked:           []string{"u2"},
+	}
+	mockService.On("PreviewReassign", mock.Anything, "pr1", "u1").Return(expected, nil)
+
+	h.Reassign(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.AssignmentPreviewResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, &resp)
+	mockService.AssertNotCalled(t, "Reassign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPrHandler_PreviewAssignment_Success(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body, _ := json.Marshal(pr.PreviewAssignmentRequest{AuthorID: "a1", Labels: []string{"go"}})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/previewAssignment", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expected := &api.AssignmentPreviewResponse{
+		AuthorID: "a1",
+		Eligible: []string{"u2", "u3"},
+		Excluded: []api.ExcludedCandidate{{UserID: "a1", Reason: "author"}},
+		Picked:   []string{"u2", "u3"},
+	}
+	mockService.On("PreviewCreate", mock.Anything, "a1", []string(nil), []string{"go"}).Return(expected, nil)
+
+	h.PreviewAssignment(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.AssignmentPreviewResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, &resp)
+}
+
+func TestPrHandler_PreviewAssignment_AuthorNotFound(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/previewAssignment", bytes.NewReader([]byte(`{"author_id":"ghost"}`)))
+	w := httptest.NewRecorder()
+
+	mockService.On("PreviewCreate", mock.Anything, "ghost", []string(nil), []string(nil)).Return(nil, repo.ErrNotFound)
+
+	h.PreviewAssignment(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
+
+// Остальные кейсы Reassign: BadJSON, ValidationError, NotFound, NoCandidate, ExcludedByRules, PRMerged,
+// NotAssigned, InternalError
 func TestPrHandler_Reassign_Errors(t *testing.T) {
 	mockService := mocks.NewMockPrService(t)
 	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
@@ -260,7 +368,9 @@ func TestPrHandler_Reassign_Errors(t *testing.T) {
 	}{
 		{"NotFound", repo.ErrNotFound, http.StatusNotFound, api.ErrCodeNotFound},
 		{"NoCandidate", repo.ErrNoCandidate, http.StatusConflict, api.ErrCodeNoCandidate},
+		{"ExcludedByRules", repo.ErrExcludedByRules, http.StatusConflict, api.ErrCodeExcludedByRules},
 		{"PRMerged", repo.ErrPRMerged, http.StatusConflict, api.ErrCodePRMerged},
+		{"PRClosed", repo.ErrPRClosed, http.StatusConflict, api.ErrCodePRClosed},
 		{"NotAssigned", repo.ErrNotAssigned, http.StatusConflict, api.ErrCodeNotAssigned},
 		{"InternalError", errors.New("db error"), http.StatusInternalServerError, api.ErrInternalErr},
 	}
@@ -282,3 +392,665 @@ func TestPrHandler_Reassign_Errors(t *testing.T) {
 		})
 	}
 }
+
+// ----------------- TopUpReviewers -----------------
+func TestPrHandler_TopUpReviewers_Success(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/topUpReviewers", nil)
+	w := httptest.NewRecorder()
+
+	prs := []api.PullRequestSchema{
+		{ID: "pr1", AssignedReviewers: []string{"u2", "u3"}, NeedMoreReviewers: false},
+	}
+	mockService.On("TopUpReviewers", mock.Anything).Return(prs, nil)
+
+	h.TopUpReviewers(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.TopUpResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, prs, resp.PullRequests)
+}
+
+func TestPrHandler_TopUpReviewers_InternalError(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/topUpReviewers", nil)
+	w := httptest.NewRecorder()
+
+	mockService.On("TopUpReviewers", mock.Anything).Return(nil, errors.New("db error"))
+
+	h.TopUpReviewers(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrInternalErr, resp.Error.Code)
+}
+
+// ----------------- Draft / Ready / Close / Reopen -----------------
+func TestPrHandler_Create_Draft(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","pull_request_name":"My PR","author_id":"u1","draft":true}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedPR := &api.PullRequestSchema{
+		ID:                "pr1",
+		Name:              "My PR",
+		AuthorID:          "u1",
+		Status:            "DRAFT",
+		AssignedReviewers: []string{},
+	}
+	mockService.On("CreateDraft", mock.Anything, "pr1", "My PR", "u1", []string(nil)).Return(expectedPR, nil)
+
+	h.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp api.PrResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedPR, resp.PullRequest)
+	mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPrHandler_Create_DraftWithFiles(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","pull_request_name":"My PR","author_id":"u1","draft":true,"files":["docs/a.md"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.Create(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrBadRequest, resp.Error.Code)
+	mockService.AssertNotCalled(t, "CreateDraft", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPrHandler_Close_Success(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/close", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedPR := &api.PullRequestSchema{ID: "pr1", Status: "CLOSED", AssignedReviewers: []string{"u2"}}
+	mockService.On("Close", mock.Anything, "pr1").Return(expectedPR, nil)
+
+	h.Close(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PrResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedPR, resp.PullRequest)
+}
+
+func TestPrHandler_StatusChange_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		mockErr     error
+		wantStatus  int
+		wantErrCode string
+	}{
+		{"ReadyNotFound", "Ready", repo.ErrNotFound, http.StatusNotFound, api.ErrCodeNotFound},
+		{"ReadyClosed", "Ready", repo.ErrPRClosed, http.StatusConflict, api.ErrCodePRClosed},
+		{"CloseMerged", "Close", repo.ErrPRMerged, http.StatusConflict, api.ErrCodePRMerged},
+		{"ReopenDraft", "Reopen", repo.ErrTransition, http.StatusConflict, api.ErrCodeTransition},
+		{"ReopenInternal", "Reopen", errors.New("db error"), http.StatusInternalServerError, api.ErrInternalErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewMockPrService(t)
+			h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+			handle := map[string]http.HandlerFunc{
+				"Ready":  h.Ready,
+				"Close":  h.Close,
+				"Reopen": h.Reopen,
+			}[tt.method]
+
+			body := []byte(`{"pull_request_id":"pr1"}`)
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/status", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			mockService.On(tt.method, mock.Anything, "pr1").Return(nil, tt.mockErr).Once()
+
+			handle(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			resp := handlers.DecodeErrorResponse(t, w.Body)
+			assert.Equal(t, tt.wantErrCode, resp.Error.Code)
+		})
+	}
+}
+
+func TestPrHandler_Merge_DraftPR(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("Merge", mock.Anything, "pr1").Return(nil, repo.ErrTransition)
+
+	h.Merge(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeTransition, resp.Error.Code)
+}
+
+// ----------------- Review -----------------
+func TestPrHandler_Review_Success(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","reviewer_id":"u2","verdict":"APPROVED","comment":"lgtm"}`)
+	req := reviewRequest(body, "u2")
+	w := httptest.NewRecorder()
+
+	expectedPR := &api.PullRequestSchema{
+		ID:                "pr1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+		Verdicts:          map[string]api.VerdictSchema{"u2": {Verdict: "APPROVED", Comment: "lgtm"}},
+	}
+	mockService.On("SubmitReview", mock.Anything, "pr1", "u2", "APPROVED", "lgtm").Return(expectedPR, nil)
+
+	h.Review(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PrResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedPR, resp.PullRequest)
+}
+
+func TestPrHandler_Review_InvalidVerdict(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","reviewer_id":"u2","verdict":"MAYBE"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.Review(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestPrHandler_Review_NotAssigned(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","verdict":"CHANGES_REQUESTED"}`)
+	req := reviewRequest(body, "u9")
+	w := httptest.NewRecorder()
+
+	mockService.On("SubmitReview", mock.Anything, "pr1", "u9", "CHANGES_REQUESTED", "").Return(nil, repo.ErrNotAssigned)
+
+	h.Review(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotAssigned, resp.Error.Code)
+}
+
+func TestPrHandler_Review_ReviewerMismatch(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	// пользователь u3 пытается апрувить от имени u2
+	body := []byte(`{"pull_request_id":"pr1","reviewer_id":"u2","verdict":"APPROVED"}`)
+	w := httptest.NewRecorder()
+
+	h.Review(w, reviewRequest(body, "u3"))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeForbidden, resp.Error.Code)
+	mockService.AssertNotCalled(t, "SubmitReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPrHandler_Review_NoSubject(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","reviewer_id":"u2","verdict":"APPROVED"}`)
+	w := httptest.NewRecorder()
+
+	h.Review(w, reviewRequest(body, ""))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeForbidden, resp.Error.Code)
+}
+
+// reviewRequest - запрос вердикта от пользователя subject, как его передает AuthMiddleware.
+func reviewRequest(body []byte, subject string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewReader(body))
+	return req.WithContext(actor.WithSubject(req.Context(), subject))
+}
+
+func TestPrHandler_Merge_NotEnoughApprovals(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("Merge", mock.Anything, "pr1").Return(nil, repo.ErrNoApprovals)
+
+	h.Merge(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNoApprovals, resp.Error.Code)
+}
+
+// ----------------- Add/Remove reviewer -----------------
+func TestPrHandler_AddReviewer_Success(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","user_id":"u3"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedPR := &api.PullRequestSchema{
+		ID:                "pr1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2", "u3"},
+		ReviewerLoads:     map[string]int{"u3": 1},
+	}
+	mockService.On("AddReviewer", mock.Anything, "pr1", "u3").Return(expectedPR, nil)
+
+	h.AddReviewer(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PrResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedPR, resp.PullRequest)
+}
+
+func TestPrHandler_AddReviewer_NoCandidate(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","user_id":"a1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("AddReviewer", mock.Anything, "pr1", "a1").Return(nil, repo.ErrNoCandidate)
+
+	h.AddReviewer(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNoCandidate, resp.Error.Code)
+}
+
+func TestPrHandler_AddReviewer_Draft(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","user_id":"u3"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("AddReviewer", mock.Anything, "pr1", "u3").Return(nil, repo.ErrTransition)
+
+	h.AddReviewer(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeTransition, resp.Error.Code)
+}
+
+func TestPrHandler_AddReviewer_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.AddReviewer(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestPrHandler_RemoveReviewer_Errors(t *testing.T) {
+	cases := []struct {
+		err      error
+		wantCode int
+		wantErr  string
+	}{
+		{repo.ErrNotFound, http.StatusNotFound, api.ErrCodeNotFound},
+		{repo.ErrPRMerged, http.StatusConflict, api.ErrCodePRMerged},
+		{repo.ErrNotAssigned, http.StatusConflict, api.ErrCodeNotAssigned},
+	}
+
+	for _, tc := range cases {
+		mockService := mocks.NewMockPrService(t)
+		h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+		body := []byte(`{"pull_request_id":"pr1","user_id":"u2"}`)
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/removeReviewer", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		mockService.On("RemoveReviewer", mock.Anything, "pr1", "u2").Return(nil, tc.err)
+
+		h.RemoveReviewer(w, req)
+
+		assert.Equal(t, tc.wantCode, w.Code)
+		resp := handlers.DecodeErrorResponse(t, w.Body)
+		assert.Equal(t, tc.wantErr, resp.Error.Code)
+	}
+}
+
+// ----------------- Labels -----------------
+func TestPrHandler_Create_WithLabels(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"a1","labels":["go","postgres"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedPR := &api.PullRequestSchema{
+		ID:                "pr1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+		Labels:            []string{"go", "postgres"},
+	}
+	mockService.On("Create", mock.Anything, "pr1", "Add search", "a1", []string(nil), []string{"go", "postgres"}).
+		Return(expectedPR, nil)
+
+	h.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp api.PrResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedPR, resp.PullRequest)
+}
+
+func TestPrHandler_SetLabels_Success(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"pull_request_id":"pr1","labels":["frontend"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/setLabels", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedPR := &api.PullRequestSchema{
+		ID:     "pr1",
+		Status: "OPEN",
+		Labels: []string{"frontend"},
+	}
+	mockService.On("SetLabels", mock.Anything, "pr1", []string{"frontend"}).Return(expectedPR, nil)
+
+	h.SetLabels(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PrResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedPR, resp.PullRequest)
+}
+
+func TestPrHandler_SetLabels_Errors(t *testing.T) {
+	cases := []struct {
+		err      error
+		wantCode int
+		wantErr  string
+	}{
+		{repo.ErrNotFound, http.StatusNotFound, api.ErrCodeNotFound},
+		{repo.ErrPRMerged, http.StatusConflict, api.ErrCodePRMerged},
+		{repo.ErrPRClosed, http.StatusConflict, api.ErrCodePRClosed},
+	}
+
+	for _, tc := range cases {
+		mockService := mocks.NewMockPrService(t)
+		h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+		body := []byte(`{"pull_request_id":"pr1","labels":["go"]}`)
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/setLabels", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		mockService.On("SetLabels", mock.Anything, "pr1", []string{"go"}).Return(nil, tc.err)
+
+		h.SetLabels(w, req)
+
+		assert.Equal(t, tc.wantCode, w.Code)
+		resp := handlers.DecodeErrorResponse(t, w.Body)
+		assert.Equal(t, tc.wantErr, resp.Error.Code)
+	}
+}
+
+// Get
+func TestPrHandler_Get_Success(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	expected := &api.PullRequestSchema{ID: "pr1", Name: "PR", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{"u2"}}
+	mockService.On("Get", mock.Anything, "pr1").Return(expected, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id=pr1", nil)
+	w := httptest.NewRecorder()
+	h.Get(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PrResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, *expected, resp.PullRequest)
+}
+
+func TestPrHandler_Get_Errors(t *testing.T) {
+	cases := []struct {
+		name     string
+		url      string
+		err      error
+		wantCode int
+		wantErr  string
+	}{
+		{"missing id", "/pullRequest/get", nil, http.StatusBadRequest, api.ErrBadRequest},
+		{"not found", "/pullRequest/get?pull_request_id=pr1", repo.ErrNotFound, http.StatusNotFound, api.ErrCodeNotFound},
+		{"internal", "/pullRequest/get?pull_request_id=pr1", errors.New("db"), http.StatusInternalServerError, api.ErrInternalErr},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := mocks.NewMockPrService(t)
+			h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+			if tc.err != nil {
+				mockService.On("Get", mock.Anything, "pr1").Return(nil, tc.err).Once()
+			}
+
+			w := httptest.NewRecorder()
+			h.Get(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			assert.Equal(t, tc.wantCode, w.Code)
+			assert.Equal(t, tc.wantErr, handlers.DecodeErrorResponse(t, w.Body).Error.Code)
+		})
+	}
+}
+
+// List
+func TestPrHandler_List_ParsesFilters(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 15, 9, 0, 0, 0, time.UTC)
+	want := &models.PrListFilter{
+		Status:      "MERGED",
+		AuthorID:    "u1",
+		ReviewerID:  "u2",
+		TeamName:    "backend",
+		CreatedFrom: &from,
+		MergedTo:    &to,
+		SortBy:      models.PrSortMergedAt,
+		Desc:        false,
+		Cursor:      "abc",
+		Limit:       5,
+	}
+	expected := &api.PrListResponse{
+		PullRequests: []api.PullRequestSchema{{ID: "pr1", AuthorID: "u1", Status: "MERGED", AssignedReviewers: []string{"u2"}}},
+		NextCursor:   "next",
+	}
+	mockService.On("List", mock.Anything, want).Return(expected, nil).Once()
+
+	url := "/pullRequest/list?status=MERGED&author_id=u1&reviewer_id=u2&team_name=backend" +
+		"&created_from=2025-10-01&merged_to=2025-10-15T12:00:00%2B03:00&sort_by=merged_at&order=asc&cursor=abc&limit=5"
+	w := httptest.NewRecorder()
+	h.List(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PrListResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, *expected, resp)
+}
+
+func TestPrHandler_List_Defaults(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	mockService.On("List", mock.Anything, &models.PrListFilter{
+		SortBy: models.PrSortCreatedAt,
+		Desc:   true,
+		Limit:  20,
+	}).Return(&api.PrListResponse{PullRequests: []api.PullRequestSchema{}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	h.List(w, httptest.NewRequest(http.MethodGet, "/pullRequest/list", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPrHandler_List_BadParams(t *testing.T) {
+	for _, query := range []string{
+		"status=ACTIVE",
+		"sort_by=title",
+		"order=up",
+		"limit=0",
+		"limit=101",
+		"created_to=yesterday",
+	} {
+		t.Run(query, func(t *testing.T) {
+			mockService := mocks.NewMockPrService(t)
+			h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+			w := httptest.NewRecorder()
+			h.List(w, httptest.NewRequest(http.MethodGet, "/pullRequest/list?"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, api.ErrBadRequest, handlers.DecodeErrorResponse(t, w.Body).Error.Code)
+		})
+	}
+}
+
+func TestPrHandler_List_InvalidCursor(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	mockService.On("List", mock.Anything, mock.Anything).Return(nil, repo.ErrInvalidCursor).Once()
+
+	w := httptest.NewRecorder()
+	h.List(w, httptest.NewRequest(http.MethodGet, "/pullRequest/list?cursor=zzz", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, api.ErrBadRequest, handlers.DecodeErrorResponse(t, w.Body).Error.Code)
+}
+
+// Search
+func TestPrHandler_Search_Success(t *testing.T) {
+	mockService := mocks.NewMockPrService(t)
+	h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+	expected := &api.PrSearchResponse{
+		Query: "migration",
+		PullRequests: []api.PrSearchResult{{
+			PullRequestSchema: api.PullRequestSchema{ID: "pr1", Name: "Add migration", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{}},
+			Rank:              0.6,
+		}},
+	}
+	mockService.On("Search", mock.Anything, &models.PrSearchFilter{
+		Query:    "migration",
+		Status:   "OPEN",
+		TeamName: "backend",
+		Limit:    5,
+	}).Return(expected, nil).Once()
+
+	w := httptest.NewRecorder()
+	h.Search(w, httptest.NewRequest(http.MethodGet, "/pullRequest/search?q=+migration+&status=OPEN&team_name=backend&limit=5", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PrSearchResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, *expected, resp)
+}
+
+func TestPrHandler_Search_BadParams(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"q=+++",
+		"q=" + string(bytes.Repeat([]byte("a"), 201)),
+		"q=fix&status=ACTIVE",
+		"q=fix&limit=1000",
+	} {
+		t.Run(query, func(t *testing.T) {
+			mockService := mocks.NewMockPrService(t)
+			h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+
+			w := httptest.NewRecorder()
+			h.Search(w, httptest.NewRequest(http.MethodGet, "/pullRequest/search?"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, api.ErrBadRequest, handlers.DecodeErrorResponse(t, w.Body).Error.Code)
+		})
+	}
+}
+
+func TestPrHandler_Search_Errors(t *testing.T) {
+	cases := []struct {
+		err      error
+		wantCode int
+		wantErr  string
+	}{
+		{repo.ErrInvalidQuery, http.StatusBadRequest, api.ErrBadRequest},
+		{errors.New("db"), http.StatusInternalServerError, api.ErrInternalErr},
+	}
+
+	for _, tc := range cases {
+		mockService := mocks.NewMockPrService(t)
+		h := pr.NewPrHandler(handlers.NewLogger(), mockService)
+		mockService.On("Search", mock.Anything, mock.Anything).Return(nil, tc.err).Once()
+
+		w := httptest.NewRecorder()
+		h.Search(w, httptest.NewRequest(http.MethodGet, "/pullRequest/search?q=%26%26", nil))
+
+		assert.Equal(t, tc.wantCode, w.Code)
+		assert.Equal(t, tc.wantErr, handlers.DecodeErrorResponse(t, w.Body).Error.Code)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"avito-intership-2025/internal/http/api"
 	"avito-intership-2025/internal/lib/sl"
@@ -18,6 +19,10 @@ import (
 type teamService interface {
 	Add(ctx context.Context, teamName string, users []api.TeamMember) (*api.TeamSchema, error)
 	Get(ctx context.Context, teamName string) (*api.TeamSchema, error)
+	GetSettings(ctx context.Context, teamName string) (*api.TeamSettingsSchema, error)
+	SetSettings(ctx context.Context, settings *api.TeamSettingsUpdate) (*api.TeamSettingsSchema, error)
+	DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*api.DeactivateUsersResponse, error)
+	GetPairings(ctx context.Context, teamName string, days *int) (*api.PairingMatrixResponse, error)
 }
 
 type TeamHandler struct {
@@ -123,3 +128,220 @@ func (h *TeamHandler) Get(w http.ResponseWriter, r *http.Request) {
 	log.Info("team retrieved")
 	render.JSON(w, r, resp)
 }
+
+func (h *TeamHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.team.GetSettings"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "team_name is required"))
+		return
+	}
+
+	resp, err := h.service.GetSettings(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("team not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while retrieving team settings", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	render.JSON(w, r, api.TeamSettingsResponse{Settings: *resp})
+}
+
+// GetPairings отдает матрицу пар автор-ревьюер команды, days ограничивает окно последними днями.
+func (h *TeamHandler) GetPairings(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.team.GetPairings"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "team_name is required"))
+		return
+	}
+
+	var days *int
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, api.Error(api.ErrBadRequest, "days must be a positive integer"))
+			return
+		}
+		days = &n
+	}
+
+	resp, err := h.service.GetPairings(ctx, teamName, days)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("team not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while building pairing matrix", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	render.JSON(w, r, resp)
+}
+
+// SetSettingsRequest - изменение настроек команды: пропущенные поля сохраняют прежние значения,
+// Reset сбрасывает перечисленные поля к значениям по умолчанию.
+type SetSettingsRequest struct {
+	TeamName          string `json:"team_name"          validate:"required,max=16"`
+	ReviewersCount    *int   `json:"reviewers_count"    validate:"omitempty,gte=0,lte=10"`
+	MaxOpenReviews    *int   `json:"max_open_reviews"   validate:"omitempty,gte=1"`
+	RequiredApprovals *int   `json:"required_approvals" validate:"omitempty,gte=1"`
+	// правила состава ревьюеров по уровням, см. api.TeamSettingsSchema
+	MinSeniorReviewers     *int  `json:"min_senior_reviewers"      validate:"omitempty,gte=1"`
+	MinJuniorReviewers     *int  `json:"min_junior_reviewers"      validate:"omitempty,gte=1"`
+	MaxJuniorReviewers     *int  `json:"max_junior_reviewers"      validate:"omitempty,gte=0"`
+	SeniorForJuniorAuthors *bool `json:"senior_for_junior_authors"`
+	// окно ротации ревьюеров, задается не больше одного
+	RotationWindowPRs  *int     `json:"rotation_window_prs"  validate:"omitempty,gte=1"`
+	RotationWindowDays *int     `json:"rotation_window_days" validate:"omitempty,gte=1"`
+	Reset              []string `json:"reset"                validate:"omitempty,dive,required"`
+}
+
+func (h *TeamHandler) SetSettings(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.team.SetSettings"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input SetSettingsRequest
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		var validateError validator.ValidationErrors
+		_ = errors.As(err, &validateError)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := h.service.SetSettings(ctx, &api.TeamSettingsUpdate{
+		TeamName:               input.TeamName,
+		ReviewersCount:         input.ReviewersCount,
+		MaxOpenReviews:         input.MaxOpenReviews,
+		RequiredApprovals:      input.RequiredApprovals,
+		MinSeniorReviewers:     input.MinSeniorReviewers,
+		MinJuniorReviewers:     input.MinJuniorReviewers,
+		MaxJuniorReviewers:     input.MaxJuniorReviewers,
+		SeniorForJuniorAuthors: input.SeniorForJuniorAuthors,
+		RotationWindowPRs:      input.RotationWindowPRs,
+		RotationWindowDays:     input.RotationWindowDays,
+		Reset:                  input.Reset,
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrInvalidSettings) {
+			log.Info("invalid team settings", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, api.Error(api.ErrBadRequest, err.Error()))
+			return
+		}
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("team not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while saving team settings", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	log.Info("team settings saved")
+	render.JSON(w, r, api.TeamSettingsResponse{Settings: *resp})
+}
+
+type DeactivateUsersRequest struct {
+	TeamName string   `json:"team_name" validate:"required,max=16"`
+	UserIDs  []string `json:"user_ids"  validate:"omitempty,dive,required"`
+}
+
+func (h *TeamHandler) DeactivateUsers(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.team.DeactivateUsers"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input DeactivateUsersRequest
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		var validateError validator.ValidationErrors
+		_ = errors.As(err, &validateError)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := h.service.DeactivateUsers(ctx, input.TeamName, input.UserIDs)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("team or user not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while deactivating team users", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	log.Info("team users deactivated",
+		slog.Int("deactivated", len(resp.DeactivatedUsers)),
+		slog.Int("reassigned", len(resp.Reassigned)),
+		slog.Int("understaffed", len(resp.Understaffed)),
+	)
+	render.JSON(w, r, resp)
+}
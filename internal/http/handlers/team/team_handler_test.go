@@ -202,3 +202,186 @@ func TestTeamHandler_Get_InternalError(t *testing.T) {
 	resp := handlers.DecodeErrorResponse(t, w.Body)
 	assert.Equal(t, api.ErrInternalErr, resp.Error.Code)
 }
+
+// Settings
+
+func TestTeamHandler_GetSettings_Success(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/settings?team_name=team1", nil)
+	w := httptest.NewRecorder()
+
+	expected := &api.TeamSettingsSchema{TeamName: "team1", ReviewersCount: 2}
+	mockService.On("GetSettings", mock.Anything, "team1").Return(expected, nil)
+
+	h.GetSettings(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.TeamSettingsResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp.Settings)
+}
+
+func TestTeamHandler_GetSettings_NotFound(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/settings?team_name=ghost", nil)
+	w := httptest.NewRecorder()
+
+	mockService.On("GetSettings", mock.Anything, "ghost").Return(nil, repo.ErrNotFound)
+
+	h.GetSettings(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
+
+func TestTeamHandler_GetPairings_Success(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/pairings?team_name=team1&days=30", nil)
+	w := httptest.NewRecorder()
+
+	days := 30
+	expected := &api.PairingMatrixResponse{
+		TeamName: "team1",
+		Days:     &days,
+		Members:  []string{"u1", "u2"},
+		Matrix:   map[string]map[string]int{"u1": {"u2": 3}, "u2": {"u1": 0}},
+		Total:    3,
+	}
+	mockService.On("GetPairings", mock.Anything, "team1", &days).Return(expected, nil)
+
+	h.GetPairings(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.PairingMatrixResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp)
+}
+
+func TestTeamHandler_GetPairings_BadDays(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/pairings?team_name=team1&days=0", nil)
+	w := httptest.NewRecorder()
+
+	h.GetPairings(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrBadRequest, resp.Error.Code)
+}
+
+func TestTeamHandler_SetSettings_Success(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"team_name":"team1","reviewers_count":3,"max_open_reviews":4,"reset":["required_approvals"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/team/settings", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	reviewers, maxOpen := 3, 4
+	update := &api.TeamSettingsUpdate{
+		TeamName:       "team1",
+		ReviewersCount: &reviewers,
+		MaxOpenReviews: &maxOpen,
+		Reset:          []string{"required_approvals"},
+	}
+	expected := &api.TeamSettingsSchema{
+		TeamName:       "team1",
+		ReviewersCount: 3,
+		MaxOpenReviews: &maxOpen,
+	}
+	mockService.On("SetSettings", mock.Anything, update).Return(expected, nil)
+
+	h.SetSettings(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.TeamSettingsResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp.Settings)
+}
+
+func TestTeamHandler_SetSettings_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	// max_open_reviews должен быть положительным
+	body := []byte(`{"team_name":"team1","max_open_reviews":0}`)
+	req := httptest.NewRequest(http.MethodPost, "/team/settings", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetSettings(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestTeamHandler_DeactivateUsers_Success(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"team_name":"team1","user_ids":["u1","u2"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivateUsers", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expected := &api.DeactivateUsersResponse{
+		TeamName:         "team1",
+		DeactivatedUsers: []string{"u1", "u2"},
+		Reassigned: []api.ReviewReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "u1", ReplacedBy: "u3"},
+		},
+		Understaffed: []string{"pr-2"},
+	}
+	mockService.On("DeactivateUsers", mock.Anything, "team1", []string{"u1", "u2"}).Return(expected, nil)
+
+	h.DeactivateUsers(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.DeactivateUsersResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp)
+}
+
+func TestTeamHandler_DeactivateUsers_NotFound(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"team_name":"ghost"}`)
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivateUsers", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("DeactivateUsers", mock.Anything, "ghost", []string(nil)).Return(nil, repo.ErrNotFound)
+
+	h.DeactivateUsers(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
+
+func TestTeamHandler_DeactivateUsers_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockTeamService(t)
+	h := team.NewTeamHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"team_name":"team1","user_ids":[""]}`)
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivateUsers", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.DeactivateUsers(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
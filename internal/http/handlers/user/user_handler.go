@@ -18,6 +18,9 @@ import (
 type userService interface {
 	GetReview(ctx context.Context, userID string) (*api.GetReviewResponse, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*api.UserSchema, error)
+	SetSkills(ctx context.Context, userID string, skills []string) (*api.UserSchema, error)
+	SetSeniority(ctx context.Context, userID, seniority string) (*api.UserSchema, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*api.UserSchema, error)
 }
 
 type UserHandler struct {
@@ -84,6 +87,163 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, api.UserResponse{User: *resp})
 }
 
+type SetSkillsRequest struct {
+	UserID string   `json:"user_id" validate:"required"`
+	Skills []string `json:"skills"  validate:"max=20,dive,required,max=32"`
+}
+
+func (h *UserHandler) SetSkills(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.user.SetSkills"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input SetSkillsRequest
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := h.service.SetSkills(ctx, input.UserID, input.Skills)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("user not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while setting user skills", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	log.Info("user skills set", slog.Int("skills", len(resp.Skills)))
+	render.JSON(w, r, api.UserResponse{User: *resp})
+}
+
+type SetSeniorityRequest struct {
+	UserID    string `json:"user_id"   validate:"required"`
+	Seniority string `json:"seniority" validate:"required,oneof=junior middle senior"`
+}
+
+func (h *UserHandler) SetSeniority(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.user.SetSeniority"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input SetSeniorityRequest
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := h.service.SetSeniority(ctx, input.UserID, input.Seniority)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("user not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while setting user seniority", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	log.Info("user seniority set", slog.String("seniority", resp.Seniority))
+	render.JSON(w, r, api.UserResponse{User: *resp})
+}
+
+// SetMaxOpenReviewsRequest - null в max_open_reviews возвращает пользователю лимит команды.
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id"          validate:"required"`
+	MaxOpenReviews *int   `json:"max_open_reviews" validate:"omitempty,gte=1"`
+}
+
+func (h *UserHandler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.user.SetMaxOpenReviews"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ctx := r.Context()
+
+	var input SetMaxOpenReviewsRequest
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return
+	}
+
+	resp, err := h.service.SetMaxOpenReviews(ctx, input.UserID, input.MaxOpenReviews)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			log.Info("user not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+			return
+		}
+		log.Error("error while setting user max open reviews", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+		return
+	}
+
+	log.Info("user max open reviews set")
+	render.JSON(w, r, api.UserResponse{User: *resp})
+}
+
 func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	const op = "handlers.user.GetReview"
 	log := h.log.With(
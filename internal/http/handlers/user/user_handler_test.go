@@ -197,3 +197,139 @@ func TestUserHandler_GetReview_InternalError(t *testing.T) {
 	resp := handlers.DecodeErrorResponse(t, w.Body)
 	assert.Equal(t, api.ErrInternalErr, resp.Error.Code)
 }
+
+// SetSkills
+
+func TestUserHandler_SetSkills_Success(t *testing.T) {
+	mockService := mocks.NewMockUserService(t)
+	h := user.NewUserHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","skills":["go","postgres"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/setSkills", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedUser := &api.UserSchema{
+		UserID:   "u1",
+		Username: "User1",
+		TeamName: "team1",
+		IsActive: true,
+		Skills:   []string{"go", "postgres"},
+	}
+	mockService.On("SetSkills", mock.Anything, "u1", []string{"go", "postgres"}).Return(expectedUser, nil)
+
+	h.SetSkills(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.UserResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedUser, resp.User)
+}
+
+func TestUserHandler_SetSkills_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockUserService(t)
+	h := user.NewUserHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","skills":[""]}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/setSkills", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetSkills(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestUserHandler_SetSkills_NotFound(t *testing.T) {
+	mockService := mocks.NewMockUserService(t)
+	h := user.NewUserHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u404","skills":["go"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/setSkills", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("SetSkills", mock.Anything, "u404", []string{"go"}).Return(nil, repo.ErrNotFound)
+
+	h.SetSkills(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
+
+func TestUserHandler_SetSeniority_Success(t *testing.T) {
+	mockService := mocks.NewMockUserService(t)
+	h := user.NewUserHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","seniority":"senior"}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/setSeniority", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedUser := &api.UserSchema{
+		UserID:    "u1",
+		Username:  "User1",
+		TeamName:  "team1",
+		IsActive:  true,
+		Seniority: "senior",
+	}
+	mockService.On("SetSeniority", mock.Anything, "u1", "senior").Return(expectedUser, nil)
+
+	h.SetSeniority(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.UserResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedUser, resp.User)
+}
+
+func TestUserHandler_SetSeniority_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockUserService(t)
+	h := user.NewUserHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","seniority":"lead"}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/setSeniority", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetSeniority(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestUserHandler_SetMaxOpenReviews_ResetToTeamDefault(t *testing.T) {
+	mockService := mocks.NewMockUserService(t)
+	h := user.NewUserHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","max_open_reviews":null}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expectedUser := &api.UserSchema{UserID: "u1", Username: "User1", TeamName: "team1", IsActive: true}
+	mockService.On("SetMaxOpenReviews", mock.Anything, "u1", (*int)(nil)).Return(expectedUser, nil)
+
+	h.SetMaxOpenReviews(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.UserResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expectedUser, resp.User)
+}
+
+func TestUserHandler_SetMaxOpenReviews_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockUserService(t)
+	h := user.NewUserHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"user_id":"u1","max_open_reviews":0}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetMaxOpenReviews(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
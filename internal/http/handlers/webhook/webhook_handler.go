@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/sl"
+	repo "avito-intership-2025/internal/repository"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type webhookService interface {
+	AddSubscription(ctx context.Context, input *api.WebhookSubscriptionSchema) (*api.WebhookSubscriptionSchema, error)
+	ListSubscriptions(ctx context.Context) ([]api.WebhookSubscriptionSchema, error)
+	DeleteSubscription(ctx context.Context, id int) ([]api.WebhookSubscriptionSchema, error)
+	ListDeliveries(ctx context.Context, status string, subscriptionID int) ([]api.WebhookDeliverySchema, error)
+	Replay(ctx context.Context, deliveryID int64, subscriptionID int) (int, error)
+}
+
+type WebhookHandler struct {
+	log     *slog.Logger
+	service webhookService
+}
+
+func NewWebhookHandler(log *slog.Logger, s webhookService) *WebhookHandler {
+	return &WebhookHandler{
+		log:     log,
+		service: s,
+	}
+}
+
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.webhook.List"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	subs, err := h.service.ListSubscriptions(r.Context())
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	render.JSON(w, r, api.WebhookSubscriptionsResponse{Subscriptions: subs})
+}
+
+type AddRequest struct {
+	URL string `json:"url" validate:"required,url"`
+	// Secret - ключ подписи, без него генерируется случайный
+	Secret string `json:"secret" validate:"omitempty,min=16"`
+	// EventTypes - типы событий, пустой список - все
+	EventTypes []string `json:"event_types"`
+}
+
+func (h *WebhookHandler) Add(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.webhook.Add"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input AddRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	sub, err := h.service.AddSubscription(r.Context(), &api.WebhookSubscriptionSchema{
+		URL:        input.URL,
+		Secret:     input.Secret,
+		EventTypes: input.EventTypes,
+	})
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("webhook subscription added", slog.Int("subscription_id", sub.ID), slog.String("url", sub.URL))
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, api.WebhookSubscriptionResponse{Subscription: *sub})
+}
+
+type DeleteRequest struct {
+	SubscriptionID int `json:"subscription_id" validate:"required"`
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.webhook.Delete"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input DeleteRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	subs, err := h.service.DeleteSubscription(r.Context(), input.SubscriptionID)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("webhook subscription deleted", slog.Int("subscription_id", input.SubscriptionID))
+	render.JSON(w, r, api.WebhookSubscriptionsResponse{Subscriptions: subs})
+}
+
+func (h *WebhookHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.webhook.Deliveries"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	// оба фильтра необязательны
+	status := r.URL.Query().Get("status")
+	subscriptionID := 0
+	if raw := r.URL.Query().Get("subscription_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, api.Error(api.ErrBadRequest, "subscription_id must be an integer"))
+			return
+		}
+		subscriptionID = id
+	}
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), status, subscriptionID)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	render.JSON(w, r, api.WebhookDeliveriesResponse{Deliveries: deliveries})
+}
+
+type ReplayRequest struct {
+	// DeliveryID - повторить одну доставку; без нее повторяются все доставки в DEAD
+	DeliveryID int64 `json:"delivery_id"`
+	// SubscriptionID - ограничить повтор доставок в DEAD одной подпиской
+	SubscriptionID int `json:"subscription_id"`
+}
+
+func (h *WebhookHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.webhook.Replay"
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var input ReplayRequest
+	if !h.decode(w, r, log, &input) {
+		return
+	}
+
+	replayed, err := h.service.Replay(r.Context(), input.DeliveryID, input.SubscriptionID)
+	if err != nil {
+		h.renderError(w, r, log, err)
+		return
+	}
+
+	log.Info("webhook deliveries replayed", slog.Int("replayed", replayed))
+	render.JSON(w, r, api.WebhookReplayResponse{Replayed: replayed})
+}
+
+// decode читает и валидирует тело запроса, при ошибке сам пишет ответ.
+func (h *WebhookHandler) decode(w http.ResponseWriter, r *http.Request, log *slog.Logger, input any) bool {
+	if err := render.DecodeJSON(r.Body, input); err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, "bad request"))
+		return false
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		validateError := err.(validator.ValidationErrors)
+
+		log.Error("invalid request", sl.Err(err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.ValidationError(validateError))
+		return false
+	}
+
+	return true
+}
+
+func (h *WebhookHandler) renderError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, repo.ErrInvalidSubscription):
+		log.Info("invalid webhook request", sl.Err(err))
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, api.Error(api.ErrBadRequest, err.Error()))
+
+	case errors.Is(err, repo.ErrNotFound):
+		log.Info("resource not found", sl.Err(err))
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, api.Error(api.ErrCodeNotFound, err.Error()))
+
+	default:
+		log.Error("error while handling webhooks", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, api.InternalError())
+	}
+}
@@ -0,0 +1,129 @@
+package webhook_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/http/handlers"
+	"avito-intership-2025/internal/http/handlers/mocks"
+	"avito-intership-2025/internal/http/handlers/webhook"
+	repo "avito-intership-2025/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookHandler_Add_Success(t *testing.T) {
+	mockService := mocks.NewMockWebhookService(t)
+	h := webhook.NewWebhookHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"url":"https://ci.example.com/hooks","event_types":["pull_request.merged"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/add", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	expected := &api.WebhookSubscriptionSchema{
+		ID:         1,
+		URL:        "https://ci.example.com/hooks",
+		Secret:     "generated",
+		EventTypes: []string{"pull_request.merged"},
+	}
+	mockService.On("AddSubscription", mock.Anything, &api.WebhookSubscriptionSchema{
+		URL:        "https://ci.example.com/hooks",
+		EventTypes: []string{"pull_request.merged"},
+	}).Return(expected, nil)
+
+	h.Add(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp api.WebhookSubscriptionResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, resp.Subscription)
+}
+
+func TestWebhookHandler_Add_ValidationError(t *testing.T) {
+	mockService := mocks.NewMockWebhookService(t)
+	h := webhook.NewWebhookHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"url":"not a url"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/add", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.Add(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrValidationErr, resp.Error.Code)
+}
+
+func TestWebhookHandler_Add_InvalidSubscription(t *testing.T) {
+	mockService := mocks.NewMockWebhookService(t)
+	h := webhook.NewWebhookHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"url":"https://ci.example.com/hooks","event_types":["pull_request.deleted"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/add", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("AddSubscription", mock.Anything, mock.AnythingOfType("*api.WebhookSubscriptionSchema")).
+		Return(nil, fmt.Errorf("%w: unknown event type", repo.ErrInvalidSubscription))
+
+	h.Add(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrBadRequest, resp.Error.Code)
+}
+
+func TestWebhookHandler_Deliveries_Filters(t *testing.T) {
+	mockService := mocks.NewMockWebhookService(t)
+	h := webhook.NewWebhookHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/deliveries?status=DEAD&subscription_id=3", nil)
+	w := httptest.NewRecorder()
+
+	expected := []api.WebhookDeliverySchema{
+		{ID: 9, EventID: 4, EventType: "pull_request.created", SubscriptionID: 3, Status: "DEAD", Attempts: 8},
+	}
+	mockService.On("ListDeliveries", mock.Anything, "DEAD", 3).Return(expected, nil)
+
+	h.Deliveries(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.WebhookDeliveriesResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp.Deliveries)
+}
+
+func TestWebhookHandler_Deliveries_InvalidSubscriptionID(t *testing.T) {
+	mockService := mocks.NewMockWebhookService(t)
+	h := webhook.NewWebhookHandler(handlers.NewLogger(), mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/deliveries?subscription_id=abc", nil)
+	w := httptest.NewRecorder()
+
+	h.Deliveries(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWebhookHandler_Replay_NotFound(t *testing.T) {
+	mockService := mocks.NewMockWebhookService(t)
+	h := webhook.NewWebhookHandler(handlers.NewLogger(), mockService)
+
+	body := []byte(`{"delivery_id":42}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/replay", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.On("Replay", mock.Anything, int64(42), 0).Return(0, repo.ErrNotFound)
+
+	h.Replay(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := handlers.DecodeErrorResponse(t, w.Body)
+	assert.Equal(t, api.ErrCodeNotFound, resp.Error.Code)
+}
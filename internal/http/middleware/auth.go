@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/actor"
 	"github.com/go-chi/render"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -31,17 +32,21 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		tokenString, _ = strings.CutPrefix(tokenString, "Bearer ")
 
 		// Try admin token
-		role, ok := validateToken(tokenString, adminSecret)
-		if ok && role == "admin" {
+		claims, ok := validateToken(tokenString, adminSecret)
+		if ok && claims.role == "admin" {
 			ctx := context.WithValue(r.Context(), RoleKey, "admin")
+			ctx = actor.WithActor(ctx, claims.actor())
+			ctx = actor.WithSubject(ctx, claims.subject)
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
 
 		// Try user token
-		role, ok = validateToken(tokenString, userSecret)
-		if ok && role == "user" {
+		claims, ok = validateToken(tokenString, userSecret)
+		if ok && claims.role == "user" {
 			ctx := context.WithValue(r.Context(), RoleKey, "user")
+			ctx = actor.WithActor(ctx, claims.actor())
+			ctx = actor.WithSubject(ctx, claims.subject)
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
@@ -64,22 +69,37 @@ func AdminOnly(next http.Handler) http.Handler {
 	})
 }
 
-func validateToken(tokenString, secret string) (string, bool) {
+type tokenClaims struct {
+	role    string
+	subject string
+}
+
+// actor возвращает, кого записывать исполнителем действий в журнал ревью:
+// subject токена, а если его нет - роль.
+func (c tokenClaims) actor() string {
+	if c.subject != "" {
+		return c.subject
+	}
+	return c.role
+}
+
+func validateToken(tokenString, secret string) (tokenClaims, bool) {
 	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (any, error) {
 		return []byte(secret), nil
 	})
 
 	if err != nil || !token.Valid {
-		return "", false
+		return tokenClaims{}, false
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok {
 		roleVal, ok := claims["role"].(string)
 		if !ok {
-			return "", false
+			return tokenClaims{}, false
 		}
-		return roleVal, true
+		subject, _ := claims["sub"].(string)
+		return tokenClaims{role: roleVal, subject: subject}, true
 	}
 
-	return "", false
+	return tokenClaims{}, false
 }
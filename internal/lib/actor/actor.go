@@ -0,0 +1,36 @@
+// Package actor передает через контекст, кто выполняет действие: пользователь из JWT
+// или фоновый процесс.
+package actor
+
+import "context"
+
+// System - действия без запроса пользователя: фоновые воркеры, автоматические переназначения.
+const System = "system"
+
+type key struct{}
+
+func WithActor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, key{}, name)
+}
+
+type subjectKey struct{}
+
+// WithSubject запоминает id пользователя из subject токена.
+func WithSubject(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, userID)
+}
+
+// SubjectFromContext возвращает id пользователя из токена. В отличие от FromContext
+// не подставляет роль: без subject в токене - пустая строка.
+func SubjectFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(subjectKey{}).(string)
+	return userID
+}
+
+// FromContext возвращает исполнителя действия или System, если он не задан.
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(key{}).(string); ok && name != "" {
+		return name
+	}
+	return System
+}
@@ -0,0 +1,27 @@
+package actor_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/lib/actor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, actor.System, actor.FromContext(ctx))
+	assert.Equal(t, "alice", actor.FromContext(actor.WithActor(ctx, "alice")))
+	assert.Equal(t, actor.System, actor.FromContext(actor.WithActor(ctx, "")))
+}
+
+func TestSubjectFromContext(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, "", actor.SubjectFromContext(ctx))
+	assert.Equal(t, "u1", actor.SubjectFromContext(actor.WithSubject(ctx, "u1")))
+	// исполнитель без subject - роль, но пользователем он не считается
+	assert.Equal(t, "", actor.SubjectFromContext(actor.WithActor(ctx, "admin")))
+}
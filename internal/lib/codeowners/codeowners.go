@@ -0,0 +1,114 @@
+// Package codeowners разбирает файлы CODEOWNERS и сопоставляет пути с их шаблонами.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Pattern - скомпилированный шаблон пути в синтаксисе CODEOWNERS (gitignore).
+type Pattern struct {
+	re *regexp.Regexp
+}
+
+// Compile переводит шаблон в регулярное выражение:
+//   - "*" - любые символы внутри одного сегмента пути, "?" - один символ;
+//   - "**" - любое число сегментов;
+//   - шаблон с "/" в начале или середине привязан к корню репозитория, иначе ищется на любой глубине;
+//   - шаблон совпадает и с каталогом, и со всем его содержимым.
+func Compile(pattern string) (*Pattern, error) {
+	p := strings.TrimSpace(pattern)
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	anchored := strings.Contains(strings.TrimSuffix(p, "/"), "/")
+	p = strings.TrimPrefix(p, "/")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			switch {
+			case strings.HasPrefix(p[i:], "**/"):
+				b.WriteString("(?:.*/)?")
+				i += 2
+			case strings.HasPrefix(p[i:], "**"):
+				b.WriteString(".*")
+				i++
+			default:
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if dirOnly {
+		b.WriteString("/.*")
+	} else {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	return &Pattern{re: re}, nil
+}
+
+// Match проверяет путь относительно корня репозитория, ведущие "/" и "./" игнорируются.
+func (p *Pattern) Match(path string) bool {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "./"), "/")
+	return p.re.MatchString(path)
+}
+
+// Line - строка CODEOWNERS: шаблон и владельцы в исходном виде (@user, @org/team, email).
+type Line struct {
+	Pattern string
+	Owners  []string
+}
+
+// Parse разбирает содержимое CODEOWNERS, пропуская пустые строки и комментарии.
+// Строка без владельцев допустима: она снимает владельцев, заданных выше.
+func Parse(content string) ([]Line, error) {
+	lines := []Line{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for n := 1; scanner.Scan(); n++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if _, err := Compile(fields[0]); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		lines = append(lines, Line{Pattern: fields[0], Owners: fields[1:]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,58 @@
+package codeowners_test
+
+import (
+	"testing"
+
+	"avito-intership-2025/internal/lib/codeowners"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPattern_Match(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*", "any/file.go", true},
+		{"*.go", "internal/service/pr/pr_service.go", true},
+		{"*.go", "docs/final.yml", false},
+		{"/docs/", "docs/final.yml", true},
+		{"/docs/", "internal/docs/readme.md", false},
+		{"docs/", "internal/docs/readme.md", true},
+		{"migrations", "migrations/001_create_tables.up.sql", true},
+		{"internal/service/*", "internal/service/transaction.go", true},
+		{"internal/service/*", "cmd/internal/service/main.go", false},
+		{"**/handlers", "internal/http/handlers/utils.go", true},
+		{"internal/**/mocks/*.go", "internal/service/mocks/PrController.go", true},
+		{"internal/**/mocks/*.go", "internal/mocks/x.go", true},
+		{"cmd/?erver/", "cmd/server/main.go", true},
+		{"/cmd/server/main.go", "./cmd/server/main.go", true},
+		{"main.go", "cmd/server/main.go.bak", false},
+	}
+
+	for _, tc := range cases {
+		p, err := codeowners.Compile(tc.pattern)
+		require.NoError(t, err, tc.pattern)
+		assert.Equal(t, tc.want, p.Match(tc.path), "%s ~ %s", tc.pattern, tc.path)
+	}
+}
+
+func TestParse(t *testing.T) {
+	content := `
+# общие правила
+*            @org/backend
+/docs/       @u1 @u2   # документация
+*.sql
+`
+
+	lines, err := codeowners.Parse(content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []codeowners.Line{
+		{Pattern: "*", Owners: []string{"@org/backend"}},
+		{Pattern: "/docs/", Owners: []string{"@u1", "@u2"}},
+		{Pattern: "*.sql", Owners: []string{}},
+	}, lines)
+}
@@ -11,6 +11,10 @@ import (
 type Config struct {
 	Env        string     `yaml:"env"         env-default:"info"`
 	HTTPServer HTTPServer `yaml:"http_server"                    env-required:"true"`
+	Reviewers  Reviewers  `yaml:"reviewers"`
+	Absences   Absences   `yaml:"absences"`
+	ReviewSLA  ReviewSLA  `yaml:"review_sla"`
+	Webhooks   Webhooks   `yaml:"webhooks"`
 }
 
 type HTTPServer struct {
@@ -21,6 +25,39 @@ type HTTPServer struct {
 	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"                              end-default:"15s"`
 }
 
+// Reviewers задает стратегию выбора ревьюеров: общую и, при необходимости, отдельную для команд.
+type Reviewers struct {
+	Strategy string            `yaml:"strategy" env-default:"random"`
+	Teams    map[string]string `yaml:"teams"`
+}
+
+// Absences задает, как часто проверять начавшиеся отсутствия, чтобы снять
+// пользователей с открытых ревью. Нулевой интервал отключает проверку.
+type Absences struct {
+	CheckInterval time.Duration `yaml:"check_interval" env-default:"10m"`
+}
+
+// ReviewSLA задает срок, за который ревьюер должен оставить вердикт, иначе ревью
+// переназначается. Срок отсчитывается от назначения и выбирается по команде автора PR,
+// нулевой срок отключает переназначение (по умолчанию или для отдельной команды).
+type ReviewSLA struct {
+	ScanInterval time.Duration            `yaml:"scan_interval" env-default:"5m"`
+	Default      time.Duration            `yaml:"default"`
+	Teams        map[string]time.Duration `yaml:"teams"`
+}
+
+// Webhooks задает рассылку событий о PR подписчикам. Неудачная доставка повторяется
+// с удваивающейся задержкой от initial_backoff до max_backoff, после max_attempts
+// попыток доставка переходит в DEAD. Нулевой интервал отключает рассылку.
+type Webhooks struct {
+	DispatchInterval time.Duration `yaml:"dispatch_interval" env-default:"5s"`
+	BatchSize        int           `yaml:"batch_size"        env-default:"50"`
+	Timeout          time.Duration `yaml:"timeout"           env-default:"10s"`
+	MaxAttempts      int           `yaml:"max_attempts"      env-default:"8"`
+	InitialBackoff   time.Duration `yaml:"initial_backoff"   env-default:"30s"`
+	MaxBackoff       time.Duration `yaml:"max_backoff"       env-default:"1h"`
+}
+
 // MustLoad panics if config can not be found.
 func MustLoad() *Config {
 	configPath := fetchConfigPath()
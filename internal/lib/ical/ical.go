@@ -0,0 +1,244 @@
+// Package ical разбирает календари iCalendar (.ics) в периоды отсутствия.
+package ical
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event - событие VEVENT, сведенное к целым дням.
+type Event struct {
+	UID     string
+	Summary string
+	// Start и End - первый и последний день события включительно (полночь UTC)
+	Start time.Time
+	End   time.Time
+}
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+var durationRe = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// property - строка календаря вида NAME;PARAM=VALUE:value.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse возвращает события календаря, отмененные события (STATUS:CANCELLED) пропускаются.
+// Конец события берется из DTEND (для событий на целый день он не входит в период)
+// или из DURATION. Повторения (RRULE) не разворачиваются: учитывается только первое вхождение.
+func Parse(content string) ([]Event, error) {
+	lines := unfold(content)
+
+	var (
+		events  []Event
+		current map[string]property
+		eventNo int
+		// nested - глубина вложенных в VEVENT компонентов (VALARM), их свойства не нужны
+		nested int
+	)
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			current = make(map[string]property)
+			eventNo++
+
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN", i+1)
+			}
+			event, skip, err := toEvent(current)
+			if err != nil {
+				return nil, fmt.Errorf("event %d: %w", eventNo, err)
+			}
+			if !skip {
+				events = append(events, event)
+			}
+			current = nil
+
+		case current != nil && prop.name == "BEGIN":
+			nested++
+
+		case current != nil && prop.name == "END":
+			nested--
+
+		case current != nil && nested == 0:
+			current[prop.name] = prop
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("event %d: missing END:VEVENT", eventNo)
+	}
+
+	return events, nil
+}
+
+// unfold склеивает перенесенные строки: продолжение начинается с пробела или табуляции.
+func unfold(content string) []string {
+	raw := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	lines := make([]string, 0, len(raw))
+	for _, l := range raw {
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimRight(l, "\r"))
+	}
+	return lines
+}
+
+func parseProperty(line string) (property, error) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return property{}, fmt.Errorf("invalid content line %q", line)
+	}
+
+	parts := strings.Split(head, ";")
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  value,
+	}
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		prop.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+
+	return prop, nil
+}
+
+// toEvent собирает событие из свойств VEVENT. skip - событие отменено.
+func toEvent(props map[string]property) (Event, bool, error) {
+	if status, ok := props["STATUS"]; ok && strings.EqualFold(status.value, "CANCELLED") {
+		return Event{}, true, nil
+	}
+
+	uid := strings.TrimSpace(props["UID"].value)
+	if uid == "" {
+		return Event{}, false, fmt.Errorf("missing UID")
+	}
+
+	dtStart, ok := props["DTSTART"]
+	if !ok {
+		return Event{}, false, fmt.Errorf("missing DTSTART")
+	}
+	start, allDay, err := parseTime(dtStart)
+	if err != nil {
+		return Event{}, false, fmt.Errorf("DTSTART: %w", err)
+	}
+
+	end := start
+	if dtEnd, ok := props["DTEND"]; ok {
+		end, _, err = parseTime(dtEnd)
+		if err != nil {
+			return Event{}, false, fmt.Errorf("DTEND: %w", err)
+		}
+	} else if duration, ok := props["DURATION"]; ok {
+		d, err := parseDuration(duration.value)
+		if err != nil {
+			return Event{}, false, fmt.Errorf("DURATION: %w", err)
+		}
+		end = start.Add(d)
+	}
+
+	// конец события не входит в период: событие на целый день 1-3 июля
+	// записывается как DTSTART 1 июля, DTEND 4 июля
+	lastDay := dateOf(end)
+	if end.After(start) && (allDay || isMidnight(end)) {
+		lastDay = lastDay.AddDate(0, 0, -1)
+	}
+
+	event := Event{
+		UID:     uid,
+		Summary: unescape(props["SUMMARY"].value),
+		Start:   dateOf(start),
+		End:     lastDay,
+	}
+	if event.End.Before(event.Start) {
+		return Event{}, false, fmt.Errorf("event ends before it starts")
+	}
+
+	return event, false, nil
+}
+
+// parseTime разбирает DATE или DATE-TIME. Время без зоны считается локальным,
+// с TZID - временем указанной зоны.
+func parseTime(prop property) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.Parse(dateLayout, value)
+		return t, true, err
+	}
+
+	if utc, ok := strings.CutSuffix(value, "Z"); ok {
+		t, err := time.Parse(dateTimeLayout, utc)
+		return t.In(time.Local), false, err
+	}
+
+	loc := time.Local
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, loc)
+	return t, false, err
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	m := durationRe.FindStringSubmatch(strings.TrimPrefix(strings.TrimSpace(value), "+"))
+	if m == nil {
+		return 0, fmt.Errorf("unsupported duration %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+// dateOf отбрасывает время, оставляя календарный день в зоне t.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func isMidnight(t time.Time) bool {
+	h, m, sec := t.Clock()
+	return h == 0 && m == 0 && sec == 0
+}
+
+func unescape(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,86 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"avito-intership-2025/internal/lib/ical"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Example//Calendar//EN",
+		"BEGIN:VEVENT",
+		"UID:vacation-1@example.com",
+		"SUMMARY:Отпуск\\, море",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"DTSTART:20250601T000000",
+		"END:VALARM",
+		"DTSTART;VALUE=DATE:20250701",
+		"DTEND;VALUE=DATE:20250715",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:sick-",
+		" day@example.com",
+		"SUMMARY:Sick day",
+		"DTSTART;TZID=Europe/Moscow:20250903T090000",
+		"DTEND;TZID=Europe/Moscow:20250903T180000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:conf@example.com",
+		"DTSTART:20251010",
+		"DURATION:P2D",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:cancelled@example.com",
+		"STATUS:CANCELLED",
+		"DTSTART:20251101",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := ical.Parse(content)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, ical.Event{
+		UID:     "vacation-1@example.com",
+		Summary: "Отпуск, море",
+		Start:   date(2025, time.July, 1),
+		End:     date(2025, time.July, 14),
+	}, events[0])
+
+	assert.Equal(t, "sick-day@example.com", events[1].UID)
+	assert.Equal(t, date(2025, time.September, 3), events[1].Start)
+	assert.Equal(t, date(2025, time.September, 3), events[1].End)
+
+	assert.Equal(t, date(2025, time.October, 10), events[2].Start)
+	assert.Equal(t, date(2025, time.October, 11), events[2].End)
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := map[string]string{
+		"missing uid":   "BEGIN:VEVENT\nDTSTART:20250701\nEND:VEVENT",
+		"missing start": "BEGIN:VEVENT\nUID:x\nEND:VEVENT",
+		"bad date":      "BEGIN:VEVENT\nUID:x\nDTSTART:2025-07-01\nEND:VEVENT",
+		"ends early":    "BEGIN:VEVENT\nUID:x\nDTSTART:20250701\nDTEND:20250601\nEND:VEVENT",
+		"unterminated":  "BEGIN:VEVENT\nUID:x\nDTSTART:20250701",
+		"no colon":      "BEGIN:VEVENT\nUID\nEND:VEVENT",
+	}
+
+	for name, content := range cases {
+		_, err := ical.Parse(content)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,36 @@
+// Package signature подписывает тела вебхуков HMAC-SHA256 в формате
+// заголовка "sha256=<hex>", как это делает GitHub.
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const prefix = "sha256="
+
+// Sign возвращает значение заголовка подписи для тела body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return prefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет заголовок подписи за постоянное время.
+func Verify(secret string, body []byte, header string) bool {
+	got, ok := strings.CutPrefix(header, prefix)
+	if !ok {
+		return false
+	}
+
+	sum, err := hex.DecodeString(got)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(sum, mac.Sum(nil))
+}
@@ -0,0 +1,27 @@
+package signature_test
+
+import (
+	"testing"
+
+	"avito-intership-2025/internal/lib/signature"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// пример из документации GitHub по проверке доставок вебхуков
+	got := signature.Sign("It's a Secret to Everybody", []byte("Hello, World!"))
+
+	assert.Equal(t, "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17", got)
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event_id":1}`)
+	header := signature.Sign("secret", body)
+
+	assert.True(t, signature.Verify("secret", body, header))
+	assert.False(t, signature.Verify("other", body, header))
+	assert.False(t, signature.Verify("secret", []byte(`{"event_id":2}`), header))
+	assert.False(t, signature.Verify("secret", body, header[len("sha256="):]))
+	assert.False(t, signature.Verify("secret", body, "sha256=zz"))
+}
@@ -1,8 +1,26 @@
 package lib
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
 
 // Err returns formatted error in "op: err" template.
 func Err(op string, err error) error {
 	return fmt.Errorf("%s: %w", op, err)
 }
+
+// NormalizeTags lowercases and trims tags, drops empty ones and duplicates and sorts the result.
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" {
+			res = append(res, t)
+		}
+	}
+
+	slices.Sort(res)
+	return slices.Compact(res)
+}
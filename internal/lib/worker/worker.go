@@ -0,0 +1,24 @@
+// Package worker запускает периодические фоновые задачи.
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// Every вызывает job сразу и затем раз в interval, пока не отменен ctx.
+// Следующий запуск не начинается, пока не закончился предыдущий.
+func Every(ctx context.Context, interval time.Duration, job func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
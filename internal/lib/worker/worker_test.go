@@ -0,0 +1,34 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avito-intership-2025/internal/lib/worker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runs := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		worker.Every(ctx, time.Millisecond, func(context.Context) {
+			runs++
+			if runs == 3 {
+				cancel()
+			}
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after cancel")
+	}
+	assert.Equal(t, 3, runs)
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Absence - период, в который пользователь не участвует в ревью (отпуск, больничный).
+// Даты StartsOn и EndsOn включительно.
+type Absence struct {
+	ID                  int        `db:"id"`
+	UserID              string     `db:"user_id"`
+	StartsOn            time.Time  `db:"starts_on"`
+	EndsOn              time.Time  `db:"ends_on"`
+	Reason              string     `db:"reason"`
+	ExternalUID         *string    `db:"external_uid"`
+	ReassignReviews     bool       `db:"reassign_reviews"`
+	ReviewsReassignedAt *time.Time `db:"reviews_reassigned_at"`
+	CreatedAt           *time.Time `db:"created_at"`
+}
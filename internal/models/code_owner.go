@@ -0,0 +1,14 @@
+package models
+
+import "github.com/lib/pq"
+
+// CodeOwnerRule - правило владения путями в стиле CODEOWNERS.
+type CodeOwnerRule struct {
+	ID        int            `db:"id"`
+	Pattern   string         `db:"pattern"`
+	UserIDs   pq.StringArray `db:"user_ids"`
+	TeamIDs   pq.Int64Array  `db:"team_ids"`
+	TeamNames pq.StringArray `db:"team_names"`
+	// ActiveOwners - активные и не отсутствующие пользователи из UserIDs и участники команд из TeamIDs
+	ActiveOwners pq.StringArray `db:"active_owners"`
+}
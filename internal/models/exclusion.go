@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ReviewExclusion - направленный запрет: ReviewerID не назначается ревьюером на PR автора AuthorID.
+type ReviewExclusion struct {
+	AuthorID   string     `db:"author_id"`
+	ReviewerID string     `db:"reviewer_id"`
+	Reason     string     `db:"reason"`
+	CreatedAt  *time.Time `db:"created_at"`
+}
+
+// ExclusionGroup - подгруппа пользователей, которые не ревьюят PR друг друга.
+type ExclusionGroup struct {
+	ID        int            `db:"id"`
+	Name      string         `db:"name"`
+	Reason    string         `db:"reason"`
+	UserIDs   pq.StringArray `db:"user_ids"`
+	CreatedAt *time.Time     `db:"created_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// ExternalIdentity - учетная запись пользователя во внешней системе, например логин в GitHub.
+type ExternalIdentity struct {
+	Provider  string     `db:"provider"`
+	Login     string     `db:"login"`
+	UserID    string     `db:"user_id"`
+	CreatedAt *time.Time `db:"created_at"`
+}
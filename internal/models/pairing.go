@@ -0,0 +1,8 @@
+package models
+
+// ReviewPairing - сколько PR автора ревьюил ревьюер.
+type ReviewPairing struct {
+	AuthorID   string `db:"author_id"`
+	ReviewerID string `db:"reviewer_id"`
+	Count      int    `db:"pair_count"`
+}
@@ -1,12 +1,86 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
 
 type PullRequest struct {
-	ID        string     `db:"id"`
-	Title     string     `db:"title"`
-	AuthorId  string     `db:"author_id"`
-	Status    string     `db:"status"`
-	CreatedAt *time.Time `db:"created_at"`
-	MergedAt  *time.Time `db:"merged_at"`
+	ID                string `db:"id"`
+	Title             string `db:"title"`
+	AuthorId          string `db:"author_id"`
+	Status            string `db:"status"`
+	NeedMoreReviewers bool   `db:"need_more_reviewers"`
+	// Labels - метки PR, по ним предпочитаются ревьюеры с совпадающими навыками
+	Labels    pq.StringArray `db:"labels"`
+	CreatedAt *time.Time     `db:"created_at"`
+	MergedAt  *time.Time     `db:"merged_at"`
+	ClosedAt  *time.Time     `db:"closed_at"`
+}
+
+// ReviewAssignment - назначение ревьюера на PR вместе с автором PR.
+type ReviewAssignment struct {
+	PullRequestID string         `db:"pull_request_id"`
+	ReviewerID    string         `db:"reviewer_id"`
+	AuthorID      string         `db:"author_id"`
+	Labels        pq.StringArray `db:"labels"`
+}
+
+// PendingReview - назначение ревьюера на открытый PR, по которому он еще не оставил вердикт.
+type PendingReview struct {
+	PullRequestID string `db:"pull_request_id"`
+	ReviewerID    string `db:"reviewer_id"`
+	// AuthorTeam - команда автора PR, по ней выбирается SLA
+	AuthorTeam string    `db:"author_team"`
+	AssignedAt time.Time `db:"assigned_at"`
+	// WaitingSeconds - сколько прошло с назначения, считается по часам базы
+	WaitingSeconds float64 `db:"waiting_seconds"`
+}
+
+// Поля, по которым сортируется список PR.
+const (
+	PrSortCreatedAt = "created_at"
+	PrSortMergedAt  = "merged_at"
+)
+
+// PrListFilter - фильтры и страница списка PR. Пустые поля не фильтруют,
+// интервалы дат полуоткрытые: [From, To).
+type PrListFilter struct {
+	Status     string
+	AuthorID   string
+	ReviewerID string
+	// TeamName - команда автора PR
+	TeamName    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	// SortBy - PrSortCreatedAt или PrSortMergedAt, Desc - по убыванию
+	SortBy string
+	Desc   bool
+	// Cursor - next_cursor предыдущей страницы, пустой - первая страница
+	Cursor string
+	Limit  int
+}
+
+// PullRequestListItem - PR из списка вместе с назначенными ревьюерами.
+type PullRequestListItem struct {
+	PullRequest
+	Reviewers pq.StringArray `db:"reviewers"`
+}
+
+// PrSearchFilter - поиск PR по названию. Status и TeamName фильтруют как в списке.
+type PrSearchFilter struct {
+	// Query - текст запроса, в репозиторий сервис передает его уже как tsquery
+	Query    string
+	Status   string
+	TeamName string
+	Limit    int
+}
+
+// PullRequestSearchItem - найденный PR и его релевантность запросу.
+type PullRequestSearchItem struct {
+	PullRequestListItem
+	Rank float64 `db:"rank"`
 }
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type Review struct {
+	ID            int64      `db:"id"`
+	PullRequestID string     `db:"pull_request_id"`
+	ReviewerID    string     `db:"user_id"`
+	Verdict       string     `db:"verdict"`
+	Comment       string     `db:"comment"`
+	CreatedAt     *time.Time `db:"created_at"`
+}
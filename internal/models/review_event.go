@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+const (
+	EventAssigned        = "ASSIGNED"
+	EventReassigned      = "REASSIGNED"
+	EventRemoved         = "REMOVED"
+	EventStatusChanged   = "STATUS_CHANGED"
+	EventActivityChanged = "ACTIVITY_CHANGED"
+)
+
+// ReviewEvent - запись журнала изменений ревью. Какие поля заполнены, зависит от типа:
+// назначение и снятие ревьюера, смена статуса PR или активности пользователя.
+type ReviewEvent struct {
+	ID            int64     `db:"id"`
+	Type          string    `db:"event_type"`
+	PullRequestID string    `db:"pull_request_id"`
+	UserID        string    `db:"user_id"`
+	OldReviewerID string    `db:"old_reviewer_id"`
+	NewReviewerID string    `db:"new_reviewer_id"`
+	Status        string    `db:"status"`
+	IsActive      *bool     `db:"is_active"`
+	Reason        string    `db:"reason"`
+	Actor         string    `db:"actor"`
+	CreatedAt     time.Time `db:"created_at"`
+}
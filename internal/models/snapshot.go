@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Snapshot - команды, пользователи и PR, на которых симулятор воспроизводит назначение ревьюеров.
+// Выгружается из базы или читается из JSON-файла.
+type Snapshot struct {
+	Teams        []SnapshotTeam `json:"teams"`
+	Users        []SnapshotUser `json:"users"`
+	PullRequests []SnapshotPR   `json:"pull_requests"`
+}
+
+// SnapshotTeam - команда с настройками подбора ревьюеров. Окно ротации задает сам симулятор.
+type SnapshotTeam struct {
+	ID                     int    `json:"id" db:"id"`
+	Name                   string `json:"name" db:"name"`
+	ReviewersCount         int    `json:"reviewers_count" db:"reviewers_count"`
+	MaxOpenReviews         *int   `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+	MinSeniorReviewers     *int   `json:"min_senior_reviewers,omitempty" db:"min_senior_reviewers"`
+	MinJuniorReviewers     *int   `json:"min_junior_reviewers,omitempty" db:"min_junior_reviewers"`
+	MaxJuniorReviewers     *int   `json:"max_junior_reviewers,omitempty" db:"max_junior_reviewers"`
+	SeniorForJuniorAuthors bool   `json:"senior_for_junior_authors,omitempty" db:"senior_for_junior_authors"`
+}
+
+type SnapshotUser struct {
+	ID             string         `json:"id" db:"id"`
+	TeamID         int            `json:"team_id" db:"team_id"`
+	IsActive       bool           `json:"is_active" db:"is_active"`
+	Skills         pq.StringArray `json:"skills,omitempty" db:"skills"`
+	Seniority      string         `json:"seniority,omitempty" db:"seniority"`
+	MaxOpenReviews *int           `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+}
+
+// SnapshotPR - PR в порядке создания. ClosedAt - момент мержа или закрытия, nil - PR еще открыт.
+type SnapshotPR struct {
+	ID        string         `json:"id" db:"id"`
+	AuthorID  string         `json:"author_id" db:"author_id"`
+	Labels    pq.StringArray `json:"labels,omitempty" db:"labels"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	ClosedAt  *time.Time     `json:"closed_at,omitempty" db:"closed_at"`
+}
@@ -4,10 +4,15 @@ type UserStatistics struct {
 	UserID          string `db:"user_id"`
 	Username        string `db:"username"`
 	AssignmentCount int    `db:"assignment_count"`
+	OpenReviewCount int    `db:"open_review_count"`
+	MaxOpenReviews  *int   `db:"max_open_reviews"`
+	OverLimit       bool   `db:"over_limit"`
 }
 
 type PrStatistics struct {
 	PrCount   int `db:"pr_count"`
 	OpenPrs   int `db:"open_pr_count"`
 	MergedPrs int `db:"merged_pr_count"`
+	ClosedPrs int `db:"closed_pr_count"`
+	DraftPrs  int `db:"draft_pr_count"`
 }
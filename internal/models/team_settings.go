@@ -0,0 +1,18 @@
+package models
+
+type TeamSettings struct {
+	TeamID         int  `db:"team_id"`
+	ReviewersCount int  `db:"reviewers_count"`
+	MaxOpenReviews *int `db:"max_open_reviews"`
+	// RequiredApprovals - сколько апрувов нужно для мержа, nil - без ограничения
+	RequiredApprovals *int `db:"required_approvals"`
+	// Правила состава ревьюеров PR, nil - правило не действует
+	MinSeniorReviewers *int `db:"min_senior_reviewers"`
+	MinJuniorReviewers *int `db:"min_junior_reviewers"`
+	MaxJuniorReviewers *int `db:"max_junior_reviewers"`
+	// SeniorForJuniorAuthors - PR джуна получает хотя бы одного сеньора
+	SeniorForJuniorAuthors bool `db:"senior_for_junior_authors"`
+	// Окно ротации: последние N PR автора или последние N дней, задается не больше одного
+	RotationWindowPRs  *int `db:"rotation_window_prs"`
+	RotationWindowDays *int `db:"rotation_window_days"`
+}
@@ -2,12 +2,25 @@ package models
 
 import (
 	"time"
+
+	"github.com/lib/pq"
+)
+
+// Уровни пользователя, по ним собирается состав ревьюеров PR.
+const (
+	SeniorityJunior = "junior"
+	SeniorityMiddle = "middle"
+	SenioritySenior = "senior"
 )
 
 type User struct {
-	ID        string     `db:"id"`
-	Name      string     `db:"name"`
-	TeamID    int        `db:"team_id"`
-	IsActive  bool       `db:"is_active"`
-	CreatedAt *time.Time `db:"created_at"`
+	ID        string         `db:"id"`
+	Name      string         `db:"name"`
+	TeamID    int            `db:"team_id"`
+	IsActive  bool           `db:"is_active"`
+	Skills    pq.StringArray `db:"skills"`
+	Seniority string         `db:"seniority"`
+	// MaxOpenReviews - личный лимит открытых ревью, nil - действует лимит команды.
+	MaxOpenReviews *int       `db:"max_open_reviews"`
+	CreatedAt      *time.Time `db:"created_at"`
 }
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Типы событий, рассылаемых подписчикам вебхуков.
+const (
+	WebhookPrCreated    = "pull_request.created"
+	WebhookPrMerged     = "pull_request.merged"
+	WebhookPrReassigned = "pull_request.reassigned"
+)
+
+var WebhookEventTypes = []string{WebhookPrCreated, WebhookPrMerged, WebhookPrReassigned}
+
+const (
+	DeliveryPending   = "PENDING"
+	DeliveryDelivered = "DELIVERED"
+	DeliveryDead      = "DEAD"
+)
+
+// WebhookSubscription - адрес, на который рассылаются события. Пустой EventTypes - все события.
+type WebhookSubscription struct {
+	ID         int            `db:"id"`
+	URL        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+	CreatedAt  *time.Time     `db:"created_at"`
+}
+
+// WebhookDelivery - доставка события одному подписчику. Для рассылки дополняется
+// событием и адресом подписки.
+type WebhookDelivery struct {
+	ID             int64      `db:"id"`
+	EventID        int64      `db:"event_id"`
+	SubscriptionID int        `db:"subscription_id"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	LastError      string     `db:"last_error"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+	CreatedAt      *time.Time `db:"created_at"`
+
+	EventType      string    `db:"event_type"`
+	Payload        []byte    `db:"payload"`
+	EventCreatedAt time.Time `db:"event_created_at"`
+	URL            string    `db:"url"`
+	Secret         string    `db:"secret"`
+}
@@ -0,0 +1,156 @@
+package repo
+
+import (
+	"context"
+
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+)
+
+type AbsenceRepository interface {
+	Save(ctx context.Context, absence *models.Absence) (*models.Absence, error)
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, userID string) ([]*models.Absence, error)
+	GetStartedForReassign(ctx context.Context) ([]*models.Absence, error)
+	MarkReviewsReassigned(ctx context.Context, id int) error
+}
+
+type AbsenceRepo struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewAbsenceRepo(db *sqlx.DB, c *trmsqlx.CtxGetter) *AbsenceRepo {
+	return &AbsenceRepo{
+		db:     db,
+		getter: c,
+	}
+}
+
+const absenceColumns = `id, user_id, starts_on, ends_on, reason, external_uid,
+	reassign_reviews, reviews_reassigned_at, created_at`
+
+// Save добавляет отсутствие. Отсутствие с тем же external_uid у пользователя обновляется,
+// а если сдвинулось его начало - ревью переназначаются заново.
+func (r *AbsenceRepo) Save(ctx context.Context, absence *models.Absence) (*models.Absence, error) {
+	const op = "absence_repo.Save"
+
+	query := `
+		INSERT INTO user_absences (user_id, starts_on, ends_on, reason, external_uid, reassign_reviews, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, now())
+		ON CONFLICT (user_id, external_uid) WHERE external_uid IS NOT NULL DO UPDATE SET
+			starts_on = EXCLUDED.starts_on,
+			ends_on = EXCLUDED.ends_on,
+			reason = EXCLUDED.reason,
+			reassign_reviews = EXCLUDED.reassign_reviews,
+			reviews_reassigned_at = CASE
+				WHEN user_absences.starts_on = EXCLUDED.starts_on THEN user_absences.reviews_reassigned_at
+			END
+		RETURNING ` + absenceColumns + `;
+	`
+
+	var saved models.Absence
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &saved, query,
+		absence.UserID,
+		absence.StartsOn,
+		absence.EndsOn,
+		absence.Reason,
+		absence.ExternalUID,
+		absence.ReassignReviews,
+	)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return &saved, nil
+}
+
+func (r *AbsenceRepo) Delete(ctx context.Context, id int) error {
+	const op = "absence_repo.Delete"
+
+	query := `DELETE FROM user_absences WHERE id = $1;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// List возвращает текущие и будущие отсутствия пользователя (всех пользователей, если userID пуст).
+func (r *AbsenceRepo) List(ctx context.Context, userID string) ([]*models.Absence, error) {
+	const op = "absence_repo.List"
+
+	query := `
+		SELECT ` + absenceColumns + `
+		FROM user_absences
+		WHERE ($1 = '' OR user_id = $1) AND ends_on >= CURRENT_DATE
+		ORDER BY starts_on, user_id;
+	`
+
+	absences := []*models.Absence{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &absences, query, userID)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return absences, nil
+}
+
+// GetStartedForReassign возвращает начавшиеся отсутствия, по которым еще не сняты ревью.
+func (r *AbsenceRepo) GetStartedForReassign(ctx context.Context) ([]*models.Absence, error) {
+	const op = "absence_repo.GetStartedForReassign"
+
+	query := `
+		SELECT ` + absenceColumns + `
+		FROM user_absences
+		WHERE reassign_reviews AND reviews_reassigned_at IS NULL
+			AND CURRENT_DATE BETWEEN starts_on AND ends_on
+		ORDER BY starts_on, id;
+	`
+
+	absences := []*models.Absence{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &absences, query)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return absences, nil
+}
+
+// MarkReviewsReassigned отмечает, что ревью по отсутствию сняты. Уже отмеченное отсутствие
+// дает ErrNotFound: так несколько экземпляров сервиса не обработают его дважды.
+func (r *AbsenceRepo) MarkReviewsReassigned(ctx context.Context, id int) error {
+	const op = "absence_repo.MarkReviewsReassigned"
+
+	query := `
+		UPDATE user_absences SET reviews_reassigned_at = now()
+		WHERE id = $1 AND reviews_reassigned_at IS NULL;
+	`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
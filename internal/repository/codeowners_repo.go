@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type CodeOwnersRepository interface {
+	GetRules(ctx context.Context) ([]*models.CodeOwnerRule, error)
+	SaveRule(ctx context.Context, pattern string, userIDs []string, teamIDs []int) error
+	DeleteRule(ctx context.Context, pattern string) error
+	DeleteAllRules(ctx context.Context) error
+}
+
+type CodeOwnersRepo struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewCodeOwnersRepo(db *sqlx.DB, c *trmsqlx.CtxGetter) *CodeOwnersRepo {
+	return &CodeOwnersRepo{
+		db:     db,
+		getter: c,
+	}
+}
+
+// GetRules возвращает правила в порядке CODEOWNERS вместе с активными владельцами.
+// Владельцы в отпуске в active_owners не попадают.
+func (r *CodeOwnersRepo) GetRules(ctx context.Context) ([]*models.CodeOwnerRule, error) {
+	const op = "codeowners_repo.GetRules"
+
+	query := `
+		SELECT r.id, r.pattern, r.user_ids, r.team_ids,
+			ARRAY(
+				SELECT t.name FROM teams t
+				WHERE t.id = ANY(r.team_ids)
+				ORDER BY t.name
+			) AS team_names,
+			ARRAY(
+				SELECT u.id FROM users u
+				WHERE u.is_active AND (u.id = ANY(r.user_ids) OR u.team_id = ANY(r.team_ids))
+					AND NOT EXISTS (
+						SELECT 1 FROM user_absences a
+						WHERE a.user_id = u.id AND CURRENT_DATE BETWEEN a.starts_on AND a.ends_on
+					)
+				ORDER BY u.id
+			) AS active_owners
+		FROM code_owner_rules r
+		ORDER BY r.position;
+	`
+
+	rules := []*models.CodeOwnerRule{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rules, query)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return rules, nil
+}
+
+// SaveRule создает правило в конце списка или обновляет владельцев существующего, не меняя его позицию.
+func (r *CodeOwnersRepo) SaveRule(ctx context.Context, pattern string, userIDs []string, teamIDs []int) error {
+	const op = "codeowners_repo.SaveRule"
+
+	query := `
+		INSERT INTO code_owner_rules (pattern, user_ids, team_ids, position, updated_at)
+		VALUES ($1, $2, $3, (SELECT COALESCE(MAX(position), 0) + 1 FROM code_owner_rules), now())
+		ON CONFLICT (pattern) DO UPDATE SET
+			user_ids = EXCLUDED.user_ids,
+			team_ids = EXCLUDED.team_ids,
+			updated_at = EXCLUDED.updated_at;
+	`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, pattern, pq.Array(userIDs), pq.Array(teamIDs))
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
+func (r *CodeOwnersRepo) DeleteRule(ctx context.Context, pattern string) error {
+	const op = "codeowners_repo.DeleteRule"
+
+	query := `DELETE FROM code_owner_rules WHERE pattern = $1;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, pattern)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *CodeOwnersRepo) DeleteAllRules(ctx context.Context) error {
+	const op = "codeowners_repo.DeleteAllRules"
+
+	query := `DELETE FROM code_owner_rules;`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
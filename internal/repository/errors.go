@@ -14,4 +14,20 @@ var (
 	ErrPRMerged    = errors.New("cannot reassign on merged PR")
 	ErrNotAssigned = errors.New("reviewer is not assigned to this PR")
 	ErrNoCandidate = errors.New("no active replacement candidate in team")
+	ErrPRClosed    = errors.New("PR is closed")
+	ErrTransition  = errors.New("transition is not allowed from current PR status")
+	ErrNoApprovals = errors.New("not enough approvals to merge PR")
+
+	ErrInvalidPattern  = errors.New("invalid code owners pattern")
+	ErrInvalidAbsence  = errors.New("invalid absence")
+	ErrInvalidSettings = errors.New("invalid team settings")
+
+	ErrInvalidSubscription = errors.New("invalid webhook subscription")
+	ErrInvalidPayload      = errors.New("invalid webhook payload")
+
+	ErrInvalidExclusion = errors.New("invalid exclusion rule")
+	ErrExcludedByRules  = errors.New("all candidates are excluded by conflict-of-interest rules")
+
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	ErrInvalidQuery  = errors.New("invalid search query")
 )
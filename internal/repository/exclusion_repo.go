@@ -0,0 +1,198 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ExclusionRepository interface {
+	ListPairs(ctx context.Context) ([]*models.ReviewExclusion, error)
+	SavePair(ctx context.Context, pair *models.ReviewExclusion) error
+	DeletePair(ctx context.Context, authorID, reviewerID string) error
+	ListGroups(ctx context.Context) ([]*models.ExclusionGroup, error)
+	SaveGroup(ctx context.Context, group *models.ExclusionGroup) error
+	DeleteGroup(ctx context.Context, name string) error
+	GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error)
+}
+
+type ExclusionRepo struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewExclusionRepo(db *sqlx.DB, c *trmsqlx.CtxGetter) *ExclusionRepo {
+	return &ExclusionRepo{
+		db:     db,
+		getter: c,
+	}
+}
+
+func (r *ExclusionRepo) ListPairs(ctx context.Context) ([]*models.ReviewExclusion, error) {
+	const op = "exclusion_repo.ListPairs"
+
+	query := `
+		SELECT author_id, reviewer_id, reason, created_at
+		FROM review_exclusions
+		ORDER BY author_id, reviewer_id;
+	`
+
+	pairs := []*models.ReviewExclusion{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &pairs, query)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return pairs, nil
+}
+
+// SavePair добавляет запрет или обновляет причину существующего.
+func (r *ExclusionRepo) SavePair(ctx context.Context, pair *models.ReviewExclusion) error {
+	const op = "exclusion_repo.SavePair"
+
+	query := `
+		INSERT INTO review_exclusions (author_id, reviewer_id, reason, created_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (author_id, reviewer_id) DO UPDATE SET
+			reason = EXCLUDED.reason;
+	`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, pair.AuthorID, pair.ReviewerID, pair.Reason)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
+func (r *ExclusionRepo) DeletePair(ctx context.Context, authorID, reviewerID string) error {
+	const op = "exclusion_repo.DeletePair"
+
+	query := `DELETE FROM review_exclusions WHERE author_id = $1 AND reviewer_id = $2;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, authorID, reviewerID)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ExclusionRepo) ListGroups(ctx context.Context) ([]*models.ExclusionGroup, error) {
+	const op = "exclusion_repo.ListGroups"
+
+	query := `
+		SELECT g.id, g.name, g.reason, g.created_at,
+			ARRAY(
+				SELECT m.user_id FROM exclusion_group_members m
+				WHERE m.group_id = g.id
+				ORDER BY m.user_id
+			) AS user_ids
+		FROM exclusion_groups g
+		ORDER BY g.name;
+	`
+
+	groups := []*models.ExclusionGroup{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &groups, query)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return groups, nil
+}
+
+// SaveGroup создает подгруппу или заменяет причину и состав существующей.
+func (r *ExclusionRepo) SaveGroup(ctx context.Context, group *models.ExclusionGroup) error {
+	const op = "exclusion_repo.SaveGroup"
+
+	query := `
+		INSERT INTO exclusion_groups (name, reason, created_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (name) DO UPDATE SET
+			reason = EXCLUDED.reason
+		RETURNING id;
+	`
+
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var groupID int
+	if err := tr.QueryRowContext(ctx, query, group.Name, group.Reason).Scan(&groupID); err != nil {
+		return lib.Err(op, err)
+	}
+
+	if _, err := tr.ExecContext(ctx, `DELETE FROM exclusion_group_members WHERE group_id = $1;`, groupID); err != nil {
+		return lib.Err(op, err)
+	}
+
+	membersQuery := `
+		INSERT INTO exclusion_group_members (group_id, user_id)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT DO NOTHING;
+	`
+	if _, err := tr.ExecContext(ctx, membersQuery, groupID, pq.Array(group.UserIDs)); err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
+func (r *ExclusionRepo) DeleteGroup(ctx context.Context, name string) error {
+	const op = "exclusion_repo.DeleteGroup"
+
+	query := `DELETE FROM exclusion_groups WHERE name = $1;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, name)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetExcludedReviewers возвращает пользователей, которых нельзя назначать на PR автора:
+// по направленным запретам и по общим с автором подгруппам.
+func (r *ExclusionRepo) GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error) {
+	const op = "exclusion_repo.GetExcludedReviewers"
+
+	query := `
+		SELECT reviewer_id FROM review_exclusions WHERE author_id = $1
+		UNION
+		SELECT other.user_id
+		FROM exclusion_group_members own
+		JOIN exclusion_group_members other ON other.group_id = own.group_id
+		WHERE own.user_id = $1 AND other.user_id <> $1;
+	`
+
+	excluded := []string{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &excluded, query, authorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []string{}, nil
+		}
+		return nil, lib.Err(op, err)
+	}
+
+	return excluded, nil
+}
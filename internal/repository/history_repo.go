@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+)
+
+type HistoryRepository interface {
+	AddEvents(ctx context.Context, events []*models.ReviewEvent) error
+	GetPrHistory(ctx context.Context, prID string) ([]*models.ReviewEvent, error)
+	GetUserHistory(ctx context.Context, userID string) ([]*models.ReviewEvent, error)
+}
+
+type HistoryRepo struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewHistoryRepo(db *sqlx.DB, c *trmsqlx.CtxGetter) *HistoryRepo {
+	return &HistoryRepo{
+		db:     db,
+		getter: c,
+	}
+}
+
+const reviewEventColumns = `id, event_type, pull_request_id, user_id, old_reviewer_id, new_reviewer_id,
+	status, is_active, reason, actor, created_at`
+
+// AddEvents дописывает события в журнал. Записи журнала не изменяются и не удаляются.
+func (r *HistoryRepo) AddEvents(ctx context.Context, events []*models.ReviewEvent) error {
+	const op = "history_repo.AddEvents"
+
+	query := `
+		INSERT INTO review_events (
+			event_type, pull_request_id, user_id, old_reviewer_id, new_reviewer_id,
+			status, is_active, reason, actor, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, now());
+	`
+
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+	for _, e := range events {
+		_, err := tr.ExecContext(ctx, query,
+			e.Type,
+			e.PullRequestID,
+			e.UserID,
+			e.OldReviewerID,
+			e.NewReviewerID,
+			e.Status,
+			e.IsActive,
+			e.Reason,
+			e.Actor,
+		)
+		if err != nil {
+			return lib.Err(op, err)
+		}
+	}
+
+	return nil
+}
+
+// GetPrHistory возвращает события PR в порядке записи.
+func (r *HistoryRepo) GetPrHistory(ctx context.Context, prID string) ([]*models.ReviewEvent, error) {
+	const op = "history_repo.GetPrHistory"
+
+	query := `
+		SELECT ` + reviewEventColumns + `
+		FROM review_events
+		WHERE pull_request_id = $1
+		ORDER BY id;
+	`
+
+	events := []*models.ReviewEvent{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &events, query, prID)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return events, nil
+}
+
+// GetUserHistory возвращает события, в которых пользователь назначался или снимался
+// с ревью, и изменения его активности, в порядке записи.
+func (r *HistoryRepo) GetUserHistory(ctx context.Context, userID string) ([]*models.ReviewEvent, error) {
+	const op = "history_repo.GetUserHistory"
+
+	query := `
+		SELECT ` + reviewEventColumns + `
+		FROM review_events
+		WHERE user_id = $1 OR old_reviewer_id = $1 OR new_reviewer_id = $1
+		ORDER BY id;
+	`
+
+	events := []*models.ReviewEvent{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &events, query, userID)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+)
+
+type IdentityRepository interface {
+	SaveIdentity(ctx context.Context, identity *models.ExternalIdentity) error
+	DeleteIdentity(ctx context.Context, provider, login string) error
+	ListIdentities(ctx context.Context, provider string) ([]*models.ExternalIdentity, error)
+	GetUserID(ctx context.Context, provider, login string) (string, error)
+}
+
+type IdentityRepo struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewIdentityRepo(db *sqlx.DB, c *trmsqlx.CtxGetter) *IdentityRepo {
+	return &IdentityRepo{
+		db:     db,
+		getter: c,
+	}
+}
+
+// SaveIdentity привязывает внешний логин к пользователю. Уже привязанный логин перепривязывается.
+func (r *IdentityRepo) SaveIdentity(ctx context.Context, identity *models.ExternalIdentity) error {
+	const op = "identity_repo.SaveIdentity"
+
+	query := `
+		INSERT INTO external_identities (provider, login, user_id, created_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (provider, login) DO UPDATE SET
+			user_id = EXCLUDED.user_id;
+	`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		identity.Provider,
+		identity.Login,
+		identity.UserID,
+	)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
+func (r *IdentityRepo) DeleteIdentity(ctx context.Context, provider, login string) error {
+	const op = "identity_repo.DeleteIdentity"
+
+	query := `DELETE FROM external_identities WHERE provider = $1 AND login = $2;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, provider, login)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListIdentities возвращает привязки внешней системы (всех систем, если provider пуст).
+func (r *IdentityRepo) ListIdentities(ctx context.Context, provider string) ([]*models.ExternalIdentity, error) {
+	const op = "identity_repo.ListIdentities"
+
+	query := `
+		SELECT provider, login, user_id, created_at
+		FROM external_identities
+		WHERE $1 = '' OR provider = $1
+		ORDER BY provider, login;
+	`
+
+	identities := []*models.ExternalIdentity{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &identities, query, provider)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return identities, nil
+}
+
+// GetUserID возвращает пользователя, к которому привязан внешний логин.
+func (r *IdentityRepo) GetUserID(ctx context.Context, provider, login string) (string, error) {
+	const op = "identity_repo.GetUserID"
+
+	query := `SELECT user_id FROM external_identities WHERE provider = $1 AND login = $2;`
+
+	var userID string
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &userID, query, provider, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", lib.Err(op, err)
+	}
+
+	return userID, nil
+}
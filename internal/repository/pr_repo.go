@@ -3,8 +3,11 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"avito-intership-2025/internal/lib"
 	"avito-intership-2025/internal/models"
@@ -19,11 +22,27 @@ type PullRequestRepository interface {
 	Create(ctx context.Context, pr *models.PullRequest) (string, error)
 	GetById(ctx context.Context, prID string) (*models.PullRequest, error)
 	GetByAuthor(ctx context.Context, authorID string) ([]*models.PullRequest, error)
+	List(ctx context.Context, filter *models.PrListFilter) ([]*models.PullRequestListItem, string, error)
+	Search(ctx context.Context, filter *models.PrSearchFilter) ([]*models.PullRequestSearchItem, error)
 	MarkAsMerged(ctx context.Context, prID string) error
+	SetStatus(ctx context.Context, prID, status string) error
+	GetUnderstaffed(ctx context.Context) ([]*models.PullRequest, error)
+	SetNeedMoreReviewers(ctx context.Context, prID string, need bool) error
+	SetLabels(ctx context.Context, prID string, labels []string) error
 
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
 	AssignReviewer(ctx context.Context, prID, userID string) error
 	ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) error
+	GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetOpenReviewsByUsers(ctx context.Context, userIDs []string) ([]*models.ReviewAssignment, error)
+	SetAssignmentReason(ctx context.Context, prID, userID, reason string) error
+	GetPendingReviews(ctx context.Context, olderThan time.Duration) ([]*models.PendingReview, error)
+	AddReview(ctx context.Context, review *models.Review) error
+	GetLatestReviews(ctx context.Context, prID string) ([]*models.Review, error)
+
+	GetPairingsInLastPRs(ctx context.Context, authorID string, reviewerIDs []string, lastPRs int) (map[string]int, error)
+	GetPairingsSince(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error)
+	GetTeamPairings(ctx context.Context, teamID int, since *time.Time) ([]*models.ReviewPairing, error)
 }
 
 type PullRequestRepo struct {
@@ -44,8 +63,8 @@ func (r *PullRequestRepo) Create(ctx context.Context, pr *models.PullRequest) (s
 	const op = "pull_request_repo.Create"
 
 	query := `
-        INSERT INTO pull_requests (id, title, author_id, status, created_at)
-        VALUES ($1, $2, $3, $4, now())
+        INSERT INTO pull_requests (id, title, author_id, status, need_more_reviewers, labels, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, now())
         RETURNING id;
     `
 
@@ -57,6 +76,8 @@ func (r *PullRequestRepo) Create(ctx context.Context, pr *models.PullRequest) (s
 		pr.Title,
 		pr.AuthorId,
 		pr.Status,
+		pr.NeedMoreReviewers,
+		pq.Array(pr.Labels),
 	).Scan(&prID)
 
 	if err != nil {
@@ -76,7 +97,7 @@ func (r *PullRequestRepo) GetById(ctx context.Context, prID string) (*models.Pul
 	const op = "pull_request_repo.GetById"
 
 	query := `
-        SELECT id, title, author_id, status, created_at, merged_at
+        SELECT id, title, author_id, status, need_more_reviewers, labels, created_at, merged_at, closed_at
         FROM pull_requests
         WHERE id = $1
     `
@@ -97,7 +118,7 @@ func (r *PullRequestRepo) GetByAuthor(ctx context.Context, authorID string) ([]*
 	const op = "pull_request_repo.GetByAuthor"
 
 	query := `
-        SELECT id, title, author_id, status, created_at, merged_at
+        SELECT id, title, author_id, status, need_more_reviewers, labels, created_at, merged_at, closed_at
         FROM pull_requests
         WHERE author_id = $1
         ORDER BY created_at DESC
@@ -115,6 +136,154 @@ func (r *PullRequestRepo) GetByAuthor(ctx context.Context, authorID string) ([]*
 	return prs, nil
 }
 
+// List возвращает страницу PR по фильтру и курсор следующей страницы, пустой - страница последняя.
+// Страницы отсчитываются от ключа (поле сортировки, id) последнего PR, поэтому не съезжают,
+// когда создаются новые PR. При сортировке по merged_at в список попадают только смерженные PR.
+func (r *PullRequestRepo) List(ctx context.Context, filter *models.PrListFilter) ([]*models.PullRequestListItem, string, error) {
+	const op = "pull_request_repo.List"
+
+	column := "p.created_at"
+	if filter.SortBy == models.PrSortMergedAt {
+		column = "p.merged_at"
+	}
+	order, after := "ASC", ">"
+	if filter.Desc {
+		order, after = "DESC", "<"
+	}
+
+	var afterKey *time.Time
+	afterID := ""
+	if filter.Cursor != "" {
+		cursor, err := decodePrCursor(filter)
+		if err != nil {
+			return nil, "", err
+		}
+		afterKey, afterID = &cursor.Key, cursor.ID
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.id, p.title, p.author_id, p.status, p.need_more_reviewers, p.labels,
+			p.created_at, p.merged_at, p.closed_at,
+			ARRAY(
+				SELECT prr.user_id FROM pr_reviewers prr
+				WHERE prr.pull_request_id = p.id
+				ORDER BY prr.user_id
+			) AS reviewers
+		FROM pull_requests p
+		WHERE %[1]s IS NOT NULL
+			AND ($1 = '' OR p.status = $1)
+			AND ($2 = '' OR p.author_id = $2)
+			AND ($3 = '' OR EXISTS (
+				SELECT 1 FROM pr_reviewers prr
+				WHERE prr.pull_request_id = p.id AND prr.user_id = $3
+			))
+			AND ($4 = '' OR p.author_id IN (
+				SELECT u.id FROM users u
+				JOIN teams t ON t.id = u.team_id
+				WHERE t.name = $4
+			))
+			AND ($5::timestamp IS NULL OR p.created_at >= $5)
+			AND ($6::timestamp IS NULL OR p.created_at < $6)
+			AND ($7::timestamp IS NULL OR p.merged_at >= $7)
+			AND ($8::timestamp IS NULL OR p.merged_at < $8)
+			AND ($9::timestamp IS NULL OR (%[1]s, p.id) %[2]s ($9::timestamp, $10::text))
+		ORDER BY %[1]s %[3]s, p.id %[3]s
+		LIMIT $11
+	`, column, after, order)
+
+	// лишняя строка показывает, есть ли следующая страница
+	items := []*models.PullRequestListItem{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &items, query,
+		filter.Status, filter.AuthorID, filter.ReviewerID, filter.TeamName,
+		filter.CreatedFrom, filter.CreatedTo, filter.MergedFrom, filter.MergedTo,
+		afterKey, afterID, filter.Limit+1,
+	)
+	if err != nil {
+		return nil, "", lib.Err(op, err)
+	}
+
+	if len(items) <= filter.Limit {
+		return items, "", nil
+	}
+
+	items = items[:filter.Limit]
+	return items, encodePrCursor(filter, items[len(items)-1]), nil
+}
+
+// Search ищет PR по названию через title_tsv и сортирует по релевантности, при равной - сначала новые.
+func (r *PullRequestRepo) Search(ctx context.Context, filter *models.PrSearchFilter) ([]*models.PullRequestSearchItem, error) {
+	const op = "pull_request_repo.Search"
+
+	query := `
+		SELECT p.id, p.title, p.author_id, p.status, p.need_more_reviewers, p.labels,
+			p.created_at, p.merged_at, p.closed_at,
+			ARRAY(
+				SELECT prr.user_id FROM pr_reviewers prr
+				WHERE prr.pull_request_id = p.id
+				ORDER BY prr.user_id
+			) AS reviewers,
+			ts_rank(p.title_tsv, q.query) AS rank
+		FROM pull_requests p, to_tsquery('simple', $1) AS q(query)
+		WHERE p.title_tsv @@ q.query
+			AND ($2 = '' OR p.status = $2)
+			AND ($3 = '' OR p.author_id IN (
+				SELECT u.id FROM users u
+				JOIN teams t ON t.id = u.team_id
+				WHERE t.name = $3
+			))
+		ORDER BY rank DESC, p.created_at DESC, p.id
+		LIMIT $4
+	`
+
+	items := []*models.PullRequestSearchItem{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &items, query,
+		filter.Query, filter.Status, filter.TeamName, filter.Limit,
+	)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return items, nil
+}
+
+// prCursor - позиция в списке PR. Сортировка входит в курсор, чтобы его нельзя было
+// продолжить с другим порядком.
+type prCursor struct {
+	SortBy string    `json:"s"`
+	Desc   bool      `json:"d"`
+	Key    time.Time `json:"k"`
+	ID     string    `json:"i"`
+}
+
+func encodePrCursor(filter *models.PrListFilter, last *models.PullRequestListItem) string {
+	cursor := prCursor{SortBy: filter.SortBy, Desc: filter.Desc, ID: last.ID}
+	if filter.SortBy == models.PrSortMergedAt {
+		cursor.Key = *last.MergedAt
+	} else {
+		cursor.Key = *last.CreatedAt
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePrCursor(filter *models.PrListFilter) (*prCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(filter.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor prCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.SortBy != filter.SortBy || cursor.Desc != filter.Desc {
+		return nil, fmt.Errorf("%w: cursor was issued for another sort order", ErrInvalidCursor)
+	}
+
+	return &cursor, nil
+}
+
 func (r *PullRequestRepo) MarkAsMerged(ctx context.Context, prID string) error {
 	const op = "pull_request_repo.MarkAsMerged"
 
@@ -140,6 +309,95 @@ func (r *PullRequestRepo) MarkAsMerged(ctx context.Context, prID string) error {
 	return nil
 }
 
+// SetStatus переводит PR в статус DRAFT, OPEN или CLOSED, проставляя closed_at для закрытых.
+func (r *PullRequestRepo) SetStatus(ctx context.Context, prID, status string) error {
+	const op = "pull_request_repo.SetStatus"
+
+	query := `
+        UPDATE pull_requests
+        SET status = $1,
+            closed_at = CASE WHEN $1 = 'CLOSED' THEN now() END
+        WHERE id = $2
+    `
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, status, prID)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetUnderstaffed возвращает открытые PR, которым при создании не хватило ревьюеров.
+func (r *PullRequestRepo) GetUnderstaffed(ctx context.Context) ([]*models.PullRequest, error) {
+	const op = "pull_request_repo.GetUnderstaffed"
+
+	query := `
+        SELECT id, title, author_id, status, need_more_reviewers, labels, created_at, merged_at, closed_at
+        FROM pull_requests
+        WHERE status = 'OPEN' AND need_more_reviewers
+        ORDER BY created_at
+    `
+
+	var prs []*models.PullRequest
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &prs, query)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return prs, nil
+}
+
+func (r *PullRequestRepo) SetLabels(ctx context.Context, prID string, labels []string) error {
+	const op = "pull_request_repo.SetLabels"
+
+	query := `UPDATE pull_requests SET labels = $2 WHERE id = $1`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, prID, pq.Array(labels))
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepo) SetNeedMoreReviewers(ctx context.Context, prID string, need bool) error {
+	const op = "pull_request_repo.SetNeedMoreReviewers"
+
+	query := `UPDATE pull_requests SET need_more_reviewers = $1 WHERE id = $2`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, need, prID)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *PullRequestRepo) DeleteReviewer(ctx context.Context, prID, userID string) error {
 	const op = "pull_request_repo.DeleteReviewer"
 
@@ -167,7 +425,7 @@ func (r *PullRequestRepo) GetUserReviews(ctx context.Context, userID string) ([]
 	const op = "pull_request_repo.GetUserReviews"
 
 	query := `
-		SELECT p.id, p.title, p.author_id, p.status, p.created_at, p.merged_at
+		SELECT p.id, p.title, p.author_id, p.status, p.need_more_reviewers, p.labels, p.created_at, p.merged_at, p.closed_at
 		FROM pull_requests p
 		JOIN pr_reviewers prr ON prr.pull_request_id = p.id
 		WHERE prr.user_id = $1
@@ -226,7 +484,7 @@ func (r *PullRequestRepo) ReassignReviewer(ctx context.Context, prID, oldUserID,
 
 	err := r.trm.Do(ctx, func(ctx context.Context) error {
 		// Удаляем старого ревьюера
-		_, err := r.db.ExecContext(ctx,
+		_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx,
 			`DELETE FROM pr_reviewers WHERE pull_request_id=$1 AND user_id=$2`,
 			prID, oldUserID)
 		if err != nil {
@@ -234,7 +492,7 @@ func (r *PullRequestRepo) ReassignReviewer(ctx context.Context, prID, oldUserID,
 		}
 
 		// Добавляем нового ревьюера
-		_, err = r.db.ExecContext(ctx,
+		_, err = r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx,
 			`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`,
 			prID, newUserID)
 		if err != nil {
@@ -253,3 +511,253 @@ func (r *PullRequestRepo) ReassignReviewer(ctx context.Context, prID, oldUserID,
 
 	return err
 }
+
+func (r *PullRequestRepo) GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error) {
+	const op = "pull_request_repo.GetOpenReviewsCount"
+
+	query := `
+		SELECT prr.user_id, COUNT(*) AS open_count
+		FROM pr_reviewers prr
+		JOIN pull_requests p ON p.id = prr.pull_request_id
+		WHERE p.status = 'OPEN' AND prr.user_id = ANY($1)
+		GROUP BY prr.user_id
+	`
+
+	var rows []struct {
+		UserID    string `db:"user_id"`
+		OpenCount int    `db:"open_count"`
+	}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rows, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	// пользователи без открытых ревью в выборку не попадают, для них нагрузка 0
+	loads := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		loads[id] = 0
+	}
+	for _, row := range rows {
+		loads[row.UserID] = row.OpenCount
+	}
+
+	return loads, nil
+}
+
+// GetPairingsInLastPRs считает, сколько из последних lastPRs PR автора ревьюил каждый из reviewerIDs.
+// Ревьюеры без пар в ответ не попадают.
+func (r *PullRequestRepo) GetPairingsInLastPRs(
+	ctx context.Context,
+	authorID string,
+	reviewerIDs []string,
+	lastPRs int,
+) (map[string]int, error) {
+	const op = "pull_request_repo.GetPairingsInLastPRs"
+
+	query := `
+		SELECT prr.user_id AS reviewer_id, COUNT(*) AS pair_count
+		FROM pr_reviewers prr
+		JOIN (
+			SELECT id FROM pull_requests
+			WHERE author_id = $1
+			ORDER BY created_at DESC
+			LIMIT $3
+		) p ON p.id = prr.pull_request_id
+		WHERE prr.user_id = ANY($2)
+		GROUP BY prr.user_id
+	`
+
+	return r.selectPairCounts(ctx, op, query, authorID, pq.Array(reviewerIDs), lastPRs)
+}
+
+// GetPairingsSince считает, сколько PR автора, созданных после since, ревьюил каждый из reviewerIDs.
+// Ревьюеры без пар в ответ не попадают.
+func (r *PullRequestRepo) GetPairingsSince(
+	ctx context.Context,
+	authorID string,
+	reviewerIDs []string,
+	since time.Time,
+) (map[string]int, error) {
+	const op = "pull_request_repo.GetPairingsSince"
+
+	query := `
+		SELECT prr.user_id AS reviewer_id, COUNT(*) AS pair_count
+		FROM pr_reviewers prr
+		JOIN pull_requests p ON p.id = prr.pull_request_id
+		WHERE p.author_id = $1 AND prr.user_id = ANY($2) AND p.created_at >= $3
+		GROUP BY prr.user_id
+	`
+
+	return r.selectPairCounts(ctx, op, query, authorID, pq.Array(reviewerIDs), since)
+}
+
+func (r *PullRequestRepo) selectPairCounts(ctx context.Context, op, query string, args ...any) (map[string]int, error) {
+	var rows []struct {
+		ReviewerID string `db:"reviewer_id"`
+		Count      int    `db:"pair_count"`
+	}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	pairs := make(map[string]int, len(rows))
+	for _, row := range rows {
+		pairs[row.ReviewerID] = row.Count
+	}
+
+	return pairs, nil
+}
+
+// GetTeamPairings возвращает пары автор-ревьюер внутри команды по PR, созданным после since
+// (nil - за все время).
+func (r *PullRequestRepo) GetTeamPairings(ctx context.Context, teamID int, since *time.Time) ([]*models.ReviewPairing, error) {
+	const op = "pull_request_repo.GetTeamPairings"
+
+	query := `
+		SELECT p.author_id, prr.user_id AS reviewer_id, COUNT(*) AS pair_count
+		FROM pr_reviewers prr
+		JOIN pull_requests p ON p.id = prr.pull_request_id
+		JOIN users a ON a.id = p.author_id
+		JOIN users rv ON rv.id = prr.user_id
+		WHERE a.team_id = $1 AND rv.team_id = $1
+			AND ($2::timestamptz IS NULL OR p.created_at >= $2)
+		GROUP BY p.author_id, prr.user_id
+		ORDER BY p.author_id, prr.user_id
+	`
+
+	pairings := []*models.ReviewPairing{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &pairings, query, teamID, since)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return pairings, nil
+}
+
+// GetOpenReviewsByUsers возвращает назначения пользователей ревьюерами на открытые PR.
+func (r *PullRequestRepo) GetOpenReviewsByUsers(ctx context.Context, userIDs []string) ([]*models.ReviewAssignment, error) {
+	const op = "pull_request_repo.GetOpenReviewsByUsers"
+
+	query := `
+		SELECT prr.pull_request_id, prr.user_id AS reviewer_id, p.author_id, p.labels
+		FROM pr_reviewers prr
+		JOIN pull_requests p ON p.id = prr.pull_request_id
+		WHERE p.status = 'OPEN' AND prr.user_id = ANY($1)
+		ORDER BY prr.pull_request_id, prr.user_id
+	`
+
+	var assignments []*models.ReviewAssignment
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &assignments, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return assignments, nil
+}
+
+func (r *PullRequestRepo) SetAssignmentReason(ctx context.Context, prID, userID, reason string) error {
+	const op = "pull_request_repo.SetAssignmentReason"
+
+	query := `
+		UPDATE pr_reviewers SET assignment_reason = $3
+		WHERE pull_request_id = $1 AND user_id = $2;
+	`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, prID, userID, reason)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotAssigned
+	}
+
+	return nil
+}
+
+// GetPendingReviews возвращает назначения на открытые PR старше olderThan,
+// по которым ревьюер с момента назначения не оставил ни одного вердикта.
+func (r *PullRequestRepo) GetPendingReviews(ctx context.Context, olderThan time.Duration) ([]*models.PendingReview, error) {
+	const op = "pull_request_repo.GetPendingReviews"
+
+	query := `
+		SELECT prr.pull_request_id, prr.user_id AS reviewer_id, t.name AS author_team, prr.assigned_at,
+			EXTRACT(EPOCH FROM CURRENT_TIMESTAMP::timestamp - prr.assigned_at)::float8 AS waiting_seconds
+		FROM pr_reviewers prr
+		JOIN pull_requests p ON p.id = prr.pull_request_id
+		JOIN users a ON a.id = p.author_id
+		JOIN teams t ON t.id = a.team_id
+		WHERE p.status = 'OPEN'
+			AND prr.assigned_at <= CURRENT_TIMESTAMP::timestamp - make_interval(secs => $1)
+			AND NOT EXISTS (
+				SELECT 1 FROM pr_reviews rv
+				WHERE rv.pull_request_id = prr.pull_request_id
+					AND rv.user_id = prr.user_id
+					AND rv.created_at >= prr.assigned_at
+			)
+		ORDER BY prr.assigned_at, prr.pull_request_id;
+	`
+
+	pending := []*models.PendingReview{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &pending, query, olderThan.Seconds())
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return pending, nil
+}
+
+func (r *PullRequestRepo) AddReview(ctx context.Context, review *models.Review) error {
+	const op = "pull_request_repo.AddReview"
+
+	query := `
+		INSERT INTO pr_reviews (pull_request_id, user_id, verdict, comment, created_at)
+		VALUES ($1, $2, $3, $4, now())
+		RETURNING id, created_at
+	`
+
+	err := r.getter.DefaultTrOrDB(ctx, r.db).QueryRowxContext(
+		ctx,
+		query,
+		review.PullRequestID,
+		review.ReviewerID,
+		review.Verdict,
+		review.Comment,
+	).Scan(&review.ID, &review.CreatedAt)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
+// GetLatestReviews возвращает последний вердикт каждого текущего ревьюера PR.
+// Вердикты, оставленные до последнего назначения ревьюера, не учитываются.
+func (r *PullRequestRepo) GetLatestReviews(ctx context.Context, prID string) ([]*models.Review, error) {
+	const op = "pull_request_repo.GetLatestReviews"
+
+	query := `
+		SELECT DISTINCT ON (rv.user_id)
+			rv.id, rv.pull_request_id, rv.user_id, rv.verdict, rv.comment, rv.created_at
+		FROM pr_reviews rv
+		JOIN pr_reviewers prr
+			ON prr.pull_request_id = rv.pull_request_id
+			AND prr.user_id = rv.user_id
+			AND rv.created_at >= prr.assigned_at
+		WHERE rv.pull_request_id = $1
+		ORDER BY rv.user_id, rv.created_at DESC, rv.id DESC
+	`
+
+	var reviews []*models.Review
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &reviews, query, prID)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return reviews, nil
+}
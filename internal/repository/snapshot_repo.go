@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SnapshotRepo выгружает данные для симулятора назначений, только читает.
+type SnapshotRepo struct {
+	db *sqlx.DB
+}
+
+func NewSnapshotRepo(db *sqlx.DB) *SnapshotRepo {
+	return &SnapshotRepo{
+		db: db,
+	}
+}
+
+// Load возвращает все команды с настройками, пользователей и PR, кроме черновиков, в порядке создания.
+func (r *SnapshotRepo) Load(ctx context.Context) (*models.Snapshot, error) {
+	const op = "snapshot_repo.Load"
+
+	teamsQuery := `
+		SELECT t.id, t.name,
+			COALESCE(ts.reviewers_count, $1) AS reviewers_count,
+			ts.max_open_reviews,
+			ts.min_senior_reviewers,
+			ts.min_junior_reviewers,
+			ts.max_junior_reviewers,
+			COALESCE(ts.senior_for_junior_authors, FALSE) AS senior_for_junior_authors
+		FROM teams t
+		LEFT JOIN team_settings ts ON ts.team_id = t.id
+		ORDER BY t.id
+	`
+
+	usersQuery := `
+		SELECT id, team_id, is_active, skills, seniority, max_open_reviews
+		FROM users
+		ORDER BY id
+	`
+
+	prsQuery := `
+		SELECT id, author_id, labels, created_at, COALESCE(merged_at, closed_at) AS closed_at
+		FROM pull_requests
+		WHERE status <> 'DRAFT'
+		ORDER BY created_at, id
+	`
+
+	snapshot := &models.Snapshot{
+		Teams:        []models.SnapshotTeam{},
+		Users:        []models.SnapshotUser{},
+		PullRequests: []models.SnapshotPR{},
+	}
+
+	if err := r.db.SelectContext(ctx, &snapshot.Teams, teamsQuery, DefaultReviewersCount); err != nil {
+		return nil, lib.Err(op, err)
+	}
+	if err := r.db.SelectContext(ctx, &snapshot.Users, usersQuery); err != nil {
+		return nil, lib.Err(op, err)
+	}
+	if err := r.db.SelectContext(ctx, &snapshot.PullRequests, prsQuery); err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return snapshot, nil
+}
@@ -24,11 +24,20 @@ func NewStatisticsRepo(db *sqlx.DB) *StatisticsRepo {
 func (r *StatisticsRepo) GetAssignmentsCountStats(ctx context.Context, sort string) ([]*models.UserStatistics, error) {
 	const op = "pull_request_repo.GetAssignmentsCountStats"
 
+	// лимит открытых ревью - личный, а без него - лимит команды
 	query := fmt.Sprintf(`
-		SELECT u.id as user_id, u.name as username, COUNT(pr.pull_request_id) as assignment_count
+		SELECT
+			u.id as user_id,
+			u.name as username,
+			COUNT(pr.pull_request_id) as assignment_count,
+			COUNT(CASE WHEN p.status = 'OPEN' THEN 1 END) as open_review_count,
+			COALESCE(u.max_open_reviews, ts.max_open_reviews) as max_open_reviews,
+			COALESCE(COUNT(CASE WHEN p.status = 'OPEN' THEN 1 END) > COALESCE(u.max_open_reviews, ts.max_open_reviews), FALSE) as over_limit
 		FROM users u
+		LEFT JOIN team_settings ts ON ts.team_id = u.team_id
 		LEFT JOIN pr_reviewers pr ON u.id = pr.user_id
-		GROUP BY u.id, u.name
+		LEFT JOIN pull_requests p ON p.id = pr.pull_request_id
+		GROUP BY u.id, u.name, u.max_open_reviews, ts.max_open_reviews
 		ORDER BY assignment_count %s, u.name ASC
 	`, sort)
 
@@ -51,7 +60,9 @@ func (r *StatisticsRepo) GetPrStats(ctx context.Context) (*models.PrStatistics,
 		SELECT
 		COUNT(*) as pr_count,
 		COUNT(CASE WHEN status = 'OPEN' THEN 1 END) as open_pr_count,
-		COUNT(CASE WHEN status = 'MERGED' THEN 1 END) as merged_pr_count
+		COUNT(CASE WHEN status = 'MERGED' THEN 1 END) as merged_pr_count,
+		COUNT(CASE WHEN status = 'CLOSED' THEN 1 END) as closed_pr_count,
+		COUNT(CASE WHEN status = 'DRAFT' THEN 1 END) as draft_pr_count
 		FROM pull_requests
 	`
 
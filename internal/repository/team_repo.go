@@ -12,9 +12,14 @@ import (
 	"github.com/lib/pq"
 )
 
+// DefaultReviewersCount - число ревьюеров для команд без собственных настроек.
+const DefaultReviewersCount = 2
+
 type TeamRepository interface {
 	Create(ctx context.Context, teamName string) (int, error)
 	GetByTeamName(ctx context.Context, teamName string) (*models.Team, error)
+	GetSettings(ctx context.Context, teamID int) (*models.TeamSettings, error)
+	SaveSettings(ctx context.Context, settings *models.TeamSettings) error
 }
 
 type TeamRepo struct {
@@ -89,3 +94,79 @@ func (r *TeamRepo) GetTeamNameByID(ctx context.Context, teamID int) (string, err
 
 	return teamName, nil
 }
+
+// GetSettings возвращает настройки команды, для команды без записи в team_settings - значения по умолчанию.
+func (r *TeamRepo) GetSettings(ctx context.Context, teamID int) (*models.TeamSettings, error) {
+	const op = "team_repo.GetSettings"
+
+	query := `
+		SELECT t.id AS team_id,
+			COALESCE(ts.reviewers_count, $2) AS reviewers_count,
+			ts.max_open_reviews,
+			ts.required_approvals,
+			ts.min_senior_reviewers,
+			ts.min_junior_reviewers,
+			ts.max_junior_reviewers,
+			COALESCE(ts.senior_for_junior_authors, FALSE) AS senior_for_junior_authors,
+			ts.rotation_window_prs,
+			ts.rotation_window_days
+		FROM teams t
+		LEFT JOIN team_settings ts ON ts.team_id = t.id
+		WHERE t.id = $1;
+	`
+
+	var settings models.TeamSettings
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &settings, query, teamID, DefaultReviewersCount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, lib.Err(op, err)
+	}
+
+	return &settings, nil
+}
+
+func (r *TeamRepo) SaveSettings(ctx context.Context, settings *models.TeamSettings) error {
+	const op = "team_repo.SaveSettings"
+
+	query := `
+		INSERT INTO team_settings (
+			team_id, reviewers_count, max_open_reviews, required_approvals,
+			min_senior_reviewers, min_junior_reviewers, max_junior_reviewers, senior_for_junior_authors,
+			rotation_window_prs, rotation_window_days, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, now())
+		ON CONFLICT (team_id) DO UPDATE SET
+			reviewers_count = EXCLUDED.reviewers_count,
+			max_open_reviews = EXCLUDED.max_open_reviews,
+			required_approvals = EXCLUDED.required_approvals,
+			min_senior_reviewers = EXCLUDED.min_senior_reviewers,
+			min_junior_reviewers = EXCLUDED.min_junior_reviewers,
+			max_junior_reviewers = EXCLUDED.max_junior_reviewers,
+			senior_for_junior_authors = EXCLUDED.senior_for_junior_authors,
+			rotation_window_prs = EXCLUDED.rotation_window_prs,
+			rotation_window_days = EXCLUDED.rotation_window_days,
+			updated_at = EXCLUDED.updated_at;
+	`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		settings.TeamID,
+		settings.ReviewersCount,
+		settings.MaxOpenReviews,
+		settings.RequiredApprovals,
+		settings.MinSeniorReviewers,
+		settings.MinJuniorReviewers,
+		settings.MaxJuniorReviewers,
+		settings.SeniorForJuniorAuthors,
+		settings.RotationWindowPRs,
+		settings.RotationWindowDays,
+	)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
@@ -9,13 +9,22 @@ import (
 	"avito-intership-2025/internal/models"
 	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type UserRepository interface {
 	Save(ctx context.Context, user *models.User) (string, error)
 	GetById(ctx context.Context, userID string) (*models.User, error)
 	GetUsersInTeam(ctx context.Context, teamID int) ([]*models.User, error)
+	GetTeamMembers(ctx context.Context, teamID int) ([]*models.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
+	DeactivateUsers(ctx context.Context, userIDs []string) error
+	SetSkills(ctx context.Context, userID string, skills []string) error
+	GetSkills(ctx context.Context, userIDs []string) (map[string][]string, error)
+	SetSeniority(ctx context.Context, userID, seniority string) error
+	GetSeniority(ctx context.Context, userIDs []string) (map[string]string, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) error
+	GetMaxOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
 }
 
 type UserRepo struct {
@@ -33,20 +42,22 @@ func NewUserRepo(db *sqlx.DB, c *trmsqlx.CtxGetter) *UserRepo {
 func (r *UserRepo) Save(ctx context.Context, user *models.User) (string, error) {
 	const op = "user_repo.Save"
 
+	// пустой уровень не меняет уровень существующего пользователя
 	query := `
-		INSERT INTO users (id, name, team_id, is_active, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
+		INSERT INTO users (id, name, team_id, is_active, seniority, created_at)
+		VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'middle'), NOW())
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
 			team_id = EXCLUDED.team_id,
-			is_active = EXCLUDED.is_active
+			is_active = EXCLUDED.is_active,
+			seniority = CASE WHEN $5 = '' THEN users.seniority ELSE EXCLUDED.seniority END
 		RETURNING id;
 	`
 
 	var userID string
 	err := r.getter.
 		DefaultTrOrDB(ctx, r.db).
-		QueryRowContext(ctx, query, user.ID, user.Name, user.TeamID, user.IsActive).Scan(&userID)
+		QueryRowContext(ctx, query, user.ID, user.Name, user.TeamID, user.IsActive, user.Seniority).Scan(&userID)
 	if err != nil {
 		return "", lib.Err(op, err)
 	}
@@ -58,7 +69,7 @@ func (r *UserRepo) GetById(ctx context.Context, userID string) (*models.User, er
 	const op = "user_repo.GetById"
 
 	query := `
-		SELECT id, name, team_id, is_active, created_at
+		SELECT id, name, team_id, is_active, skills, seniority, max_open_reviews, created_at
 		FROM users
 		WHERE id = $1;
 	`
@@ -79,7 +90,7 @@ func (r *UserRepo) GetUsersInTeam(ctx context.Context, teamName string) ([]*mode
 	const op = "user_repo.GetUsersInTeam"
 
 	query := `
-		SELECT u.id, u.name, u.team_id, u.is_active, u.created_at
+		SELECT u.id, u.name, u.team_id, u.is_active, u.skills, u.seniority, u.max_open_reviews, u.created_at
 		FROM users u
 		JOIN teams t ON u.team_id = t.id
 		WHERE t.name = $1;
@@ -97,6 +108,28 @@ func (r *UserRepo) GetUsersInTeam(ctx context.Context, teamName string) ([]*mode
 	return users, nil
 }
 
+// GetTeamMembers возвращает всех участников команды по id команды, включая неактивных.
+func (r *UserRepo) GetTeamMembers(ctx context.Context, teamID int) ([]*models.User, error) {
+	const op = "user_repo.GetTeamMembers"
+
+	query := `
+		SELECT id, name, team_id, is_active, skills, seniority, max_open_reviews, created_at
+		FROM users
+		WHERE team_id = $1
+		ORDER BY id;
+	`
+
+	users := []*models.User{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &users, query, teamID)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return users, nil
+}
+
+// GetActiveUsersIDInTeam возвращает кандидатов в ревьюеры: активных участников команды,
+// которые сегодня не в отпуске.
 func (r *UserRepo) GetActiveUsersIDInTeam(ctx context.Context, teamID int) ([]string, error) {
 	const op = "user_repo.GetActiveUsersInTeam"
 
@@ -104,7 +137,11 @@ func (r *UserRepo) GetActiveUsersIDInTeam(ctx context.Context, teamID int) ([]st
 		SELECT u.id
 		FROM users u
 		JOIN teams t ON u.team_id = t.id
-		WHERE t.id = $1 AND u.is_active = TRUE;
+		WHERE t.id = $1 AND u.is_active = TRUE
+			AND NOT EXISTS (
+				SELECT 1 FROM user_absences a
+				WHERE a.user_id = u.id AND CURRENT_DATE BETWEEN a.starts_on AND a.ends_on
+			);
 	`
 
 	var users []string
@@ -119,6 +156,147 @@ func (r *UserRepo) GetActiveUsersIDInTeam(ctx context.Context, teamID int) ([]st
 	return users, nil
 }
 
+func (r *UserRepo) SetSkills(ctx context.Context, userID string, skills []string) error {
+	const op = "user_repo.SetSkills"
+
+	query := `UPDATE users SET skills = $2 WHERE id = $1;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, userID, pq.Array(skills))
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetSkills возвращает навыки пользователей, пользователей без навыков в ответе нет.
+func (r *UserRepo) GetSkills(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	const op = "user_repo.GetSkills"
+
+	query := `
+		SELECT id, skills
+		FROM users
+		WHERE id = ANY($1) AND cardinality(skills) > 0;
+	`
+
+	var rows []struct {
+		UserID string         `db:"id"`
+		Skills pq.StringArray `db:"skills"`
+	}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rows, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	skills := make(map[string][]string, len(rows))
+	for _, row := range rows {
+		skills[row.UserID] = row.Skills
+	}
+
+	return skills, nil
+}
+
+func (r *UserRepo) SetSeniority(ctx context.Context, userID, seniority string) error {
+	const op = "user_repo.SetSeniority"
+
+	query := `UPDATE users SET seniority = $2 WHERE id = $1;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, userID, seniority)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetSeniority возвращает уровни пользователей по id.
+func (r *UserRepo) GetSeniority(ctx context.Context, userIDs []string) (map[string]string, error) {
+	const op = "user_repo.GetSeniority"
+
+	query := `SELECT id, seniority FROM users WHERE id = ANY($1);`
+
+	var rows []struct {
+		UserID    string `db:"id"`
+		Seniority string `db:"seniority"`
+	}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rows, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	levels := make(map[string]string, len(rows))
+	for _, row := range rows {
+		levels[row.UserID] = row.Seniority
+	}
+
+	return levels, nil
+}
+
+// SetMaxOpenReviews задает личный лимит открытых ревью, nil сбрасывает его к лимиту команды.
+func (r *UserRepo) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) error {
+	const op = "user_repo.SetMaxOpenReviews"
+
+	query := `UPDATE users SET max_open_reviews = $2 WHERE id = $1;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, userID, limit)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetMaxOpenReviews возвращает личные лимиты открытых ревью, пользователей без лимита в ответе нет.
+func (r *UserRepo) GetMaxOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	const op = "user_repo.GetMaxOpenReviews"
+
+	query := `
+		SELECT id, max_open_reviews
+		FROM users
+		WHERE id = ANY($1) AND max_open_reviews IS NOT NULL;
+	`
+
+	var rows []struct {
+		UserID string `db:"id"`
+		Limit  int    `db:"max_open_reviews"`
+	}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rows, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	limits := make(map[string]int, len(rows))
+	for _, row := range rows {
+		limits[row.UserID] = row.Limit
+	}
+
+	return limits, nil
+}
+
 func (r *UserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) error {
 	const op = "user_repo.SetIsActive"
 
@@ -142,6 +320,20 @@ func (r *UserRepo) SetIsActive(ctx context.Context, userID string, isActive bool
 	return nil
 }
 
+// DeactivateUsers выключает пользователей одним запросом.
+func (r *UserRepo) DeactivateUsers(ctx context.Context, userIDs []string) error {
+	const op = "user_repo.DeactivateUsers"
+
+	query := `UPDATE users SET is_active = FALSE WHERE id = ANY($1)`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
 /* заменил на Save, но не хочу удалять, т.к. мало ли понадобятся
 
 func (r *UserRepo) Update(ctx context.Context, user *models.User) error {
@@ -0,0 +1,285 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type WebhookRepository interface {
+	AddOutboxEvent(ctx context.Context, eventType string, payload []byte) error
+	CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id int64, attempts int) error
+	MarkFailed(ctx context.Context, id int64, attempts int, nextAttemptAt *time.Time, lastError string) error
+	ListDeliveries(ctx context.Context, status string, subscriptionID int, limit int) ([]*models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, id int64) error
+	ReplayDead(ctx context.Context, subscriptionID int) (int, error)
+}
+
+type WebhookRepo struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewWebhookRepo(db *sqlx.DB, c *trmsqlx.CtxGetter) *WebhookRepo {
+	return &WebhookRepo{
+		db:     db,
+		getter: c,
+	}
+}
+
+// AddOutboxEvent сохраняет событие и ставит его в очередь доставки всем подпискам на этот тип.
+// Вызывается в транзакции изменения PR: событие появится, только если изменение зафиксировано.
+func (r *WebhookRepo) AddOutboxEvent(ctx context.Context, eventType string, payload []byte) error {
+	const op = "webhook_repo.AddOutboxEvent"
+
+	query := `
+		WITH event AS (
+			INSERT INTO outbox_events (event_type, payload, created_at)
+			VALUES ($1, $2, now())
+			RETURNING id
+		)
+		INSERT INTO webhook_deliveries (event_id, subscription_id, next_attempt_at, created_at)
+		SELECT event.id, s.id, now(), now()
+		FROM event, webhook_subscriptions s
+		WHERE cardinality(s.event_types) = 0 OR $1 = ANY(s.event_types);
+	`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, eventType, payload)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) CreateSubscription(
+	ctx context.Context,
+	sub *models.WebhookSubscription,
+) (*models.WebhookSubscription, error) {
+	const op = "webhook_repo.CreateSubscription"
+
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, created_at)
+		VALUES ($1, $2, $3, now())
+		RETURNING id, url, secret, event_types, created_at;
+	`
+
+	var created models.WebhookSubscription
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &created, query,
+		sub.URL,
+		sub.Secret,
+		pq.Array(sub.EventTypes),
+	)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return &created, nil
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	const op = "webhook_repo.ListSubscriptions"
+
+	query := `
+		SELECT id, url, secret, event_types, created_at
+		FROM webhook_subscriptions
+		ORDER BY id;
+	`
+
+	subs := []*models.WebhookSubscription{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &subs, query)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return subs, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с ее доставками.
+func (r *WebhookRepo) DeleteSubscription(ctx context.Context, id int) error {
+	const op = "webhook_repo.DeleteSubscription"
+
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1;`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries забирает до limit доставок, время попытки которых наступило, и откладывает
+// их следующую попытку на lease: пока доставка выполняется, другие экземпляры ее не возьмут.
+func (r *WebhookRepo) ClaimDueDeliveries(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]*models.WebhookDelivery, error) {
+	const op = "webhook_repo.ClaimDueDeliveries"
+
+	query := `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'PENDING' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = now() + make_interval(secs => $2)
+		FROM due, outbox_events e, webhook_subscriptions s
+		WHERE d.id = due.id AND e.id = d.event_id AND s.id = d.subscription_id
+		RETURNING d.id, d.event_id, d.subscription_id, d.status, d.attempts, d.next_attempt_at,
+			d.last_error, d.delivered_at, d.created_at,
+			e.event_type, e.payload, e.created_at AS event_created_at, s.url, s.secret;
+	`
+
+	deliveries := []*models.WebhookDelivery{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &deliveries, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id int64, attempts int) error {
+	const op = "webhook_repo.MarkDelivered"
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'DELIVERED', attempts = $2, last_error = '', delivered_at = now()
+		WHERE id = $1;
+	`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, id, attempts)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
+// MarkFailed записывает неудачную попытку. Без времени следующей попытки доставка
+// переходит в DEAD.
+func (r *WebhookRepo) MarkFailed(
+	ctx context.Context,
+	id int64,
+	attempts int,
+	nextAttemptAt *time.Time,
+	lastError string,
+) error {
+	const op = "webhook_repo.MarkFailed"
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = CASE WHEN $3::timestamp IS NULL THEN 'DEAD' ELSE 'PENDING' END,
+			attempts = $2,
+			next_attempt_at = COALESCE($3, next_attempt_at),
+			last_error = $4
+		WHERE id = $1;
+	`
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, id, attempts, nextAttemptAt, lastError)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	return nil
+}
+
+// ListDeliveries возвращает последние доставки, при необходимости по статусу и подписке.
+func (r *WebhookRepo) ListDeliveries(
+	ctx context.Context,
+	status string,
+	subscriptionID int,
+	limit int,
+) ([]*models.WebhookDelivery, error) {
+	const op = "webhook_repo.ListDeliveries"
+
+	query := `
+		SELECT d.id, d.event_id, d.subscription_id, d.status, d.attempts, d.next_attempt_at,
+			d.last_error, d.delivered_at, d.created_at, e.event_type
+		FROM webhook_deliveries d
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE ($1 = '' OR d.status = $1) AND ($2 = 0 OR d.subscription_id = $2)
+		ORDER BY d.id DESC
+		LIMIT $3;
+	`
+
+	deliveries := []*models.WebhookDelivery{}
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &deliveries, query, status, subscriptionID, limit)
+	if err != nil {
+		return nil, lib.Err(op, err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery ставит доставку в очередь заново с обнуленным счетчиком попыток.
+func (r *WebhookRepo) ReplayDelivery(ctx context.Context, id int64) error {
+	const op = "webhook_repo.ReplayDelivery"
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = now(), last_error = '', delivered_at = NULL
+		WHERE id = $1;
+	`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return lib.Err(op, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ReplayDead ставит в очередь заново все доставки подписки в статусе DEAD
+// (всех подписок, если subscriptionID = 0) и возвращает их число.
+func (r *WebhookRepo) ReplayDead(ctx context.Context, subscriptionID int) (int, error) {
+	const op = "webhook_repo.ReplayDead"
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = now(), last_error = ''
+		WHERE status = 'DEAD' AND ($1 = 0 OR subscription_id = $1);
+	`
+
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, subscriptionID)
+	if err != nil {
+		return 0, lib.Err(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, lib.Err(op, err)
+	}
+
+	return int(rowsAffected), nil
+}
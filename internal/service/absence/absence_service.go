@@ -0,0 +1,313 @@
+package absence
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/ical"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const dateLayout = time.DateOnly
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=AbsenceStore
+type AbsenceStore interface {
+	Save(ctx context.Context, absence *models.Absence) (*models.Absence, error)
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, userID string) ([]*models.Absence, error)
+	GetStartedForReassign(ctx context.Context) ([]*models.Absence, error)
+	MarkReviewsReassigned(ctx context.Context, id int) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=AbsentUserLookup
+type AbsentUserLookup interface {
+	GetById(ctx context.Context, userID string) (*models.User, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=OpenReviewsReassigner
+type OpenReviewsReassigner interface {
+	ReassignOpenReviews(ctx context.Context, teamID int, userIDs []string, reason string) ([]api.ReviewReplacement, []string, error)
+}
+
+type AbsenceService struct {
+	store      AbsenceStore
+	users      AbsentUserLookup
+	reassigner OpenReviewsReassigner
+	trm        service.TransactionManager
+}
+
+func NewAbsenceService(
+	trm service.TransactionManager,
+	store AbsenceStore,
+	users AbsentUserLookup,
+	reassigner OpenReviewsReassigner,
+) *AbsenceService {
+	return &AbsenceService{
+		trm:        trm,
+		store:      store,
+		users:      users,
+		reassigner: reassigner,
+	}
+}
+
+// List возвращает текущие и будущие отсутствия пользователя (всех пользователей, если userID пуст).
+func (s *AbsenceService) List(ctx context.Context, userID string) ([]api.AbsenceSchema, error) {
+	var resp []api.AbsenceSchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if userID != "" {
+			if _, err := s.users.GetById(ctx, userID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		resp, err = s.listAbsences(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Add добавляет отсутствие. Флаг is_active пользователя не меняется: пользователь
+// просто не попадает в кандидаты на ревью в дни отсутствия.
+// Если отсутствие уже началось и нужно переназначение, ревью снимаются сразу.
+func (s *AbsenceService) Add(ctx context.Context, input *api.AbsenceSchema) (*api.AbsenceResponse, error) {
+	absence, err := fromAbsenceSchema(input)
+	if err != nil {
+		return nil, err
+	}
+	if absence.EndsOn.Before(today()) {
+		return nil, fmt.Errorf("%w: absence has already ended", repo.ErrInvalidAbsence)
+	}
+
+	resp := &api.AbsenceResponse{}
+
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetById(ctx, absence.UserID)
+		if err != nil {
+			return err
+		}
+
+		saved, err := s.store.Save(ctx, absence)
+		if err != nil {
+			return err
+		}
+
+		resp.Reassigned, resp.Understaffed, err = s.reassignIfStarted(ctx, user.TeamID, saved)
+		if err != nil {
+			return err
+		}
+
+		resp.Absence = toAbsenceSchema(saved)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Delete удаляет отсутствие и возвращает оставшиеся текущие и будущие отсутствия.
+// Снятые ранее ревью обратно не возвращаются.
+func (s *AbsenceService) Delete(ctx context.Context, id int) ([]api.AbsenceSchema, error) {
+	var resp []api.AbsenceSchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.store.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		resp, err = s.listAbsences(ctx, "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Import загружает отсутствия пользователя из календаря .ics. Повторный импорт того же
+// календаря обновляет отсутствия по UID событий, закончившиеся события пропускаются.
+func (s *AbsenceService) Import(
+	ctx context.Context,
+	userID string,
+	content string,
+	reassignReviews bool,
+) (*api.AbsenceImportResponse, error) {
+	events, err := ical.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repo.ErrInvalidAbsence, err)
+	}
+
+	resp := &api.AbsenceImportResponse{
+		Absences: []api.AbsenceSchema{},
+	}
+
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetById(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if event.End.Before(today()) {
+				resp.Skipped++
+				continue
+			}
+
+			saved, err := s.store.Save(ctx, &models.Absence{
+				UserID:          userID,
+				StartsOn:        event.Start,
+				EndsOn:          event.End,
+				Reason:          event.Summary,
+				ExternalUID:     &event.UID,
+				ReassignReviews: reassignReviews,
+			})
+			if err != nil {
+				return err
+			}
+
+			reassigned, understaffed, err := s.reassignIfStarted(ctx, user.TeamID, saved)
+			if err != nil {
+				return err
+			}
+			resp.Reassigned = append(resp.Reassigned, reassigned...)
+			resp.Understaffed = append(resp.Understaffed, understaffed...)
+
+			resp.Absences = append(resp.Absences, toAbsenceSchema(saved))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReassignStarted снимает с открытых ревью пользователей, у которых началось отсутствие
+// с переназначением. Каждое отсутствие обрабатывается в своей транзакции, чтобы ошибка
+// по одному пользователю не мешала остальным. Возвращает число обработанных отсутствий.
+func (s *AbsenceService) ReassignStarted(ctx context.Context) (int, error) {
+	absences, err := s.store.GetStartedForReassign(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		processed int
+		errs      []error
+	)
+	for _, a := range absences {
+		err := s.trm.Do(ctx, func(ctx context.Context) error {
+			user, err := s.users.GetById(ctx, a.UserID)
+			if err != nil {
+				return err
+			}
+
+			_, _, err = s.reassignIfStarted(ctx, user.TeamID, a)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("absence %d: %w", a.ID, err))
+			continue
+		}
+		processed++
+	}
+
+	return processed, errors.Join(errs...)
+}
+
+// reassignIfStarted снимает пользователя с открытых ревью, если отсутствие идет сегодня,
+// требует переназначения и еще не было обработано.
+func (s *AbsenceService) reassignIfStarted(
+	ctx context.Context,
+	teamID int,
+	a *models.Absence,
+) ([]api.ReviewReplacement, []string, error) {
+	now := today()
+	if !a.ReassignReviews || a.ReviewsReassignedAt != nil || a.StartsOn.After(now) || a.EndsOn.Before(now) {
+		return nil, nil, nil
+	}
+
+	// отметка ставится до переназначения: если отсутствие уже обработал
+	// другой экземпляр сервиса, ревью второй раз не трогаем
+	err := s.store.MarkReviewsReassigned(ctx, a.ID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reason := fmt.Sprintf("reviewer is absent until %s", a.EndsOn.Format(dateLayout))
+	reassigned, understaffed, err := s.reassigner.ReassignOpenReviews(ctx, teamID, []string{a.UserID}, reason)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reassignedAt := time.Now()
+	a.ReviewsReassignedAt = &reassignedAt
+	return reassigned, understaffed, nil
+}
+
+func (s *AbsenceService) listAbsences(ctx context.Context, userID string) ([]api.AbsenceSchema, error) {
+	absences, err := s.store.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]api.AbsenceSchema, 0, len(absences))
+	for _, a := range absences {
+		resp = append(resp, toAbsenceSchema(a))
+	}
+	return resp, nil
+}
+
+// today возвращает текущий день в том же виде, в каком даты отсутствий читаются из базы.
+func today() time.Time {
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func fromAbsenceSchema(input *api.AbsenceSchema) (*models.Absence, error) {
+	startsOn, err := time.Parse(dateLayout, input.StartsOn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: starts_on: %w", repo.ErrInvalidAbsence, err)
+	}
+	endsOn, err := time.Parse(dateLayout, input.EndsOn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ends_on: %w", repo.ErrInvalidAbsence, err)
+	}
+	if endsOn.Before(startsOn) {
+		return nil, fmt.Errorf("%w: ends_on is before starts_on", repo.ErrInvalidAbsence)
+	}
+
+	return &models.Absence{
+		UserID:          input.UserID,
+		StartsOn:        startsOn,
+		EndsOn:          endsOn,
+		Reason:          input.Reason,
+		ReassignReviews: input.ReassignReviews,
+	}, nil
+}
+
+func toAbsenceSchema(a *models.Absence) api.AbsenceSchema {
+	return api.AbsenceSchema{
+		ID:                  a.ID,
+		UserID:              a.UserID,
+		StartsOn:            a.StartsOn.Format(dateLayout),
+		EndsOn:              a.EndsOn.Format(dateLayout),
+		Reason:              a.Reason,
+		ReassignReviews:     a.ReassignReviews,
+		ReviewsReassignedAt: a.ReviewsReassignedAt,
+	}
+}
@@ -0,0 +1,263 @@
+package absence_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/absence"
+	"avito-intership-2025/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDoMock(t *testing.T, ctx context.Context, wantErr error) *mocks.MockManager {
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), wantErr)
+		}).Return(wantErr).Once()
+
+	return trm
+}
+
+// day возвращает дату через offset дней от сегодняшней в том виде, в каком она хранится в базе.
+func day(offset int) time.Time {
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d+offset, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAbsenceService_Add_FutureAbsence(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewAbsenceStore(t)
+	users := mocks.NewAbsentUserLookup(t)
+	reassigner := mocks.NewOpenReviewsReassigner(t)
+	trm := newDoMock(t, ctx, nil)
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1", TeamID: 3}, nil).Once()
+	store.On("Save", ctx, &models.Absence{
+		UserID:          "u1",
+		StartsOn:        day(7),
+		EndsOn:          day(20),
+		Reason:          "vacation",
+		ReassignReviews: true,
+	}).Return(&models.Absence{
+		ID:              1,
+		UserID:          "u1",
+		StartsOn:        day(7),
+		EndsOn:          day(20),
+		Reason:          "vacation",
+		ReassignReviews: true,
+	}, nil).Once()
+
+	svc := absence.NewAbsenceService(trm, store, users, reassigner)
+	resp, err := svc.Add(ctx, &api.AbsenceSchema{
+		UserID:          "u1",
+		StartsOn:        day(7).Format(time.DateOnly),
+		EndsOn:          day(20).Format(time.DateOnly),
+		Reason:          "vacation",
+		ReassignReviews: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Absence.ID)
+	assert.Equal(t, day(7).Format(time.DateOnly), resp.Absence.StartsOn)
+	assert.Empty(t, resp.Reassigned)
+	// отсутствие еще не началось - ревью снимет воркер
+	store.AssertNotCalled(t, "MarkReviewsReassigned", mock.Anything, mock.Anything)
+}
+
+func TestAbsenceService_Add_StartedAbsenceReassignsReviews(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewAbsenceStore(t)
+	users := mocks.NewAbsentUserLookup(t)
+	reassigner := mocks.NewOpenReviewsReassigner(t)
+	trm := newDoMock(t, ctx, nil)
+
+	saved := &models.Absence{ID: 2, UserID: "u1", StartsOn: day(0), EndsOn: day(3), ReassignReviews: true}
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1", TeamID: 3}, nil).Once()
+	store.On("Save", ctx, mock.AnythingOfType("*models.Absence")).Return(saved, nil).Once()
+	store.On("MarkReviewsReassigned", ctx, 2).Return(nil).Once()
+	reassigner.On("ReassignOpenReviews", ctx, 3, []string{"u1"},
+		"reviewer is absent until "+day(3).Format(time.DateOnly)).Return(
+		[]api.ReviewReplacement{{PullRequestID: "pr1", OldReviewerID: "u1", ReplacedBy: "u2"}},
+		[]string{"pr2"},
+		nil,
+	).Once()
+
+	svc := absence.NewAbsenceService(trm, store, users, reassigner)
+	resp, err := svc.Add(ctx, &api.AbsenceSchema{
+		UserID:          "u1",
+		StartsOn:        day(0).Format(time.DateOnly),
+		EndsOn:          day(3).Format(time.DateOnly),
+		ReassignReviews: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.ReviewReplacement{{PullRequestID: "pr1", OldReviewerID: "u1", ReplacedBy: "u2"}}, resp.Reassigned)
+	assert.Equal(t, []string{"pr2"}, resp.Understaffed)
+	assert.NotNil(t, resp.Absence.ReviewsReassignedAt)
+}
+
+func TestAbsenceService_Add_InvalidPeriod(t *testing.T) {
+	svc := absence.NewAbsenceService(nil, nil, nil, nil)
+
+	cases := []*api.AbsenceSchema{
+		{UserID: "u1", StartsOn: day(5).Format(time.DateOnly), EndsOn: day(1).Format(time.DateOnly)},
+		{UserID: "u1", StartsOn: day(-10).Format(time.DateOnly), EndsOn: day(-1).Format(time.DateOnly)},
+		{UserID: "u1", StartsOn: "01.07.2025", EndsOn: day(1).Format(time.DateOnly)},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Add(context.Background(), tc)
+		assert.ErrorIs(t, err, repo.ErrInvalidAbsence, "%+v", tc)
+	}
+}
+
+func TestAbsenceService_Add_UserNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewAbsenceStore(t)
+	users := mocks.NewAbsentUserLookup(t)
+	trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+	users.On("GetById", ctx, "ghost").Return(nil, repo.ErrNotFound).Once()
+
+	svc := absence.NewAbsenceService(trm, store, users, nil)
+	_, err := svc.Add(ctx, &api.AbsenceSchema{
+		UserID:   "ghost",
+		StartsOn: day(1).Format(time.DateOnly),
+		EndsOn:   day(2).Format(time.DateOnly),
+	})
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	store.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestAbsenceService_Import(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewAbsenceStore(t)
+	users := mocks.NewAbsentUserLookup(t)
+	trm := newDoMock(t, ctx, nil)
+
+	content := fmt.Sprintf(`BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:old
+DTSTART;VALUE=DATE:%s
+DTEND;VALUE=DATE:%s
+END:VEVENT
+BEGIN:VEVENT
+UID:summer
+SUMMARY:Vacation
+DTSTART;VALUE=DATE:%s
+DTEND;VALUE=DATE:%s
+END:VEVENT
+END:VCALENDAR`,
+		day(-30).Format("20060102"), day(-20).Format("20060102"),
+		day(10).Format("20060102"), day(24).Format("20060102"),
+	)
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1", TeamID: 3}, nil).Once()
+	store.On("Save", ctx, mock.MatchedBy(func(a *models.Absence) bool {
+		return a.UserID == "u1" && *a.ExternalUID == "summer" && a.Reason == "Vacation" &&
+			a.StartsOn.Equal(day(10)) && a.EndsOn.Equal(day(23)) && !a.ReassignReviews
+	})).Return(&models.Absence{ID: 5, UserID: "u1", StartsOn: day(10), EndsOn: day(23), Reason: "Vacation"}, nil).Once()
+
+	svc := absence.NewAbsenceService(trm, store, users, nil)
+	resp, err := svc.Import(ctx, "u1", content, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Skipped)
+	assert.Len(t, resp.Absences, 1)
+	assert.Equal(t, day(23).Format(time.DateOnly), resp.Absences[0].EndsOn)
+}
+
+func TestAbsenceService_Import_InvalidCalendar(t *testing.T) {
+	svc := absence.NewAbsenceService(nil, nil, nil, nil)
+
+	_, err := svc.Import(context.Background(), "u1", "BEGIN:VEVENT\nDTSTART:20250101\nEND:VEVENT", true)
+
+	assert.ErrorIs(t, err, repo.ErrInvalidAbsence)
+}
+
+func TestAbsenceService_ReassignStarted(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewAbsenceStore(t)
+	users := mocks.NewAbsentUserLookup(t)
+	reassigner := mocks.NewOpenReviewsReassigner(t)
+
+	dbErr := errors.New("db is down")
+
+	// каждое отсутствие обрабатывается в своей транзакции
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+	for _, wantErr := range []error{nil, nil, dbErr} {
+		trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(context.Context) error)
+				assert.ErrorIs(t, fn(ctx), wantErr)
+			}).Return(wantErr).Once()
+	}
+
+	store.On("GetStartedForReassign", ctx).Return([]*models.Absence{
+		{ID: 1, UserID: "u1", StartsOn: day(0), EndsOn: day(5), ReassignReviews: true},
+		{ID: 2, UserID: "u2", StartsOn: day(-1), EndsOn: day(5), ReassignReviews: true},
+		{ID: 3, UserID: "u3", StartsOn: day(-2), EndsOn: day(0), ReassignReviews: true},
+	}, nil).Once()
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1", TeamID: 1}, nil).Once()
+	store.On("MarkReviewsReassigned", ctx, 1).Return(nil).Once()
+	reassigner.On("ReassignOpenReviews", ctx, 1, []string{"u1"}, mock.AnythingOfType("string")).Return(nil, nil, nil).Once()
+
+	// отсутствие уже обработал другой экземпляр сервиса
+	users.On("GetById", ctx, "u2").Return(&models.User{ID: "u2", TeamID: 1}, nil).Once()
+	store.On("MarkReviewsReassigned", ctx, 2).Return(repo.ErrNotFound).Once()
+
+	users.On("GetById", ctx, "u3").Return(nil, dbErr).Once()
+
+	svc := absence.NewAbsenceService(trm, store, users, reassigner)
+	processed, err := svc.ReassignStarted(ctx)
+
+	assert.Equal(t, 2, processed)
+	assert.ErrorIs(t, err, dbErr)
+	reassigner.AssertNumberOfCalls(t, "ReassignOpenReviews", 1)
+}
+
+func TestAbsenceService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewAbsenceStore(t)
+	trm := newDoMock(t, ctx, nil)
+
+	store.On("Delete", ctx, 4).Return(nil).Once()
+	store.On("List", ctx, "").Return([]*models.Absence{
+		{ID: 5, UserID: "u2", StartsOn: day(1), EndsOn: day(1)},
+	}, nil).Once()
+
+	svc := absence.NewAbsenceService(trm, store, nil, nil)
+	resp, err := svc.Delete(ctx, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.AbsenceSchema{{
+		ID:       5,
+		UserID:   "u2",
+		StartsOn: day(1).Format(time.DateOnly),
+		EndsOn:   day(1).Format(time.DateOnly),
+	}}, resp)
+}
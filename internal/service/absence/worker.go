@@ -0,0 +1,45 @@
+package absence
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"avito-intership-2025/internal/lib/sl"
+	"avito-intership-2025/internal/lib/worker"
+)
+
+type startedReassigner interface {
+	ReassignStarted(ctx context.Context) (int, error)
+}
+
+// Worker периодически снимает с открытых ревью пользователей, у которых началось отсутствие.
+type Worker struct {
+	log      *slog.Logger
+	service  startedReassigner
+	interval time.Duration
+}
+
+func NewWorker(log *slog.Logger, s startedReassigner, interval time.Duration) *Worker {
+	return &Worker{
+		log:      log.With(slog.String("worker", "absences")),
+		service:  s,
+		interval: interval,
+	}
+}
+
+// Run блокируется до отмены ctx. Первая проверка выполняется сразу при запуске.
+func (w *Worker) Run(ctx context.Context) {
+	worker.Every(ctx, w.interval, w.tick)
+	w.log.Info("absence worker stopped")
+}
+
+func (w *Worker) tick(ctx context.Context) {
+	processed, err := w.service.ReassignStarted(ctx)
+	if err != nil && ctx.Err() == nil {
+		w.log.Error("failed to reassign reviews of absent users", sl.Err(err))
+	}
+	if processed > 0 {
+		w.log.Info("reviews of absent users reassigned", slog.Int("absences", processed))
+	}
+}
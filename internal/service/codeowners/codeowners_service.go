@@ -0,0 +1,246 @@
+package codeowners
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/codeowners"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=RuleStore
+type RuleStore interface {
+	GetRules(ctx context.Context) ([]*models.CodeOwnerRule, error)
+	SaveRule(ctx context.Context, pattern string, userIDs []string, teamIDs []int) error
+	DeleteRule(ctx context.Context, pattern string) error
+	DeleteAllRules(ctx context.Context) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=OwnerUserLookup
+type OwnerUserLookup interface {
+	GetById(ctx context.Context, userID string) (*models.User, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=OwnerTeamLookup
+type OwnerTeamLookup interface {
+	GetByTeamName(ctx context.Context, teamName string) (*models.Team, error)
+}
+
+type CodeOwnersService struct {
+	ruleStore RuleStore
+	users     OwnerUserLookup
+	teams     OwnerTeamLookup
+	trm       service.TransactionManager
+}
+
+func NewCodeOwnersService(
+	trm service.TransactionManager,
+	ruleStore RuleStore,
+	users OwnerUserLookup,
+	teams OwnerTeamLookup,
+) *CodeOwnersService {
+	return &CodeOwnersService{
+		trm:       trm,
+		ruleStore: ruleStore,
+		users:     users,
+		teams:     teams,
+	}
+}
+
+func (s *CodeOwnersService) List(ctx context.Context) ([]api.CodeOwnerRuleSchema, error) {
+	var resp []api.CodeOwnerRuleSchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = s.listRules(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetRule создает или обновляет правило и возвращает все правила.
+// Все пользователи и команды правила должны существовать.
+func (s *CodeOwnersService) SetRule(ctx context.Context, rule *api.CodeOwnerRuleSchema) ([]api.CodeOwnerRuleSchema, error) {
+	if _, err := codeowners.Compile(rule.Pattern); err != nil {
+		return nil, fmt.Errorf("%w: %w", repo.ErrInvalidPattern, err)
+	}
+
+	var resp []api.CodeOwnerRuleSchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		for _, userID := range rule.UserIDs {
+			if _, err := s.users.GetById(ctx, userID); err != nil {
+				return fmt.Errorf("user %s: %w", userID, err)
+			}
+		}
+
+		teamIDs := make([]int, 0, len(rule.TeamNames))
+		for _, teamName := range rule.TeamNames {
+			team, err := s.teams.GetByTeamName(ctx, teamName)
+			if err != nil {
+				return fmt.Errorf("team %s: %w", teamName, err)
+			}
+			teamIDs = append(teamIDs, team.ID)
+		}
+
+		if err := s.ruleStore.SaveRule(ctx, rule.Pattern, rule.UserIDs, teamIDs); err != nil {
+			return err
+		}
+
+		var err error
+		resp, err = s.listRules(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteRule удаляет правило по шаблону и возвращает оставшиеся правила.
+func (s *CodeOwnersService) DeleteRule(ctx context.Context, pattern string) ([]api.CodeOwnerRuleSchema, error) {
+	var resp []api.CodeOwnerRuleSchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.ruleStore.DeleteRule(ctx, pattern); err != nil {
+			return err
+		}
+
+		var err error
+		resp, err = s.listRules(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Import заменяет все правила содержимым файла CODEOWNERS.
+// Владельцы вида @user сопоставляются с user_id, @org/team - с названием команды.
+// Неизвестные владельцы не прерывают импорт и возвращаются в ответе.
+func (s *CodeOwnersService) Import(ctx context.Context, content string) (*api.CodeOwnersImportResponse, error) {
+	lines, err := codeowners.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repo.ErrInvalidPattern, err)
+	}
+
+	resp := &api.CodeOwnersImportResponse{
+		UnknownOwners: []string{},
+	}
+
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.ruleStore.DeleteAllRules(ctx); err != nil {
+			return err
+		}
+
+		for _, line := range dedupeLines(lines) {
+			userIDs, teamIDs, unknown, err := s.resolveOwners(ctx, line.Owners)
+			if err != nil {
+				return err
+			}
+			resp.UnknownOwners = append(resp.UnknownOwners, unknown...)
+
+			if err := s.ruleStore.SaveRule(ctx, line.Pattern, userIDs, teamIDs); err != nil {
+				return err
+			}
+		}
+
+		rules, err := s.listRules(ctx)
+		if err != nil {
+			return err
+		}
+		resp.Rules = rules
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *CodeOwnersService) listRules(ctx context.Context) ([]api.CodeOwnerRuleSchema, error) {
+	rules, err := s.ruleStore.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]api.CodeOwnerRuleSchema, 0, len(rules))
+	for _, r := range rules {
+		resp = append(resp, toRuleSchema(r))
+	}
+	return resp, nil
+}
+
+// resolveOwners раскладывает владельцев из CODEOWNERS на пользователей и команды.
+func (s *CodeOwnersService) resolveOwners(ctx context.Context, owners []string) ([]string, []int, []string, error) {
+	userIDs := []string{}
+	teamIDs := []int{}
+	unknown := []string{}
+
+	for _, owner := range owners {
+		name, ok := strings.CutPrefix(owner, "@")
+		if !ok {
+			// email и прочие форматы не сопоставить с пользователем сервиса
+			unknown = append(unknown, owner)
+			continue
+		}
+
+		if _, teamName, isTeam := strings.Cut(name, "/"); isTeam {
+			team, err := s.teams.GetByTeamName(ctx, teamName)
+			if errors.Is(err, repo.ErrNotFound) {
+				unknown = append(unknown, owner)
+				continue
+			}
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			teamIDs = append(teamIDs, team.ID)
+			continue
+		}
+
+		_, err := s.users.GetById(ctx, name)
+		if errors.Is(err, repo.ErrNotFound) {
+			unknown = append(unknown, owner)
+			continue
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		userIDs = append(userIDs, name)
+	}
+
+	return userIDs, teamIDs, unknown, nil
+}
+
+// dedupeLines оставляет для повторяющегося шаблона только последнюю строку:
+// в CODEOWNERS более ранняя строка с тем же шаблоном ни на что не влияет.
+func dedupeLines(lines []codeowners.Line) []codeowners.Line {
+	last := make(map[string]int, len(lines))
+	for i, l := range lines {
+		last[l.Pattern] = i
+	}
+
+	res := make([]codeowners.Line, 0, len(last))
+	for i, l := range lines {
+		if last[l.Pattern] == i {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+func toRuleSchema(r *models.CodeOwnerRule) api.CodeOwnerRuleSchema {
+	return api.CodeOwnerRuleSchema{
+		Pattern:   r.Pattern,
+		UserIDs:   append([]string{}, r.UserIDs...),
+		TeamNames: append([]string{}, r.TeamNames...),
+	}
+}
@@ -0,0 +1,132 @@
+package codeowners_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/codeowners"
+	"avito-intership-2025/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDoMock(t *testing.T, ctx context.Context, wantErr error) *mocks.MockManager {
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), wantErr)
+		}).Return(wantErr).Once()
+
+	return trm
+}
+
+func TestCodeOwnersService_SetRule_Success(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewRuleStore(t)
+	users := mocks.NewOwnerUserLookup(t)
+	teams := mocks.NewOwnerTeamLookup(t)
+	trm := newDoMock(t, ctx, nil)
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1"}, nil).Once()
+	teams.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 3, Name: "backend"}, nil).Once()
+	store.On("SaveRule", ctx, "/internal/", []string{"u1"}, []int{3}).Return(nil).Once()
+	store.On("GetRules", ctx).Return([]*models.CodeOwnerRule{
+		{Pattern: "/internal/", UserIDs: []string{"u1"}, TeamIDs: []int64{3}, TeamNames: []string{"backend"}},
+	}, nil).Once()
+
+	svc := codeowners.NewCodeOwnersService(trm, store, users, teams)
+	rules, err := svc.SetRule(ctx, &api.CodeOwnerRuleSchema{
+		Pattern:   "/internal/",
+		UserIDs:   []string{"u1"},
+		TeamNames: []string{"backend"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.CodeOwnerRuleSchema{
+		{Pattern: "/internal/", UserIDs: []string{"u1"}, TeamNames: []string{"backend"}},
+	}, rules)
+}
+
+func TestCodeOwnersService_SetRule_UnknownTeam(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewRuleStore(t)
+	teams := mocks.NewOwnerTeamLookup(t)
+	trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+	teams.On("GetByTeamName", ctx, "ghost").Return(nil, repo.ErrNotFound).Once()
+
+	svc := codeowners.NewCodeOwnersService(trm, store, nil, teams)
+	_, err := svc.SetRule(ctx, &api.CodeOwnerRuleSchema{Pattern: "*", TeamNames: []string{"ghost"}})
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	store.AssertNotCalled(t, "SaveRule", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCodeOwnersService_SetRule_InvalidPattern(t *testing.T) {
+	svc := codeowners.NewCodeOwnersService(nil, nil, nil, nil)
+
+	_, err := svc.SetRule(context.Background(), &api.CodeOwnerRuleSchema{Pattern: "   "})
+
+	assert.ErrorIs(t, err, repo.ErrInvalidPattern)
+}
+
+func TestCodeOwnersService_Import(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewRuleStore(t)
+	users := mocks.NewOwnerUserLookup(t)
+	teams := mocks.NewOwnerTeamLookup(t)
+	trm := newDoMock(t, ctx, nil)
+
+	content := `
+# повторный шаблон: действует последняя строка
+/docs/   @u1
+*        @acme/backend dev@example.com
+/docs/   @u2 @ghost
+`
+
+	store.On("DeleteAllRules", ctx).Return(nil).Once()
+	teams.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 3, Name: "backend"}, nil).Once()
+	store.On("SaveRule", ctx, "*", []string{}, []int{3}).Return(nil).Once()
+	users.On("GetById", ctx, "u2").Return(&models.User{ID: "u2"}, nil).Once()
+	users.On("GetById", ctx, "ghost").Return(nil, repo.ErrNotFound).Once()
+	store.On("SaveRule", ctx, "/docs/", []string{"u2"}, []int{}).Return(nil).Once()
+	store.On("GetRules", ctx).Return([]*models.CodeOwnerRule{
+		{Pattern: "*", TeamIDs: []int64{3}, TeamNames: []string{"backend"}},
+		{Pattern: "/docs/", UserIDs: []string{"u2"}},
+	}, nil).Once()
+
+	svc := codeowners.NewCodeOwnersService(trm, store, users, teams)
+	resp, err := svc.Import(ctx, content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.CodeOwnerRuleSchema{
+		{Pattern: "*", UserIDs: []string{}, TeamNames: []string{"backend"}},
+		{Pattern: "/docs/", UserIDs: []string{"u2"}, TeamNames: []string{}},
+	}, resp.Rules)
+	assert.Equal(t, []string{"dev@example.com", "@ghost"}, resp.UnknownOwners)
+}
+
+func TestCodeOwnersService_DeleteRule_NotFound(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewRuleStore(t)
+	trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+	store.On("DeleteRule", ctx, "/nope/").Return(repo.ErrNotFound).Once()
+
+	svc := codeowners.NewCodeOwnersService(trm, store, nil, nil)
+	_, err := svc.DeleteRule(ctx, "/nope/")
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"avito-intership-2025/internal/lib/actor"
+	"avito-intership-2025/internal/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=EventRecorder
+type EventRecorder interface {
+	AddEvents(ctx context.Context, events []*models.ReviewEvent) error
+}
+
+// RecordEvents пишет события в журнал ревью от имени исполнителя из контекста.
+// Вызывается внутри транзакции изменения, чтобы журнал не расходился с данными.
+// Если журнал не подключен, ничего не делает.
+func RecordEvents(ctx context.Context, r EventRecorder, events ...*models.ReviewEvent) error {
+	if r == nil || len(events) == 0 {
+		return nil
+	}
+
+	name := actor.FromContext(ctx)
+	for _, e := range events {
+		e.Actor = name
+	}
+	return r.AddEvents(ctx, events)
+}
@@ -0,0 +1,233 @@
+package exclusion
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ExclusionStore
+type ExclusionStore interface {
+	ListPairs(ctx context.Context) ([]*models.ReviewExclusion, error)
+	SavePair(ctx context.Context, pair *models.ReviewExclusion) error
+	DeletePair(ctx context.Context, authorID, reviewerID string) error
+	ListGroups(ctx context.Context) ([]*models.ExclusionGroup, error)
+	SaveGroup(ctx context.Context, group *models.ExclusionGroup) error
+	DeleteGroup(ctx context.Context, name string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ExclusionUserLookup
+type ExclusionUserLookup interface {
+	GetById(ctx context.Context, userID string) (*models.User, error)
+}
+
+// ExclusionService ведет правила, по которым пользователи не назначаются ревьюерами
+// на PR друг друга: направленные пары и подгруппы.
+type ExclusionService struct {
+	store ExclusionStore
+	users ExclusionUserLookup
+	trm   service.TransactionManager
+}
+
+func NewExclusionService(
+	trm service.TransactionManager,
+	store ExclusionStore,
+	users ExclusionUserLookup,
+) *ExclusionService {
+	return &ExclusionService{
+		trm:   trm,
+		store: store,
+		users: users,
+	}
+}
+
+func (s *ExclusionService) List(ctx context.Context) (*api.ExclusionsResponse, error) {
+	var resp *api.ExclusionsResponse
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = s.list(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddPair запрещает назначать reviewer на PR автора. С mutual запрет добавляется
+// и в обратную сторону.
+func (s *ExclusionService) AddPair(
+	ctx context.Context,
+	input *api.ReviewExclusionSchema,
+	mutual bool,
+) (*api.ExclusionsResponse, error) {
+	if input.AuthorID == input.ReviewerID {
+		return nil, fmt.Errorf("%w: author and reviewer must differ", repo.ErrInvalidExclusion)
+	}
+
+	var resp *api.ExclusionsResponse
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.checkUsers(ctx, input.AuthorID, input.ReviewerID); err != nil {
+			return err
+		}
+
+		err := s.store.SavePair(ctx, &models.ReviewExclusion{
+			AuthorID:   input.AuthorID,
+			ReviewerID: input.ReviewerID,
+			Reason:     input.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		if mutual {
+			err = s.store.SavePair(ctx, &models.ReviewExclusion{
+				AuthorID:   input.ReviewerID,
+				ReviewerID: input.AuthorID,
+				Reason:     input.Reason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		resp, err = s.list(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeletePair снимает запрет. С mutual снимается и обратный запрет, если он был.
+func (s *ExclusionService) DeletePair(
+	ctx context.Context,
+	authorID, reviewerID string,
+	mutual bool,
+) (*api.ExclusionsResponse, error) {
+	var resp *api.ExclusionsResponse
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.store.DeletePair(ctx, authorID, reviewerID); err != nil {
+			return err
+		}
+
+		if mutual {
+			err := s.store.DeletePair(ctx, reviewerID, authorID)
+			if err != nil && !errors.Is(err, repo.ErrNotFound) {
+				return err
+			}
+		}
+
+		var err error
+		resp, err = s.list(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetGroup создает подгруппу или заменяет состав существующей с тем же именем.
+func (s *ExclusionService) SetGroup(ctx context.Context, input *api.ExclusionGroupSchema) (*api.ExclusionsResponse, error) {
+	userIDs := slices.Clone(input.UserIDs)
+	slices.Sort(userIDs)
+	userIDs = slices.Compact(userIDs)
+	if len(userIDs) < 2 {
+		return nil, fmt.Errorf("%w: group needs at least two distinct users", repo.ErrInvalidExclusion)
+	}
+
+	var resp *api.ExclusionsResponse
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.checkUsers(ctx, userIDs...); err != nil {
+			return err
+		}
+
+		err := s.store.SaveGroup(ctx, &models.ExclusionGroup{
+			Name:    input.Name,
+			Reason:  input.Reason,
+			UserIDs: userIDs,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp, err = s.list(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ExclusionService) DeleteGroup(ctx context.Context, name string) (*api.ExclusionsResponse, error) {
+	var resp *api.ExclusionsResponse
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.store.DeleteGroup(ctx, name); err != nil {
+			return err
+		}
+
+		var err error
+		resp, err = s.list(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ExclusionService) checkUsers(ctx context.Context, userIDs ...string) error {
+	for _, id := range userIDs {
+		if _, err := s.users.GetById(ctx, id); err != nil {
+			return fmt.Errorf("user %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (s *ExclusionService) list(ctx context.Context) (*api.ExclusionsResponse, error) {
+	pairs, err := s.store.ListPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.store.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.ExclusionsResponse{
+		Pairs:  make([]api.ReviewExclusionSchema, 0, len(pairs)),
+		Groups: make([]api.ExclusionGroupSchema, 0, len(groups)),
+	}
+	for _, p := range pairs {
+		resp.Pairs = append(resp.Pairs, api.ReviewExclusionSchema{
+			AuthorID:   p.AuthorID,
+			ReviewerID: p.ReviewerID,
+			Reason:     p.Reason,
+			CreatedAt:  p.CreatedAt,
+		})
+	}
+	for _, g := range groups {
+		resp.Groups = append(resp.Groups, api.ExclusionGroupSchema{
+			Name:      g.Name,
+			Reason:    g.Reason,
+			UserIDs:   g.UserIDs,
+			CreatedAt: g.CreatedAt,
+		})
+	}
+	return resp, nil
+}
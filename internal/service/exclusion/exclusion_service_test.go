@@ -0,0 +1,142 @@
+package exclusion_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/exclusion"
+	"avito-intership-2025/internal/service/mocks"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDoMock(t *testing.T, ctx context.Context, wantErr error) *mocks.MockManager {
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), wantErr)
+		}).Return(wantErr)
+
+	return trm
+}
+
+func TestExclusionService_AddPair_Mutual(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewExclusionStore(t)
+	users := mocks.NewExclusionUserLookup(t)
+	trm := newDoMock(t, ctx, nil)
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1"}, nil).Once()
+	users.On("GetById", ctx, "m1").Return(&models.User{ID: "m1"}, nil).Once()
+	store.On("SavePair", ctx, &models.ReviewExclusion{AuthorID: "u1", ReviewerID: "m1", Reason: "manager"}).
+		Return(nil).Once()
+	store.On("SavePair", ctx, &models.ReviewExclusion{AuthorID: "m1", ReviewerID: "u1", Reason: "manager"}).
+		Return(nil).Once()
+	store.On("ListPairs", ctx).Return([]*models.ReviewExclusion{
+		{AuthorID: "m1", ReviewerID: "u1", Reason: "manager"},
+		{AuthorID: "u1", ReviewerID: "m1", Reason: "manager"},
+	}, nil).Once()
+	store.On("ListGroups", ctx).Return([]*models.ExclusionGroup{}, nil).Once()
+
+	svc := exclusion.NewExclusionService(trm, store, users)
+	resp, err := svc.AddPair(ctx, &api.ReviewExclusionSchema{AuthorID: "u1", ReviewerID: "m1", Reason: "manager"}, true)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Pairs, 2)
+	assert.Empty(t, resp.Groups)
+}
+
+func TestExclusionService_AddPair_SameUser(t *testing.T) {
+	svc := exclusion.NewExclusionService(nil, nil, nil)
+
+	_, err := svc.AddPair(context.Background(), &api.ReviewExclusionSchema{AuthorID: "u1", ReviewerID: "u1"}, false)
+
+	assert.ErrorIs(t, err, repo.ErrInvalidExclusion)
+}
+
+func TestExclusionService_DeletePair_MutualIgnoresMissingReverse(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewExclusionStore(t)
+	trm := newDoMock(t, ctx, nil)
+
+	store.On("DeletePair", ctx, "u1", "m1").Return(nil).Once()
+	store.On("DeletePair", ctx, "m1", "u1").Return(repo.ErrNotFound).Once()
+	store.On("ListPairs", ctx).Return([]*models.ReviewExclusion{}, nil).Once()
+	store.On("ListGroups", ctx).Return([]*models.ExclusionGroup{}, nil).Once()
+
+	svc := exclusion.NewExclusionService(trm, store, nil)
+	resp, err := svc.DeletePair(ctx, "u1", "m1", true)
+
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Pairs)
+}
+
+func TestExclusionService_SetGroup_DeduplicatesUsers(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewExclusionStore(t)
+	users := mocks.NewExclusionUserLookup(t)
+	trm := newDoMock(t, ctx, nil)
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1"}, nil).Once()
+	users.On("GetById", ctx, "u2").Return(&models.User{ID: "u2"}, nil).Once()
+	store.On("SaveGroup", ctx, &models.ExclusionGroup{
+		Name:    "billing-v2",
+		Reason:  "co-authors",
+		UserIDs: pq.StringArray{"u1", "u2"},
+	}).Return(nil).Once()
+	store.On("ListPairs", ctx).Return([]*models.ReviewExclusion{}, nil).Once()
+	store.On("ListGroups", ctx).Return([]*models.ExclusionGroup{
+		{Name: "billing-v2", Reason: "co-authors", UserIDs: pq.StringArray{"u1", "u2"}},
+	}, nil).Once()
+
+	svc := exclusion.NewExclusionService(trm, store, users)
+	resp, err := svc.SetGroup(ctx, &api.ExclusionGroupSchema{
+		Name:    "billing-v2",
+		Reason:  "co-authors",
+		UserIDs: []string{"u2", "u1", "u2"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.ExclusionGroupSchema{
+		{Name: "billing-v2", Reason: "co-authors", UserIDs: []string{"u1", "u2"}},
+	}, resp.Groups)
+}
+
+func TestExclusionService_SetGroup_TooFewUsers(t *testing.T) {
+	svc := exclusion.NewExclusionService(nil, nil, nil)
+
+	_, err := svc.SetGroup(context.Background(), &api.ExclusionGroupSchema{
+		Name:    "solo",
+		UserIDs: []string{"u1", "u1"},
+	})
+
+	assert.ErrorIs(t, err, repo.ErrInvalidExclusion)
+}
+
+func TestExclusionService_SetGroup_UnknownUser(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewExclusionStore(t)
+	users := mocks.NewExclusionUserLookup(t)
+	trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+	users.On("GetById", ctx, "ghost").Return(nil, repo.ErrNotFound).Once()
+
+	svc := exclusion.NewExclusionService(trm, store, users)
+	_, err := svc.SetGroup(ctx, &api.ExclusionGroupSchema{Name: "g", UserIDs: []string{"u1", "ghost"}})
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	store.AssertNotCalled(t, "SaveGroup", mock.Anything, mock.Anything)
+}
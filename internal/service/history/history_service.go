@@ -0,0 +1,118 @@
+package history
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=EventStore
+type EventStore interface {
+	GetPrHistory(ctx context.Context, prID string) ([]*models.ReviewEvent, error)
+	GetUserHistory(ctx context.Context, userID string) ([]*models.ReviewEvent, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=HistoryPrLookup
+type HistoryPrLookup interface {
+	GetById(ctx context.Context, prID string) (*models.PullRequest, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=HistoryUserLookup
+type HistoryUserLookup interface {
+	GetById(ctx context.Context, userID string) (*models.User, error)
+}
+
+type HistoryService struct {
+	store EventStore
+	prs   HistoryPrLookup
+	users HistoryUserLookup
+	trm   service.TransactionManager
+}
+
+func NewHistoryService(
+	trm service.TransactionManager,
+	store EventStore,
+	prs HistoryPrLookup,
+	users HistoryUserLookup,
+) *HistoryService {
+	return &HistoryService{
+		trm:   trm,
+		store: store,
+		prs:   prs,
+		users: users,
+	}
+}
+
+// PrHistory возвращает журнал изменений ревью PR от старых событий к новым.
+func (s *HistoryService) PrHistory(ctx context.Context, prID string) (*api.PrHistoryResponse, error) {
+	resp := &api.PrHistoryResponse{
+		PullRequestID: prID,
+		Events:        []api.ReviewEventSchema{},
+	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.prs.GetById(ctx, prID); err != nil {
+			return err
+		}
+
+		events, err := s.store.GetPrHistory(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		resp.Events = toReviewEventSchemas(events)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UserHistory возвращает назначения и снятия пользователя с ревью и изменения
+// его активности от старых событий к новым.
+func (s *HistoryService) UserHistory(ctx context.Context, userID string) (*api.UserHistoryResponse, error) {
+	resp := &api.UserHistoryResponse{
+		UserID: userID,
+		Events: []api.ReviewEventSchema{},
+	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.users.GetById(ctx, userID); err != nil {
+			return err
+		}
+
+		events, err := s.store.GetUserHistory(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		resp.Events = toReviewEventSchemas(events)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func toReviewEventSchemas(events []*models.ReviewEvent) []api.ReviewEventSchema {
+	res := make([]api.ReviewEventSchema, 0, len(events))
+	for _, e := range events {
+		res = append(res, api.ReviewEventSchema{
+			ID:            e.ID,
+			Type:          e.Type,
+			PullRequestID: e.PullRequestID,
+			UserID:        e.UserID,
+			OldReviewerID: e.OldReviewerID,
+			NewReviewerID: e.NewReviewerID,
+			Status:        e.Status,
+			IsActive:      e.IsActive,
+			Reason:        e.Reason,
+			Actor:         e.Actor,
+			CreatedAt:     e.CreatedAt,
+		})
+	}
+	return res
+}
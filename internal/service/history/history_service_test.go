@@ -0,0 +1,108 @@
+package history_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/history"
+	"avito-intership-2025/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDoMock(t *testing.T, ctx context.Context, wantErr error) *mocks.MockManager {
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), wantErr)
+		}).Return(wantErr).Once()
+
+	return trm
+}
+
+func TestHistoryService_PrHistory(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewEventStore(t)
+	prs := mocks.NewHistoryPrLookup(t)
+	trm := newDoMock(t, ctx, nil)
+
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	prs.On("GetById", ctx, "pr1").Return(&models.PullRequest{ID: "pr1"}, nil).Once()
+	store.On("GetPrHistory", ctx, "pr1").Return([]*models.ReviewEvent{
+		{ID: 1, Type: models.EventAssigned, PullRequestID: "pr1", NewReviewerID: "u1", Actor: "admin", CreatedAt: createdAt},
+		{
+			ID:            2,
+			Type:          models.EventReassigned,
+			PullRequestID: "pr1",
+			OldReviewerID: "u1",
+			NewReviewerID: "u2",
+			Reason:        "reviewer deactivated",
+			Actor:         "system",
+			CreatedAt:     createdAt,
+		},
+	}, nil).Once()
+
+	svc := history.NewHistoryService(trm, store, prs, nil)
+	resp, err := svc.PrHistory(ctx, "pr1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pr1", resp.PullRequestID)
+	assert.Equal(t, []api.ReviewEventSchema{
+		{ID: 1, Type: models.EventAssigned, PullRequestID: "pr1", NewReviewerID: "u1", Actor: "admin", CreatedAt: createdAt},
+		{
+			ID:            2,
+			Type:          models.EventReassigned,
+			PullRequestID: "pr1",
+			OldReviewerID: "u1",
+			NewReviewerID: "u2",
+			Reason:        "reviewer deactivated",
+			Actor:         "system",
+			CreatedAt:     createdAt,
+		},
+	}, resp.Events)
+}
+
+func TestHistoryService_PrHistory_NotFound(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewEventStore(t)
+	prs := mocks.NewHistoryPrLookup(t)
+	trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+	prs.On("GetById", ctx, "ghost").Return(nil, repo.ErrNotFound).Once()
+
+	svc := history.NewHistoryService(trm, store, prs, nil)
+	_, err := svc.PrHistory(ctx, "ghost")
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	store.AssertNotCalled(t, "GetPrHistory", mock.Anything, mock.Anything)
+}
+
+func TestHistoryService_UserHistory_Empty(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewEventStore(t)
+	users := mocks.NewHistoryUserLookup(t)
+	trm := newDoMock(t, ctx, nil)
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1"}, nil).Once()
+	store.On("GetUserHistory", ctx, "u1").Return([]*models.ReviewEvent{}, nil).Once()
+
+	svc := history.NewHistoryService(trm, store, nil, users)
+	resp, err := svc.UserHistory(ctx, "u1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", resp.UserID)
+	assert.NotNil(t, resp.Events)
+	assert.Empty(t, resp.Events)
+}
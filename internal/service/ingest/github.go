@@ -0,0 +1,102 @@
+package ingest
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// githubPullRequestEvent - нужная сервису часть тела события pull_request из GitHub.
+type githubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// HandleGitHub применяет вебхук GitHub с типом event из заголовка X-GitHub-Event.
+// События pull_request превращаются в операции над PR: opened - создание (черновик для draft),
+// ready_for_review - Ready, closed - синхронизация мержа (SyncMerged) или закрытие, reopened - Reopen.
+// Остальные события и действия пропускаются.
+func (s *IngestService) HandleGitHub(ctx context.Context, event string, body []byte) (*api.IngestResponse, error) {
+	if event != "pull_request" {
+		return &api.IngestResponse{Action: ActionIgnored}, nil
+	}
+
+	var payload githubPullRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", repo.ErrInvalidPayload, err)
+	}
+	if payload.Repository.FullName == "" || payload.Number <= 0 {
+		return nil, fmt.Errorf("%w: repository and number are required", repo.ErrInvalidPayload)
+	}
+
+	// номера PR уникальны только внутри репозитория
+	prID := fmt.Sprintf("%s#%d", payload.Repository.FullName, payload.Number)
+
+	var (
+		pr     *api.PullRequestSchema
+		action string
+	)
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		switch payload.Action {
+		case "opened":
+			var authorID string
+			authorID, err = s.resolveUser(ctx, models.ProviderGitHub, payload.PullRequest.User.Login)
+			if err != nil {
+				return err
+			}
+
+			labels := make([]string, 0, len(payload.PullRequest.Labels))
+			for _, l := range payload.PullRequest.Labels {
+				labels = append(labels, l.Name)
+			}
+
+			action = ActionCreated
+			if payload.PullRequest.Draft {
+				pr, err = s.prs.CreateDraft(ctx, prID, payload.PullRequest.Title, authorID, labels)
+			} else {
+				pr, err = s.prs.Create(ctx, prID, payload.PullRequest.Title, authorID, nil, labels)
+			}
+		case "ready_for_review":
+			action = ActionReady
+			pr, err = s.prs.Ready(ctx, prID)
+		case "closed":
+			if payload.PullRequest.Merged {
+				action = ActionMerged
+				pr, err = s.prs.SyncMerged(ctx, prID)
+			} else {
+				action = ActionClosed
+				pr, err = s.prs.Close(ctx, prID)
+			}
+		case "reopened":
+			action = ActionReopened
+			pr, err = s.prs.Reopen(ctx, prID)
+		default:
+			action = ActionIgnored
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.IngestResponse{Action: action, PullRequest: pr}, nil
+}
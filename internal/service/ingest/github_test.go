@@ -0,0 +1,143 @@
+package ingest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/ingest"
+	"avito-intership-2025/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const githubPrID = "acme/review-service#42"
+
+// readFixture читает тело вебхука, записанное с реальной доставки GitHub или GitLab.
+func readFixture(t *testing.T, provider, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", provider, name))
+	require.NoError(t, err)
+	return body
+}
+
+func TestIngestService_HandleGitHub_Opened(t *testing.T) {
+	ctx := context.Background()
+
+	identities := mocks.NewIdentityStore(t)
+	prs := mocks.NewPrOperations(t)
+	trm := newDoMock(t, ctx, nil)
+
+	identities.On("GetUserID", ctx, models.ProviderGitHub, "octo-dev").Return("u1", nil).Once()
+
+	created := &api.PullRequestSchema{ID: githubPrID, AuthorID: "u1", Status: "OPEN"}
+	prs.On("Create", ctx, githubPrID, "Add reviewer load to stats", "u1", []string(nil), []string{"backend"}).
+		Return(created, nil).Once()
+
+	svc := ingest.NewIngestService(trm, identities, nil, prs)
+	resp, err := svc.HandleGitHub(ctx, "pull_request", readFixture(t, "github", "pull_request_opened.json"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, ingest.ActionCreated, resp.Action)
+	assert.Equal(t, created, resp.PullRequest)
+}
+
+func TestIngestService_HandleGitHub_OpenedDraft(t *testing.T) {
+	ctx := context.Background()
+
+	identities := mocks.NewIdentityStore(t)
+	prs := mocks.NewPrOperations(t)
+	trm := newDoMock(t, ctx, nil)
+
+	identities.On("GetUserID", ctx, models.ProviderGitHub, "octo-dev").Return("u1", nil).Once()
+	prs.On("CreateDraft", ctx, "acme/review-service#43", "WIP: rework selector", "u1", []string{}).
+		Return(&api.PullRequestSchema{ID: "acme/review-service#43", Status: "DRAFT"}, nil).Once()
+
+	svc := ingest.NewIngestService(trm, identities, nil, prs)
+	resp, err := svc.HandleGitHub(ctx, "pull_request", readFixture(t, "github", "pull_request_opened_draft.json"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, ingest.ActionCreated, resp.Action)
+}
+
+func TestIngestService_HandleGitHub_UnknownAuthor(t *testing.T) {
+	ctx := context.Background()
+
+	identities := mocks.NewIdentityStore(t)
+	prs := mocks.NewPrOperations(t)
+	trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+	identities.On("GetUserID", ctx, models.ProviderGitHub, "octo-dev").Return("", repo.ErrNotFound).Once()
+
+	svc := ingest.NewIngestService(trm, identities, nil, prs)
+	_, err := svc.HandleGitHub(ctx, "pull_request", readFixture(t, "github", "pull_request_opened.json"))
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+func TestIngestService_HandleGitHub_StatusChanges(t *testing.T) {
+	cases := []struct {
+		fixture string
+		method  string
+		action  string
+	}{
+		{fixture: "pull_request_closed_merged.json", method: "SyncMerged", action: ingest.ActionMerged},
+		{fixture: "pull_request_closed.json", method: "Close", action: ingest.ActionClosed},
+		{fixture: "pull_request_reopened.json", method: "Reopen", action: ingest.ActionReopened},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			ctx := context.Background()
+
+			prs := mocks.NewPrOperations(t)
+			trm := newDoMock(t, ctx, nil)
+
+			prs.On(tc.method, ctx, githubPrID).Return(&api.PullRequestSchema{ID: githubPrID}, nil).Once()
+
+			svc := ingest.NewIngestService(trm, nil, nil, prs)
+			resp, err := svc.HandleGitHub(ctx, "pull_request", readFixture(t, "github", tc.fixture))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.action, resp.Action)
+			assert.Equal(t, githubPrID, resp.PullRequest.ID)
+		})
+	}
+}
+
+func TestIngestService_HandleGitHub_Ignored(t *testing.T) {
+	ctx := context.Background()
+
+	prs := mocks.NewPrOperations(t)
+	trm := newDoMock(t, ctx, nil)
+
+	svc := ingest.NewIngestService(trm, nil, nil, prs)
+
+	// ping приходит при создании вебхука и до разбора тела не доходит
+	resp, err := svc.HandleGitHub(ctx, "ping", readFixture(t, "github", "ping.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, ingest.ActionIgnored, resp.Action)
+
+	resp, err = svc.HandleGitHub(ctx, "pull_request", readFixture(t, "github", "pull_request_synchronize.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, ingest.ActionIgnored, resp.Action)
+	assert.Nil(t, resp.PullRequest)
+	prs.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIngestService_HandleGitHub_InvalidPayload(t *testing.T) {
+	svc := ingest.NewIngestService(nil, nil, nil, nil)
+
+	_, err := svc.HandleGitHub(context.Background(), "pull_request", []byte(`{"action":`))
+	assert.ErrorIs(t, err, repo.ErrInvalidPayload)
+
+	_, err = svc.HandleGitHub(context.Background(), "pull_request", []byte(`{"action":"opened","number":1}`))
+	assert.ErrorIs(t, err, repo.ErrInvalidPayload)
+}
@@ -0,0 +1,117 @@
+package ingest
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// gitlabMergeRequestEvent - нужная сервису часть тела Merge Request Hook из GitLab.
+type gitlabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	// User - кто выполнил действие, для open это автор MR
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+		Draft  bool   `json:"draft"`
+	} `json:"object_attributes"`
+	Labels []struct {
+		Title string `json:"title"`
+	} `json:"labels"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// HandleGitLab применяет вебхук GitLab с типом event из заголовка X-Gitlab-Event.
+// Merge Request Hook превращается в операции над PR: open - создание (черновик для draft),
+// снятие draft - Ready, merge - синхронизация мержа (SyncMerged), close - закрытие, reopen - Reopen.
+// Остальные события и действия пропускаются.
+func (s *IngestService) HandleGitLab(ctx context.Context, event string, body []byte) (*api.IngestResponse, error) {
+	if event != "Merge Request Hook" {
+		return &api.IngestResponse{Action: ActionIgnored}, nil
+	}
+
+	var payload gitlabMergeRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", repo.ErrInvalidPayload, err)
+	}
+	if payload.ObjectKind != "merge_request" {
+		return nil, fmt.Errorf("%w: unexpected object_kind %q", repo.ErrInvalidPayload, payload.ObjectKind)
+	}
+	if payload.Project.PathWithNamespace == "" || payload.ObjectAttributes.IID <= 0 {
+		return nil, fmt.Errorf("%w: project and iid are required", repo.ErrInvalidPayload)
+	}
+
+	// iid уникален только внутри проекта, поэтому id PR включает путь проекта
+	prID := fmt.Sprintf("%s!%d", payload.Project.PathWithNamespace, payload.ObjectAttributes.IID)
+	mr := payload.ObjectAttributes
+
+	var (
+		pr     *api.PullRequestSchema
+		action string
+	)
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		switch mr.Action {
+		case "open":
+			var authorID string
+			authorID, err = s.resolveUser(ctx, models.ProviderGitLab, payload.User.Username)
+			if err != nil {
+				return err
+			}
+
+			labels := make([]string, 0, len(payload.Labels))
+			for _, l := range payload.Labels {
+				labels = append(labels, l.Title)
+			}
+
+			action = ActionCreated
+			if mr.Draft {
+				pr, err = s.prs.CreateDraft(ctx, prID, mr.Title, authorID, labels)
+			} else {
+				pr, err = s.prs.Create(ctx, prID, mr.Title, authorID, nil, labels)
+			}
+		case "update":
+			// готовность к ревью GitLab присылает как обновление со снятым draft
+			draft := payload.Changes.Draft
+			if draft == nil || !draft.Previous || draft.Current {
+				action = ActionIgnored
+				return nil
+			}
+			action = ActionReady
+			pr, err = s.prs.Ready(ctx, prID)
+		case "merge":
+			action = ActionMerged
+			pr, err = s.prs.SyncMerged(ctx, prID)
+		case "close":
+			action = ActionClosed
+			pr, err = s.prs.Close(ctx, prID)
+		case "reopen":
+			action = ActionReopened
+			pr, err = s.prs.Reopen(ctx, prID)
+		default:
+			action = ActionIgnored
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.IngestResponse{Action: action, PullRequest: pr}, nil
+}
@@ -0,0 +1,125 @@
+package ingest_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/ingest"
+	"avito-intership-2025/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	gitlabEvent = "Merge Request Hook"
+	gitlabPrID  = "platform/review-service!7"
+)
+
+func TestIngestService_HandleGitLab_Open(t *testing.T) {
+	ctx := context.Background()
+
+	identities := mocks.NewIdentityStore(t)
+	prs := mocks.NewPrOperations(t)
+	trm := newDoMock(t, ctx, nil)
+
+	identities.On("GetUserID", ctx, models.ProviderGitLab, "jane.doe").Return("u2", nil).Once()
+
+	created := &api.PullRequestSchema{ID: gitlabPrID, AuthorID: "u2", Status: "OPEN"}
+	prs.On("Create", ctx, gitlabPrID, "Add SLA worker metrics", "u2", []string(nil), []string{"backend", "sla"}).
+		Return(created, nil).Once()
+
+	svc := ingest.NewIngestService(trm, identities, nil, prs)
+	resp, err := svc.HandleGitLab(ctx, gitlabEvent, readFixture(t, "gitlab", "merge_request_open.json"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, ingest.ActionCreated, resp.Action)
+	assert.Equal(t, created, resp.PullRequest)
+}
+
+func TestIngestService_HandleGitLab_OpenDraft(t *testing.T) {
+	ctx := context.Background()
+
+	identities := mocks.NewIdentityStore(t)
+	prs := mocks.NewPrOperations(t)
+	trm := newDoMock(t, ctx, nil)
+
+	identities.On("GetUserID", ctx, models.ProviderGitLab, "jane.doe").Return("u2", nil).Once()
+	prs.On("CreateDraft", ctx, "platform/review-service!8", "Draft: split selector", "u2", []string{}).
+		Return(&api.PullRequestSchema{ID: "platform/review-service!8", Status: "DRAFT"}, nil).Once()
+
+	svc := ingest.NewIngestService(trm, identities, nil, prs)
+	resp, err := svc.HandleGitLab(ctx, gitlabEvent, readFixture(t, "gitlab", "merge_request_open_draft.json"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, ingest.ActionCreated, resp.Action)
+}
+
+func TestIngestService_HandleGitLab_StatusChanges(t *testing.T) {
+	cases := []struct {
+		fixture string
+		method  string
+		prID    string
+		action  string
+	}{
+		{fixture: "merge_request_ready.json", method: "Ready", prID: gitlabPrID, action: ingest.ActionReady},
+		{fixture: "merge_request_merge.json", method: "SyncMerged", prID: gitlabPrID, action: ingest.ActionMerged},
+		{fixture: "merge_request_close.json", method: "Close", prID: gitlabPrID, action: ingest.ActionClosed},
+		{fixture: "merge_request_reopen.json", method: "Reopen", prID: gitlabPrID, action: ingest.ActionReopened},
+		// тот же iid в другом проекте - другой PR
+		{
+			fixture: "merge_request_other_project.json",
+			method:  "SyncMerged",
+			prID:    "mobile/review-service!7",
+			action:  ingest.ActionMerged,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			ctx := context.Background()
+
+			prs := mocks.NewPrOperations(t)
+			trm := newDoMock(t, ctx, nil)
+
+			prs.On(tc.method, ctx, tc.prID).Return(&api.PullRequestSchema{ID: tc.prID}, nil).Once()
+
+			svc := ingest.NewIngestService(trm, nil, nil, prs)
+			resp, err := svc.HandleGitLab(ctx, gitlabEvent, readFixture(t, "gitlab", tc.fixture))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.action, resp.Action)
+			assert.Equal(t, tc.prID, resp.PullRequest.ID)
+		})
+	}
+}
+
+func TestIngestService_HandleGitLab_Ignored(t *testing.T) {
+	ctx := context.Background()
+
+	prs := mocks.NewPrOperations(t)
+	trm := newDoMock(t, ctx, nil)
+
+	svc := ingest.NewIngestService(trm, nil, nil, prs)
+
+	resp, err := svc.HandleGitLab(ctx, gitlabEvent, readFixture(t, "gitlab", "merge_request_update_title.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, ingest.ActionIgnored, resp.Action)
+	assert.Nil(t, resp.PullRequest)
+
+	resp, err = svc.HandleGitLab(ctx, "Push Hook", []byte(`{"object_kind":"push"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, ingest.ActionIgnored, resp.Action)
+}
+
+func TestIngestService_HandleGitLab_InvalidPayload(t *testing.T) {
+	svc := ingest.NewIngestService(nil, nil, nil, nil)
+
+	_, err := svc.HandleGitLab(context.Background(), gitlabEvent, []byte(`{"object_kind":"push"}`))
+	assert.ErrorIs(t, err, repo.ErrInvalidPayload)
+
+	_, err = svc.HandleGitLab(context.Background(), gitlabEvent, []byte(`{"object_kind":"merge_request"}`))
+	assert.ErrorIs(t, err, repo.ErrInvalidPayload)
+}
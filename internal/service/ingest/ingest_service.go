@@ -0,0 +1,163 @@
+package ingest
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Что сделано по входящему событию.
+const (
+	ActionCreated  = "created"
+	ActionMerged   = "merged"
+	ActionClosed   = "closed"
+	ActionReopened = "reopened"
+	ActionReady    = "ready"
+	ActionIgnored  = "ignored"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=IdentityStore
+type IdentityStore interface {
+	SaveIdentity(ctx context.Context, identity *models.ExternalIdentity) error
+	DeleteIdentity(ctx context.Context, provider, login string) error
+	ListIdentities(ctx context.Context, provider string) ([]*models.ExternalIdentity, error)
+	GetUserID(ctx context.Context, provider, login string) (string, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=IdentityUserLookup
+type IdentityUserLookup interface {
+	GetById(ctx context.Context, userID string) (*models.User, error)
+}
+
+// PrOperations - операции над PR, в которые превращаются события внешних систем.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=PrOperations
+type PrOperations interface {
+	Create(ctx context.Context, prID, prName, authorId string, files, labels []string) (*api.PullRequestSchema, error)
+	CreateDraft(ctx context.Context, prID, prName, authorId string, labels []string) (*api.PullRequestSchema, error)
+	SyncMerged(ctx context.Context, prID string) (*api.PullRequestSchema, error)
+	Ready(ctx context.Context, prID string) (*api.PullRequestSchema, error)
+	Close(ctx context.Context, prID string) (*api.PullRequestSchema, error)
+	Reopen(ctx context.Context, prID string) (*api.PullRequestSchema, error)
+}
+
+// IngestService принимает события о PR из внешних систем и ведет привязки внешних логинов
+// к пользователям сервиса.
+type IngestService struct {
+	identities IdentityStore
+	users      IdentityUserLookup
+	prs        PrOperations
+	trm        service.TransactionManager
+}
+
+func NewIngestService(
+	trm service.TransactionManager,
+	identities IdentityStore,
+	users IdentityUserLookup,
+	prs PrOperations,
+) *IngestService {
+	return &IngestService{
+		trm:        trm,
+		identities: identities,
+		users:      users,
+		prs:        prs,
+	}
+}
+
+func (s *IngestService) ListIdentities(ctx context.Context, provider string) ([]api.ExternalIdentitySchema, error) {
+	var resp []api.ExternalIdentitySchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = s.listIdentities(ctx, provider)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetIdentity привязывает внешний логин к существующему пользователю и возвращает
+// все привязки этой внешней системы.
+func (s *IngestService) SetIdentity(
+	ctx context.Context,
+	input *api.ExternalIdentitySchema,
+) ([]api.ExternalIdentitySchema, error) {
+	var resp []api.ExternalIdentitySchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.users.GetById(ctx, input.UserID); err != nil {
+			return fmt.Errorf("user %s: %w", input.UserID, err)
+		}
+
+		err := s.identities.SaveIdentity(ctx, &models.ExternalIdentity{
+			Provider: input.Provider,
+			Login:    normalizeLogin(input.Login),
+			UserID:   input.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp, err = s.listIdentities(ctx, input.Provider)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteIdentity удаляет привязку и возвращает оставшиеся привязки этой внешней системы.
+func (s *IngestService) DeleteIdentity(ctx context.Context, provider, login string) ([]api.ExternalIdentitySchema, error) {
+	var resp []api.ExternalIdentitySchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.identities.DeleteIdentity(ctx, provider, normalizeLogin(login)); err != nil {
+			return err
+		}
+
+		var err error
+		resp, err = s.listIdentities(ctx, provider)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *IngestService) listIdentities(ctx context.Context, provider string) ([]api.ExternalIdentitySchema, error) {
+	identities, err := s.identities.ListIdentities(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]api.ExternalIdentitySchema, 0, len(identities))
+	for _, i := range identities {
+		resp = append(resp, api.ExternalIdentitySchema{
+			Provider:  i.Provider,
+			Login:     i.Login,
+			UserID:    i.UserID,
+			CreatedAt: i.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+// resolveUser находит пользователя сервиса по логину во внешней системе.
+func (s *IngestService) resolveUser(ctx context.Context, provider, login string) (string, error) {
+	userID, err := s.identities.GetUserID(ctx, provider, normalizeLogin(login))
+	if err != nil {
+		return "", fmt.Errorf("%s login %s: %w", provider, login, err)
+	}
+	return userID, nil
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
@@ -0,0 +1,79 @@
+package ingest_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/ingest"
+	"avito-intership-2025/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDoMock(t *testing.T, ctx context.Context, wantErr error) *mocks.MockManager {
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), wantErr)
+		}).Return(wantErr)
+
+	return trm
+}
+
+func TestIngestService_SetIdentity_NormalizesLogin(t *testing.T) {
+	ctx := context.Background()
+
+	identities := mocks.NewIdentityStore(t)
+	users := mocks.NewIdentityUserLookup(t)
+	trm := newDoMock(t, ctx, nil)
+
+	users.On("GetById", ctx, "u1").Return(&models.User{ID: "u1"}, nil).Once()
+	identities.On("SaveIdentity", ctx, &models.ExternalIdentity{
+		Provider: models.ProviderGitHub,
+		Login:    "octo-dev",
+		UserID:   "u1",
+	}).Return(nil).Once()
+	identities.On("ListIdentities", ctx, models.ProviderGitHub).Return([]*models.ExternalIdentity{
+		{Provider: models.ProviderGitHub, Login: "octo-dev", UserID: "u1"},
+	}, nil).Once()
+
+	svc := ingest.NewIngestService(trm, identities, users, nil)
+	resp, err := svc.SetIdentity(ctx, &api.ExternalIdentitySchema{
+		Provider: models.ProviderGitHub,
+		Login:    " Octo-Dev ",
+		UserID:   "u1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.ExternalIdentitySchema{
+		{Provider: models.ProviderGitHub, Login: "octo-dev", UserID: "u1"},
+	}, resp)
+}
+
+func TestIngestService_SetIdentity_UserNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	identities := mocks.NewIdentityStore(t)
+	users := mocks.NewIdentityUserLookup(t)
+	trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+	users.On("GetById", ctx, "ghost").Return(nil, repo.ErrNotFound).Once()
+
+	svc := ingest.NewIngestService(trm, identities, users, nil)
+	_, err := svc.SetIdentity(ctx, &api.ExternalIdentitySchema{
+		Provider: models.ProviderGitHub,
+		Login:    "ghost",
+		UserID:   "ghost",
+	})
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	identities.AssertNotCalled(t, "SaveIdentity", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AbsenceStore is an autogenerated mock type for the AbsenceStore type
+type AbsenceStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *AbsenceStore) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetStartedForReassign provides a mock function with given fields: ctx
+func (_m *AbsenceStore) GetStartedForReassign(ctx context.Context) ([]*models.Absence, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStartedForReassign")
+	}
+
+	var r0 []*models.Absence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Absence, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Absence); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Absence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *AbsenceStore) List(ctx context.Context, userID string) ([]*models.Absence, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Absence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Absence, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Absence); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Absence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkReviewsReassigned provides a mock function with given fields: ctx, id
+func (_m *AbsenceStore) MarkReviewsReassigned(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReviewsReassigned")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: ctx, absence
+func (_m *AbsenceStore) Save(ctx context.Context, absence *models.Absence) (*models.Absence, error) {
+	ret := _m.Called(ctx, absence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *models.Absence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Absence) (*models.Absence, error)); ok {
+		return rf(ctx, absence)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Absence) *models.Absence); ok {
+		r0 = rf(ctx, absence)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Absence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Absence) error); ok {
+		r1 = rf(ctx, absence)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAbsenceStore creates a new instance of AbsenceStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAbsenceStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AbsenceStore {
+	mock := &AbsenceStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AbsentUserLookup is an autogenerated mock type for the AbsentUserLookup type
+type AbsentUserLookup struct {
+	mock.Mock
+}
+
+// GetById provides a mock function with given fields: ctx, userID
+func (_m *AbsentUserLookup) GetById(ctx context.Context, userID string) (*models.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAbsentUserLookup creates a new instance of AbsentUserLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAbsentUserLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AbsentUserLookup {
+	mock := &AbsentUserLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
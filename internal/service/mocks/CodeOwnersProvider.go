@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CodeOwnersProvider is an autogenerated mock type for the CodeOwnersProvider type
+type CodeOwnersProvider struct {
+	mock.Mock
+}
+
+// GetRules provides a mock function with given fields: ctx
+func (_m *CodeOwnersProvider) GetRules(ctx context.Context) ([]*models.CodeOwnerRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRules")
+	}
+
+	var r0 []*models.CodeOwnerRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.CodeOwnerRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.CodeOwnerRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CodeOwnerRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCodeOwnersProvider creates a new instance of CodeOwnersProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCodeOwnersProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CodeOwnersProvider {
+	mock := &CodeOwnersProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeliveryQueue is an autogenerated mock type for the DeliveryQueue type
+type DeliveryQueue struct {
+	mock.Mock
+}
+
+// ClaimDueDeliveries provides a mock function with given fields: ctx, limit, lease
+func (_m *DeliveryQueue) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueDeliveries")
+	}
+
+	var r0 []*models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]*models.WebhookDelivery, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []*models.WebhookDelivery); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkDelivered provides a mock function with given fields: ctx, id, attempts
+func (_m *DeliveryQueue) MarkDelivered(ctx context.Context, id int64, attempts int) error {
+	ret := _m.Called(ctx, id, attempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDelivered")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) error); ok {
+		r0 = rf(ctx, id, attempts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, attempts, nextAttemptAt, lastError
+func (_m *DeliveryQueue) MarkFailed(ctx context.Context, id int64, attempts int, nextAttemptAt *time.Time, lastError string) error {
+	ret := _m.Called(ctx, id, attempts, nextAttemptAt, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, *time.Time, string) error); ok {
+		r0 = rf(ctx, id, attempts, nextAttemptAt, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDeliveryQueue creates a new instance of DeliveryQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeliveryQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeliveryQueue {
+	mock := &DeliveryQueue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
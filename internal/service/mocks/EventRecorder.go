@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventRecorder is an autogenerated mock type for the EventRecorder type
+type EventRecorder struct {
+	mock.Mock
+}
+
+// AddEvents provides a mock function with given fields: ctx, events
+func (_m *EventRecorder) AddEvents(ctx context.Context, events []*models.ReviewEvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.ReviewEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEventRecorder creates a new instance of EventRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventRecorder {
+	mock := &EventRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventStore is an autogenerated mock type for the EventStore type
+type EventStore struct {
+	mock.Mock
+}
+
+// GetPrHistory provides a mock function with given fields: ctx, prID
+func (_m *EventStore) GetPrHistory(ctx context.Context, prID string) ([]*models.ReviewEvent, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPrHistory")
+	}
+
+	var r0 []*models.ReviewEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.ReviewEvent, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.ReviewEvent); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ReviewEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserHistory provides a mock function with given fields: ctx, userID
+func (_m *EventStore) GetUserHistory(ctx context.Context, userID string) ([]*models.ReviewEvent, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserHistory")
+	}
+
+	var r0 []*models.ReviewEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.ReviewEvent, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.ReviewEvent); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ReviewEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventStore creates a new instance of EventStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventStore {
+	mock := &EventStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
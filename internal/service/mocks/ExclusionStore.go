@@ -0,0 +1,162 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "avito-intership-2025/internal/models"
+)
+
+// ExclusionStore is an autogenerated mock type for the ExclusionStore type
+type ExclusionStore struct {
+	mock.Mock
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, name
+func (_m *ExclusionStore) DeleteGroup(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePair provides a mock function with given fields: ctx, authorID, reviewerID
+func (_m *ExclusionStore) DeletePair(ctx context.Context, authorID string, reviewerID string) error {
+	ret := _m.Called(ctx, authorID, reviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePair")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, authorID, reviewerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListGroups provides a mock function with given fields: ctx
+func (_m *ExclusionStore) ListGroups(ctx context.Context) ([]*models.ExclusionGroup, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroups")
+	}
+
+	var r0 []*models.ExclusionGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.ExclusionGroup, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.ExclusionGroup); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExclusionGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPairs provides a mock function with given fields: ctx
+func (_m *ExclusionStore) ListPairs(ctx context.Context) ([]*models.ReviewExclusion, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPairs")
+	}
+
+	var r0 []*models.ReviewExclusion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.ReviewExclusion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.ReviewExclusion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ReviewExclusion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveGroup provides a mock function with given fields: ctx, group
+func (_m *ExclusionStore) SaveGroup(ctx context.Context, group *models.ExclusionGroup) error {
+	ret := _m.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ExclusionGroup) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavePair provides a mock function with given fields: ctx, pair
+func (_m *ExclusionStore) SavePair(ctx context.Context, pair *models.ReviewExclusion) error {
+	ret := _m.Called(ctx, pair)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePair")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ReviewExclusion) error); ok {
+		r0 = rf(ctx, pair)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExclusionStore creates a new instance of ExclusionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExclusionStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExclusionStore {
+	mock := &ExclusionStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HistoryPrLookup is an autogenerated mock type for the HistoryPrLookup type
+type HistoryPrLookup struct {
+	mock.Mock
+}
+
+// GetById provides a mock function with given fields: ctx, prID
+func (_m *HistoryPrLookup) GetById(ctx context.Context, prID string) (*models.PullRequest, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 *models.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PullRequest, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PullRequest); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewHistoryPrLookup creates a new instance of HistoryPrLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHistoryPrLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HistoryPrLookup {
+	mock := &HistoryPrLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
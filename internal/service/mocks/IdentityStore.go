@@ -0,0 +1,124 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "avito-intership-2025/internal/models"
+)
+
+// IdentityStore is an autogenerated mock type for the IdentityStore type
+type IdentityStore struct {
+	mock.Mock
+}
+
+// DeleteIdentity provides a mock function with given fields: ctx, provider, login
+func (_m *IdentityStore) DeleteIdentity(ctx context.Context, provider string, login string) error {
+	ret := _m.Called(ctx, provider, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, provider, login)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUserID provides a mock function with given fields: ctx, provider, login
+func (_m *IdentityStore) GetUserID(ctx context.Context, provider string, login string) (string, error) {
+	ret := _m.Called(ctx, provider, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, provider, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, provider, login)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIdentities provides a mock function with given fields: ctx, provider
+func (_m *IdentityStore) ListIdentities(ctx context.Context, provider string) ([]*models.ExternalIdentity, error) {
+	ret := _m.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIdentities")
+	}
+
+	var r0 []*models.ExternalIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.ExternalIdentity, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.ExternalIdentity); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExternalIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveIdentity provides a mock function with given fields: ctx, identity
+func (_m *IdentityStore) SaveIdentity(ctx context.Context, identity *models.ExternalIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ExternalIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdentityStore creates a new instance of IdentityStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdentityStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdentityStore {
+	mock := &IdentityStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OutboxWriter is an autogenerated mock type for the OutboxWriter type
+type OutboxWriter struct {
+	mock.Mock
+}
+
+// AddOutboxEvent provides a mock function with given fields: ctx, eventType, payload
+func (_m *OutboxWriter) AddOutboxEvent(ctx context.Context, eventType string, payload []byte) error {
+	ret := _m.Called(ctx, eventType, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, eventType, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOutboxWriter creates a new instance of OutboxWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxWriter {
+	mock := &OutboxWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
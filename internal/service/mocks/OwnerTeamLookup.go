@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OwnerTeamLookup is an autogenerated mock type for the OwnerTeamLookup type
+type OwnerTeamLookup struct {
+	mock.Mock
+}
+
+// GetByTeamName provides a mock function with given fields: ctx, teamName
+func (_m *OwnerTeamLookup) GetByTeamName(ctx context.Context, teamName string) (*models.Team, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTeamName")
+	}
+
+	var r0 *models.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Team, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Team); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOwnerTeamLookup creates a new instance of OwnerTeamLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOwnerTeamLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OwnerTeamLookup {
+	mock := &OwnerTeamLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
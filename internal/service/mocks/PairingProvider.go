@@ -0,0 +1,61 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PairingProvider is an autogenerated mock type for the PairingProvider type
+type PairingProvider struct {
+	mock.Mock
+}
+
+// GetTeamPairings provides a mock function with given fields: ctx, teamID, since
+func (_m *PairingProvider) GetTeamPairings(ctx context.Context, teamID int, since *time.Time) ([]*models.ReviewPairing, error) {
+	ret := _m.Called(ctx, teamID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamPairings")
+	}
+
+	var r0 []*models.ReviewPairing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time) ([]*models.ReviewPairing, error)); ok {
+		return rf(ctx, teamID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time) []*models.ReviewPairing); ok {
+		r0 = rf(ctx, teamID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ReviewPairing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *time.Time) error); ok {
+		r1 = rf(ctx, teamID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPairingProvider creates a new instance of PairingProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPairingProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PairingProvider {
+	mock := &PairingProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,9 +14,9 @@ type PrController struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, pr
-func (_m *PrController) Create(ctx context.Context, pr *models.PullRequest) (string, error) {
-	ret := _m.Called(ctx, pr)
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *PrController) Create(ctx context.Context, _a1 *models.PullRequest) (string, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -25,16 +25,16 @@ func (_m *PrController) Create(ctx context.Context, pr *models.PullRequest) (str
 	var r0 string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *models.PullRequest) (string, error)); ok {
-		return rf(ctx, pr)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *models.PullRequest) string); ok {
-		r0 = rf(ctx, pr)
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *models.PullRequest) error); ok {
-		r1 = rf(ctx, pr)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,6 +72,73 @@ func (_m *PrController) GetById(ctx context.Context, prID string) (*models.PullR
 	return r0, r1
 }
 
+// GetUnderstaffed provides a mock function with given fields: ctx
+func (_m *PrController) GetUnderstaffed(ctx context.Context) ([]*models.PullRequest, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnderstaffed")
+	}
+
+	var r0 []*models.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.PullRequest, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.PullRequest); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *PrController) List(ctx context.Context, filter *models.PrListFilter) ([]*models.PullRequestListItem, string, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.PullRequestListItem
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PrListFilter) ([]*models.PullRequestListItem, string, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PrListFilter) []*models.PullRequestListItem); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PullRequestListItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PrListFilter) string); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.PrListFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // MarkAsMerged provides a mock function with given fields: ctx, prID
 func (_m *PrController) MarkAsMerged(ctx context.Context, prID string) error {
 	ret := _m.Called(ctx, prID)
@@ -90,6 +157,90 @@ func (_m *PrController) MarkAsMerged(ctx context.Context, prID string) error {
 	return r0
 }
 
+// Search provides a mock function with given fields: ctx, filter
+func (_m *PrController) Search(ctx context.Context, filter *models.PrSearchFilter) ([]*models.PullRequestSearchItem, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*models.PullRequestSearchItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PrSearchFilter) ([]*models.PullRequestSearchItem, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PrSearchFilter) []*models.PullRequestSearchItem); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PullRequestSearchItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PrSearchFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetLabels provides a mock function with given fields: ctx, prID, labels
+func (_m *PrController) SetLabels(ctx context.Context, prID string, labels []string) error {
+	ret := _m.Called(ctx, prID, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, prID, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetNeedMoreReviewers provides a mock function with given fields: ctx, prID, need
+func (_m *PrController) SetNeedMoreReviewers(ctx context.Context, prID string, need bool) error {
+	ret := _m.Called(ctx, prID, need)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNeedMoreReviewers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, prID, need)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetStatus provides a mock function with given fields: ctx, prID, status
+func (_m *PrController) SetStatus(ctx context.Context, prID string, status string) error {
+	ret := _m.Called(ctx, prID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, prID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPrController creates a new instance of PrController. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPrController(t interface {
@@ -0,0 +1,209 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	api "avito-intership-2025/internal/http/api"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PrOperations is an autogenerated mock type for the PrOperations type
+type PrOperations struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields: ctx, prID
+func (_m *PrOperations) Close(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, prID, prName, authorId, files, labels
+func (_m *PrOperations) Create(ctx context.Context, prID string, prName string, authorId string, files []string, labels []string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID, prName, authorId, files, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, []string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID, prName, authorId, files, labels)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, []string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID, prName, authorId, files, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string, []string) error); ok {
+		r1 = rf(ctx, prID, prName, authorId, files, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDraft provides a mock function with given fields: ctx, prID, prName, authorId, labels
+func (_m *PrOperations) CreateDraft(ctx context.Context, prID string, prName string, authorId string, labels []string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID, prName, authorId, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDraft")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID, prName, authorId, labels)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID, prName, authorId, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string) error); ok {
+		r1 = rf(ctx, prID, prName, authorId, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ready provides a mock function with given fields: ctx, prID
+func (_m *PrOperations) Ready(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reopen provides a mock function with given fields: ctx, prID
+func (_m *PrOperations) Reopen(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncMerged provides a mock function with given fields: ctx, prID
+func (_m *PrOperations) SyncMerged(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncMerged")
+	}
+
+	var r0 *api.PullRequestSchema
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.PullRequestSchema, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.PullRequestSchema); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PullRequestSchema)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPrOperations creates a new instance of PrOperations. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPrOperations(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PrOperations {
+	mock := &PrOperations{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,68 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	api "avito-intership-2025/internal/http/api"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewReassigner is an autogenerated mock type for the ReviewReassigner type
+type ReviewReassigner struct {
+	mock.Mock
+}
+
+// ReassignOpenReviews provides a mock function with given fields: ctx, teamID, userIDs, reason
+func (_m *ReviewReassigner) ReassignOpenReviews(ctx context.Context, teamID int, userIDs []string, reason string) ([]api.ReviewReplacement, []string, error) {
+	ret := _m.Called(ctx, teamID, userIDs, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignOpenReviews")
+	}
+
+	var r0 []api.ReviewReplacement
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string, string) ([]api.ReviewReplacement, []string, error)); ok {
+		return rf(ctx, teamID, userIDs, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string, string) []api.ReviewReplacement); ok {
+		r0 = rf(ctx, teamID, userIDs, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.ReviewReplacement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []string, string) []string); ok {
+		r1 = rf(ctx, teamID, userIDs, reason)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, []string, string) error); ok {
+		r2 = rf(ctx, teamID, userIDs, reason)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewReviewReassigner creates a new instance of ReviewReassigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewReassigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewReassigner {
+	mock := &ReviewReassigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
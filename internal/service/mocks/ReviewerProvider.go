@@ -3,9 +3,12 @@
 package mocks
 
 import (
+	models "avito-intership-2025/internal/models"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ReviewerProvider is an autogenerated mock type for the ReviewerProvider type
@@ -13,6 +16,24 @@ type ReviewerProvider struct {
 	mock.Mock
 }
 
+// AddReview provides a mock function with given fields: ctx, review
+func (_m *ReviewerProvider) AddReview(ctx context.Context, review *models.Review) error {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AssignReviewer provides a mock function with given fields: ctx, prID, userID
 func (_m *ReviewerProvider) AssignReviewer(ctx context.Context, prID string, userID string) error {
 	ret := _m.Called(ctx, prID, userID)
@@ -49,6 +70,186 @@ func (_m *ReviewerProvider) DeleteReviewer(ctx context.Context, prID string, use
 	return r0
 }
 
+// GetLatestReviews provides a mock function with given fields: ctx, prID
+func (_m *ReviewerProvider) GetLatestReviews(ctx context.Context, prID string) ([]*models.Review, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestReviews")
+	}
+
+	var r0 []*models.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Review, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Review); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenReviewsByUsers provides a mock function with given fields: ctx, userIDs
+func (_m *ReviewerProvider) GetOpenReviewsByUsers(ctx context.Context, userIDs []string) ([]*models.ReviewAssignment, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenReviewsByUsers")
+	}
+
+	var r0 []*models.ReviewAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*models.ReviewAssignment, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*models.ReviewAssignment); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ReviewAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenReviewsCount provides a mock function with given fields: ctx, userIDs
+func (_m *ReviewerProvider) GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenReviewsCount")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPairingsInLastPRs provides a mock function with given fields: ctx, authorID, reviewerIDs, lastPRs
+func (_m *ReviewerProvider) GetPairingsInLastPRs(ctx context.Context, authorID string, reviewerIDs []string, lastPRs int) (map[string]int, error) {
+	ret := _m.Called(ctx, authorID, reviewerIDs, lastPRs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPairingsInLastPRs")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, int) (map[string]int, error)); ok {
+		return rf(ctx, authorID, reviewerIDs, lastPRs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, int) map[string]int); ok {
+		r0 = rf(ctx, authorID, reviewerIDs, lastPRs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, int) error); ok {
+		r1 = rf(ctx, authorID, reviewerIDs, lastPRs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPairingsSince provides a mock function with given fields: ctx, authorID, reviewerIDs, since
+func (_m *ReviewerProvider) GetPairingsSince(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	ret := _m.Called(ctx, authorID, reviewerIDs, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPairingsSince")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, time.Time) (map[string]int, error)); ok {
+		return rf(ctx, authorID, reviewerIDs, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, time.Time) map[string]int); ok {
+		r0 = rf(ctx, authorID, reviewerIDs, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, time.Time) error); ok {
+		r1 = rf(ctx, authorID, reviewerIDs, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingReviews provides a mock function with given fields: ctx, olderThan
+func (_m *ReviewerProvider) GetPendingReviews(ctx context.Context, olderThan time.Duration) ([]*models.PendingReview, error) {
+	ret := _m.Called(ctx, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReviews")
+	}
+
+	var r0 []*models.PendingReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) ([]*models.PendingReview, error)); ok {
+		return rf(ctx, olderThan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) []*models.PendingReview); ok {
+		r0 = rf(ctx, olderThan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PendingReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, olderThan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPrReviewers provides a mock function with given fields: ctx, prID
 func (_m *ReviewerProvider) GetPrReviewers(ctx context.Context, prID string) ([]string, error) {
 	ret := _m.Called(ctx, prID)
@@ -97,6 +298,24 @@ func (_m *ReviewerProvider) ReassignReviewer(ctx context.Context, prID string, o
 	return r0
 }
 
+// SetAssignmentReason provides a mock function with given fields: ctx, prID, userID, reason
+func (_m *ReviewerProvider) SetAssignmentReason(ctx context.Context, prID string, userID string, reason string) error {
+	ret := _m.Called(ctx, prID, userID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAssignmentReason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, prID, userID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewReviewerProvider creates a new instance of ReviewerProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewReviewerProvider(t interface {
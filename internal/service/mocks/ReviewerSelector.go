@@ -0,0 +1,58 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewerSelector is an autogenerated mock type for the ReviewerSelector type
+type ReviewerSelector struct {
+	mock.Mock
+}
+
+// Select provides a mock function with given fields: ctx, teamID, candidates, count
+func (_m *ReviewerSelector) Select(ctx context.Context, teamID int, candidates []string, count int) ([]string, error) {
+	ret := _m.Called(ctx, teamID, candidates, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Select")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string, int) ([]string, error)); ok {
+		return rf(ctx, teamID, candidates, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string, int) []string); ok {
+		r0 = rf(ctx, teamID, candidates, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []string, int) error); ok {
+		r1 = rf(ctx, teamID, candidates, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReviewerSelector creates a new instance of ReviewerSelector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewerSelector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewerSelector {
+	mock := &ReviewerSelector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
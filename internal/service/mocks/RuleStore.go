@@ -0,0 +1,113 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RuleStore is an autogenerated mock type for the RuleStore type
+type RuleStore struct {
+	mock.Mock
+}
+
+// DeleteAllRules provides a mock function with given fields: ctx
+func (_m *RuleStore) DeleteAllRules(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRule provides a mock function with given fields: ctx, pattern
+func (_m *RuleStore) DeleteRule(ctx context.Context, pattern string) error {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRules provides a mock function with given fields: ctx
+func (_m *RuleStore) GetRules(ctx context.Context) ([]*models.CodeOwnerRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRules")
+	}
+
+	var r0 []*models.CodeOwnerRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.CodeOwnerRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.CodeOwnerRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CodeOwnerRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveRule provides a mock function with given fields: ctx, pattern, userIDs, teamIDs
+func (_m *RuleStore) SaveRule(ctx context.Context, pattern string, userIDs []string, teamIDs []int) error {
+	ret := _m.Called(ctx, pattern, userIDs, teamIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, []int) error); ok {
+		r0 = rf(ctx, pattern, userIDs, teamIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRuleStore creates a new instance of RuleStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRuleStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RuleStore {
+	mock := &RuleStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
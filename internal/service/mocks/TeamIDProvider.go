@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	models "avito-intership-2025/internal/models"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,6 +14,36 @@ type TeamIDProvider struct {
 	mock.Mock
 }
 
+// GetSettings provides a mock function with given fields: ctx, teamID
+func (_m *TeamIDProvider) GetSettings(ctx context.Context, teamID int) (*models.TeamSettings, error) {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *models.TeamSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.TeamSettings, error)); ok {
+		return rf(ctx, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.TeamSettings); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TeamSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTeamNameByID provides a mock function with given fields: ctx, teamID
 func (_m *TeamIDProvider) GetTeamNameByID(ctx context.Context, teamID int) (string, error) {
 	ret := _m.Called(ctx, teamID)
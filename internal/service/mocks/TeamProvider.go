@@ -72,6 +72,54 @@ func (_m *TeamProvider) GetByTeamName(ctx context.Context, teamName string) (*mo
 	return r0, r1
 }
 
+// GetSettings provides a mock function with given fields: ctx, teamID
+func (_m *TeamProvider) GetSettings(ctx context.Context, teamID int) (*models.TeamSettings, error) {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *models.TeamSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.TeamSettings, error)); ok {
+		return rf(ctx, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.TeamSettings); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TeamSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveSettings provides a mock function with given fields: ctx, settings
+func (_m *TeamProvider) SaveSettings(ctx context.Context, settings *models.TeamSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TeamSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTeamProvider creates a new instance of TeamProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTeamProvider(t interface {
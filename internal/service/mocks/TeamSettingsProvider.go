@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership-2025/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TeamSettingsProvider is an autogenerated mock type for the TeamSettingsProvider type
+type TeamSettingsProvider struct {
+	mock.Mock
+}
+
+// GetSettings provides a mock function with given fields: ctx, teamID
+func (_m *TeamSettingsProvider) GetSettings(ctx context.Context, teamID int) (*models.TeamSettings, error) {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *models.TeamSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.TeamSettings, error)); ok {
+		return rf(ctx, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.TeamSettings); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TeamSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTeamSettingsProvider creates a new instance of TeamSettingsProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTeamSettingsProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TeamSettingsProvider {
+	mock := &TeamSettingsProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
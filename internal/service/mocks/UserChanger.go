@@ -62,6 +62,60 @@ func (_m *UserChanger) SetIsActive(ctx context.Context, userID string, isActive
 	return r0
 }
 
+// SetMaxOpenReviews provides a mock function with given fields: ctx, userID, limit
+func (_m *UserChanger) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) error {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaxOpenReviews")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) error); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSeniority provides a mock function with given fields: ctx, userID, seniority
+func (_m *UserChanger) SetSeniority(ctx context.Context, userID string, seniority string) error {
+	ret := _m.Called(ctx, userID, seniority)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSeniority")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, seniority)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSkills provides a mock function with given fields: ctx, userID, skills
+func (_m *UserChanger) SetSkills(ctx context.Context, userID string, skills []string) error {
+	ret := _m.Called(ctx, userID, skills)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSkills")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, userID, skills)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserChanger creates a new instance of UserChanger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserChanger(t interface {
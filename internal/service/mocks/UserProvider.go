@@ -14,6 +14,24 @@ type UserProvider struct {
 	mock.Mock
 }
 
+// DeactivateUsers provides a mock function with given fields: ctx, userIDs
+func (_m *UserProvider) DeactivateUsers(ctx context.Context, userIDs []string) error {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetUsersInTeam provides a mock function with given fields: ctx, teamName
 func (_m *UserProvider) GetUsersInTeam(ctx context.Context, teamName string) ([]*models.User, error) {
 	ret := _m.Called(ctx, teamName)
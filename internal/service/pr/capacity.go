@@ -0,0 +1,70 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/models"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=CapacityProvider
+type CapacityProvider interface {
+	GetMaxOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
+}
+
+// reviewLimits возвращает лимиты открытых ревью кандидатов: личный, а без него - лимит команды.
+// Кандидатов без лимита в ответе нет.
+func (s *PullRequestService) reviewLimits(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	userIDs []string,
+) (map[string]int, error) {
+	limits := make(map[string]int, len(userIDs))
+	if settings.MaxOpenReviews != nil {
+		for _, id := range userIDs {
+			limits[id] = *settings.MaxOpenReviews
+		}
+	}
+
+	if s.capacities == nil {
+		return limits, nil
+	}
+
+	own, err := s.capacities.GetMaxOpenReviews(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id, limit := range own {
+		limits[id] = limit
+	}
+
+	return limits, nil
+}
+
+// atCapacity сообщает, достиг ли пользователь своего лимита открытых ревью.
+func atCapacity(limits, loads map[string]int, userID string) bool {
+	limit, ok := limits[userID]
+	return ok && loads[userID] >= limit
+}
+
+// allAtCapacity сообщает, достигли ли лимита открытых ревью все кандидаты.
+func (s *PullRequestService) allAtCapacity(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	candidates []string,
+) (bool, error) {
+	limits, err := s.reviewLimits(ctx, settings, candidates)
+	if err != nil || len(limits) == 0 {
+		return false, err
+	}
+
+	loads, err := s.reviewerProvider.GetOpenReviewsCount(ctx, candidates)
+	if err != nil {
+		return false, err
+	}
+
+	for _, id := range candidates {
+		if !atCapacity(limits, loads, id) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,61 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// createWithLimits создает PR автора a1 с одним ревьюером из u2 и u3 при заданных лимитах и нагрузке.
+func createWithLimits(t *testing.T, teamLimit *int, own, loads map[string]int) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	capacities := mocks.NewCapacityProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u2", "u3"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).
+		Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 1, MaxOpenReviews: teamLimit}, nil).Once()
+	capacities.On("GetMaxOpenReviews", ctx, mock.Anything).Return(own, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.Anything).Return(loads, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return("pr-1", nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, "pr-1", mock.Anything).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{Capacities: capacities})
+	resp, err := svc.Create(ctx, "pr-1", "Capacity", "a1", nil, nil)
+
+	assert.NoError(t, err)
+	return resp.AssignedReviewers
+}
+
+func TestPullRequestService_Create_SkipsUserAtOwnLimit(t *testing.T) {
+	for range 10 {
+		// у команды лимита нет, u2 упирается в личный
+		reviewers := createWithLimits(t, nil, map[string]int{"u2": 1}, map[string]int{"u2": 1, "u3": 5})
+
+		assert.Equal(t, []string{"u3"}, reviewers)
+	}
+}
+
+func TestPullRequestService_Create_OwnLimitOverridesTeamDefault(t *testing.T) {
+	teamLimit := 1
+
+	for range 10 {
+		// u3 упирается в лимит команды, а личный лимит u2 выше
+		reviewers := createWithLimits(t, &teamLimit, map[string]int{"u2": 3}, map[string]int{"u2": 2, "u3": 1})
+
+		assert.Equal(t, []string{"u2"}, reviewers)
+	}
+}
@@ -0,0 +1,141 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/lib/codeowners"
+	"avito-intership-2025/internal/models"
+	"context"
+	"maps"
+	"slices"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=CodeOwnersProvider
+type CodeOwnersProvider interface {
+	GetRules(ctx context.Context) ([]*models.CodeOwnerRule, error)
+}
+
+// matchOwnerRules возвращает правила, которые владеют хотя бы одним из файлов.
+// Как в CODEOWNERS, файлом владеет последнее совпавшее правило.
+func matchOwnerRules(rules []*models.CodeOwnerRule, files []string) ([]*models.CodeOwnerRule, error) {
+	patterns := make([]*codeowners.Pattern, len(rules))
+	for i, r := range rules {
+		p, err := codeowners.Compile(r.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		patterns[i] = p
+	}
+
+	owning := make([]bool, len(rules))
+	for _, f := range files {
+		for i := len(patterns) - 1; i >= 0; i-- {
+			if patterns[i].Match(f) {
+				owning[i] = true
+				break
+			}
+		}
+	}
+
+	matched := []*models.CodeOwnerRule{}
+	for i, r := range rules {
+		if owning[i] {
+			matched = append(matched, r)
+		}
+	}
+	return matched, nil
+}
+
+// pickOwners выбирает по одному владельцу на каждое правило, совпавшее с измененными файлами.
+// Правило пропускается, если его владелец уже выбран или свободных владельцев нет.
+// Владельцы из excluded не выбираются, лимит открытых ревью у каждого владельца - свой (см. ownerLimits).
+func (s *PullRequestService) pickOwners(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	files []string,
+	pr *models.PullRequest,
+	excluded []string,
+) ([]string, map[string]int, error) {
+	picked := []string{}
+	loads := map[string]int{}
+
+	if len(files) == 0 || s.codeOwners == nil {
+		return picked, loads, nil
+	}
+
+	rules, err := s.codeOwners.GetRules(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matched, err := matchOwnerRules(rules, files)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	owners := []string{}
+	for _, rule := range matched {
+		for _, id := range excludeUsers(rule.ActiveOwners, append([]string{pr.AuthorId}, excluded...)...) {
+			if !slices.Contains(owners, id) {
+				owners = append(owners, id)
+			}
+		}
+	}
+
+	limits, err := s.ownerLimits(ctx, settings, owners)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, rule := range matched {
+		if slices.ContainsFunc(rule.ActiveOwners, func(u string) bool { return slices.Contains(picked, u) }) {
+			continue
+		}
+
+		candidates := excludeUsers(rule.ActiveOwners, slices.Concat([]string{pr.AuthorId}, picked, excluded)...)
+		owner, ownerLoads, err := s.pickWithinLimits(ctx, settings, limits, pr.AuthorId, candidates, 1, pr.Labels)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		picked = append(picked, owner...)
+		maps.Copy(loads, ownerLoads)
+	}
+
+	return picked, loads, nil
+}
+
+// ownerLimits возвращает лимиты открытых ревью владельцев. Владелец может быть из другой команды,
+// поэтому лимит команды берется из настроек его собственной команды, а не команды автора.
+func (s *PullRequestService) ownerLimits(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	owners []string,
+) (map[string]int, error) {
+	byTeam := map[int][]string{}
+	for _, id := range owners {
+		owner, err := s.userGetter.GetById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		byTeam[owner.TeamID] = append(byTeam[owner.TeamID], id)
+	}
+
+	limits := make(map[string]int, len(owners))
+	for teamID, ids := range byTeam {
+		teamSettings := settings
+		if teamID != settings.TeamID {
+			var err error
+			teamSettings, err = s.settingsProvider.GetSettings(ctx, teamID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		teamLimits, err := s.reviewLimits(ctx, teamSettings, ids)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(limits, teamLimits)
+	}
+
+	return limits, nil
+}
@@ -0,0 +1,145 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_Create_PicksCodeOwners(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-owners"
+	authorID := "a1"
+	teamID := 1
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	owners := mocks.NewCodeOwnersProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: teamID}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return([]string{"a1", "u1", "u2"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, teamID).Return(&models.TeamSettings{TeamID: teamID, ReviewersCount: 2}, nil).Once()
+	owners.On("GetRules", ctx).Return([]*models.CodeOwnerRule{
+		// перекрыто правилами ниже для обоих файлов
+		{Pattern: "*", ActiveOwners: []string{"u9"}},
+		{Pattern: "/docs/", ActiveOwners: []string{"d1", "d2"}},
+		// единственный владелец - автор, правило пропускается
+		{Pattern: "*.sql", ActiveOwners: []string{"a1"}},
+	}, nil).Once()
+	for _, id := range []string{"d1", "d2"} {
+		userGetter.On("GetById", ctx, id).Return(&models.User{ID: id, TeamID: teamID}, nil).Once()
+	}
+	selector.On("Select", ctx, teamID, []string{"d1", "d2"}, 1).Return([]string{"d2"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"d2"}).Return(map[string]int{"d2": 1}, nil).Once()
+	// оставшееся место занимает команда автора
+	selector.On("Select", ctx, teamID, []string{"u1", "u2"}, 1).Return([]string{"u1"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u1"}).Return(map[string]int{"u1": 0}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "d2").Return(nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u1").Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{CodeOwners: owners})
+	resp, err := svc.Create(ctx, prID, "Owners", authorID, []string{"docs/api.md", "migrations/007.up.sql"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"d2", "u1"}, resp.AssignedReviewers)
+	assert.Equal(t, map[string]int{"d2": 1, "u1": 0}, resp.ReviewerLoads)
+	assert.False(t, resp.NeedMoreReviewers)
+}
+
+func TestPullRequestService_Create_OwnersExceedReviewersCount(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-owners"
+	authorID := "a1"
+	teamID := 1
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	owners := mocks.NewCodeOwnersProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: teamID}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return([]string{"a1", "u1"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, teamID).Return(&models.TeamSettings{TeamID: teamID, ReviewersCount: 1}, nil).Once()
+	owners.On("GetRules", ctx).Return([]*models.CodeOwnerRule{
+		{Pattern: "/api/", ActiveOwners: []string{"o1"}},
+		{Pattern: "/web/", ActiveOwners: []string{"o1", "o2"}},
+		{Pattern: "/db/", ActiveOwners: []string{"o3"}},
+	}, nil).Once()
+	for _, id := range []string{"o1", "o2", "o3"} {
+		userGetter.On("GetById", ctx, id).Return(&models.User{ID: id, TeamID: teamID}, nil).Once()
+	}
+	selector.On("Select", ctx, teamID, []string{"o1"}, 1).Return([]string{"o1"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"o1"}).Return(map[string]int{"o1": 0}, nil).Once()
+	// /web/ уже покрыт выбранным o1
+	selector.On("Select", ctx, teamID, []string{"o3"}, 1).Return([]string{"o3"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"o3"}).Return(map[string]int{"o3": 2}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "o1").Return(nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "o3").Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{CodeOwners: owners})
+	resp, err := svc.Create(ctx, prID, "Owners", authorID, []string{"api/x.go", "web/y.ts", "db/z.sql"}, nil)
+
+	assert.NoError(t, err)
+	// владельцев больше, чем reviewers_count: команда автора не добавляется
+	assert.Equal(t, []string{"o1", "o3"}, resp.AssignedReviewers)
+	selector.AssertNotCalled(t, "Select", ctx, teamID, []string{"u1"}, mock.Anything)
+}
+
+func TestPullRequestService_Create_OwnersUseOwnTeamCapacity(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-owners"
+	authorID := "a1"
+	one, five := 1, 5
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	owners := mocks.NewCodeOwnersProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u1"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).
+		Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 1, MaxOpenReviews: &one}, nil).Once()
+	owners.On("GetRules", ctx).Return([]*models.CodeOwnerRule{
+		{Pattern: "/api/", ActiveOwners: []string{"x1"}},
+		{Pattern: "/db/", ActiveOwners: []string{"y1"}},
+	}, nil).Once()
+	userGetter.On("GetById", ctx, "x1").Return(&models.User{ID: "x1", TeamID: 2}, nil).Once()
+	userGetter.On("GetById", ctx, "y1").Return(&models.User{ID: "y1", TeamID: 3}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 2).Return(&models.TeamSettings{TeamID: 2, MaxOpenReviews: &five}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 3).Return(&models.TeamSettings{TeamID: 3, MaxOpenReviews: &one}, nil).Once()
+	// x1 выше лимита команды автора, но в пределах лимита своей команды
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"x1"}).Return(map[string]int{"x1": 2}, nil).Once()
+	selector.On("Select", ctx, 1, []string{"x1"}, 1).Return([]string{"x1"}, nil).Once()
+	// y1 упирается в лимит своей команды
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"y1"}).Return(map[string]int{"y1": 1}, nil).Once()
+	selector.On("Select", ctx, 1, []string{}, 1).Return([]string{}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "x1").Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{CodeOwners: owners})
+	resp, err := svc.Create(ctx, prID, "Owners", authorID, []string{"api/x.go", "db/z.sql"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x1"}, resp.AssignedReviewers)
+	assert.Equal(t, map[string]int{"x1": 2}, resp.ReviewerLoads)
+	reviewerProv.AssertNotCalled(t, "AssignReviewer", ctx, prID, "y1")
+}
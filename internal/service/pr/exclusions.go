@@ -0,0 +1,34 @@
+package pr
+
+import (
+	repo "avito-intership-2025/internal/repository"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ExclusionProvider
+type ExclusionProvider interface {
+	GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error)
+}
+
+// excludedReviewers возвращает пользователей, которых правила запрещают назначать на PR автора.
+func (s *PullRequestService) excludedReviewers(ctx context.Context, authorID string) ([]string, error) {
+	if s.exclusions == nil {
+		return nil, nil
+	}
+	return s.exclusions.GetExcludedReviewers(ctx, authorID)
+}
+
+// applyExclusions убирает из кандидатов запрещенных правилами. Если кандидаты были,
+// но правила отбросили всех, возвращается ErrExcludedByRules, чтобы клиент отличал
+// конфликт интересов от пустой команды.
+func applyExclusions(candidates, excluded []string) ([]string, error) {
+	if len(candidates) == 0 || len(excluded) == 0 {
+		return candidates, nil
+	}
+
+	allowed := excludeUsers(candidates, excluded...)
+	if len(allowed) == 0 {
+		return nil, repo.ErrExcludedByRules
+	}
+	return allowed, nil
+}
@@ -0,0 +1,104 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_Create_SkipsExcludedReviewers(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	exclusions := mocks.NewExclusionProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "m1", "u2", "u3"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	// m1 - руководитель автора
+	exclusions.On("GetExcludedReviewers", ctx, "a1").Return([]string{"m1"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.Anything).Return(map[string]int{}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return("pr-1", nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, "pr-1", "u2").Return(nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, "pr-1", "u3").Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{Exclusions: exclusions})
+	resp, err := svc.Create(ctx, "pr-1", "Excluded manager", "a1", nil, nil)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, resp.AssignedReviewers)
+}
+
+func TestPullRequestService_Create_AllCandidatesExcluded(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	exclusions := mocks.NewExclusionProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrExcludedByRules)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "m1", "co1"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	exclusions.On("GetExcludedReviewers", ctx, "a1").Return([]string{"m1", "co1"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{Exclusions: exclusions})
+	_, err := svc.Create(ctx, "pr-1", "Nobody can review", "a1", nil, nil)
+
+	assert.ErrorIs(t, err, repo.ErrExcludedByRules)
+	prCtrl.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_Reassign_AllCandidatesExcluded(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	exclusions := mocks.NewExclusionProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrExcludedByRules)
+
+	prCtrl.On("GetById", ctx, "pr-1").
+		Return(&models.PullRequest{ID: "pr-1", AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr-1").Return([]string{"r1"}, nil).Once()
+	userGetter.On("GetById", ctx, "r1").Return(&models.User{ID: "r1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "r1", "co1"}, nil).Once()
+	exclusions.On("GetExcludedReviewers", ctx, "a1").Return([]string{"co1"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, nil, pr.NewRandomSelector(), pr.Options{Exclusions: exclusions})
+	_, err := svc.Reassign(ctx, "pr-1", "r1")
+
+	assert.ErrorIs(t, err, repo.ErrExcludedByRules)
+	reviewerProv.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_AddReviewer_Excluded(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	exclusions := mocks.NewExclusionProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrExcludedByRules)
+
+	prCtrl.On("GetById", ctx, "pr-1").
+		Return(&models.PullRequest{ID: "pr-1", AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr-1").Return([]string{}, nil).Once()
+	exclusions.On("GetExcludedReviewers", ctx, "a1").Return([]string{"m1"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{Exclusions: exclusions})
+	_, err := svc.AddReviewer(ctx, "pr-1", "m1")
+
+	assert.ErrorIs(t, err, repo.ErrExcludedByRules)
+}
@@ -0,0 +1,42 @@
+package pr
+
+import "avito-intership-2025/internal/models"
+
+// reasonCodeOwner - причина назначения владельца по правилу CODEOWNERS.
+const reasonCodeOwner = "code owner"
+
+func assignedEvent(prID, userID, reason string) *models.ReviewEvent {
+	return &models.ReviewEvent{
+		Type:          models.EventAssigned,
+		PullRequestID: prID,
+		NewReviewerID: userID,
+		Reason:        reason,
+	}
+}
+
+func reassignedEvent(prID, oldUserID, newUserID, reason string) *models.ReviewEvent {
+	return &models.ReviewEvent{
+		Type:          models.EventReassigned,
+		PullRequestID: prID,
+		OldReviewerID: oldUserID,
+		NewReviewerID: newUserID,
+		Reason:        reason,
+	}
+}
+
+func removedEvent(prID, userID, reason string) *models.ReviewEvent {
+	return &models.ReviewEvent{
+		Type:          models.EventRemoved,
+		PullRequestID: prID,
+		OldReviewerID: userID,
+		Reason:        reason,
+	}
+}
+
+func statusEvent(prID, status string) *models.ReviewEvent {
+	return &models.ReviewEvent{
+		Type:          models.EventStatusChanged,
+		PullRequestID: prID,
+		Status:        status,
+	}
+}
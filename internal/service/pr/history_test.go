@@ -0,0 +1,112 @@
+package pr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"avito-intership-2025/internal/lib/actor"
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_RemoveReviewer_RecordsEvent(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "alice")
+	prID := "pr-rm"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	events := mocks.NewEventRecorder(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1", "u2"}, nil).Once()
+	reviewerProv.On("DeleteReviewer", ctx, prID, "u1").Return(nil).Once()
+	events.On("AddEvents", ctx, []*models.ReviewEvent{{
+		Type:          models.EventRemoved,
+		PullRequestID: prID,
+		OldReviewerID: "u1",
+		Actor:         "alice",
+	}}).Return(nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 1}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return(nil, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{Events: events})
+	_, err := svc.RemoveReviewer(ctx, prID, "u1")
+
+	assert.NoError(t, err)
+}
+
+func TestPullRequestService_Merge_RecordsEvent(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-merge"
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	userGetter := mocks.NewUserGetter(t)
+	events := mocks.NewEventRecorder(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	prCtrl.On("MarkAsMerged", ctx, prID).Return(nil).Once()
+	// без исполнителя в контексте событие пишется от имени системы
+	events.On("AddEvents", ctx, []*models.ReviewEvent{{
+		Type:          models.EventStatusChanged,
+		PullRequestID: prID,
+		Status:        pr.StatusMerged,
+		Actor:         actor.System,
+	}}).Return(nil).Once()
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusMerged}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return(nil, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{Events: events})
+	resp, err := svc.Merge(ctx, prID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pr.StatusMerged, resp.Status)
+}
+
+func TestPullRequestService_Reassign_EventError(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-reassign"
+	dbErr := errors.New("journal is unavailable")
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	events := mocks.NewEventRecorder(t)
+	trm := newDoMock(t, ctx, dbErr)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1"}, nil).Once()
+	userGetter.On("GetById", ctx, "u1").Return(&models.User{ID: "u1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u1", "u2"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 1}, nil).Once()
+	selector.On("Select", ctx, 1, []string{"u2"}, 1).Return([]string{"u2"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u2"}).Return(map[string]int{"u2": 0}, nil).Once()
+	reviewerProv.On("ReassignReviewer", ctx, prID, "u1", "u2").Return(nil).Once()
+	events.On("AddEvents", ctx, mock.AnythingOfType("[]*models.ReviewEvent")).Return(dbErr).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{Events: events})
+	_, err := svc.Reassign(ctx, prID, "u1")
+
+	// без записи в журнал переназначение откатывается вместе с транзакцией
+	assert.ErrorIs(t, err, dbErr)
+}
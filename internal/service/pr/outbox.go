@@ -0,0 +1,27 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"context"
+	"encoding/json"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=OutboxWriter
+type OutboxWriter interface {
+	AddOutboxEvent(ctx context.Context, eventType string, payload []byte) error
+}
+
+// publish ставит событие о PR в outbox для рассылки подписчикам вебхуков.
+// Вызывается внутри транзакции изменения, поэтому событие не теряется и не уходит
+// при откате. Если outbox не подключен, ничего не делает.
+func (s *PullRequestService) publish(ctx context.Context, eventType string, data *api.PrEventData) error {
+	if s.outbox == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return s.outbox.AddOutboxEvent(ctx, eventType, payload)
+}
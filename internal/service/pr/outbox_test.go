@@ -0,0 +1,90 @@
+package pr_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_Create_PublishesEvent(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	outbox := mocks.NewOutboxWriter(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u1"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 1}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u1"}).Return(map[string]int{"u1": 0}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return("pr1", nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, "pr1", "u1").Return(nil).Once()
+
+	var published api.PrEventData
+	outbox.On("AddOutboxEvent", ctx, models.WebhookPrCreated, mock.AnythingOfType("[]uint8")).
+		Run(func(args mock.Arguments) {
+			assert.NoError(t, json.Unmarshal(args.Get(2).([]byte), &published))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{Outbox: outbox})
+	_, err := svc.Create(ctx, "pr1", "Add search", "a1", nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pr1", published.PullRequest.ID)
+	assert.Equal(t, []string{"u1"}, published.PullRequest.AssignedReviewers)
+}
+
+func TestPullRequestService_Create_OutboxErrorRollsBack(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("outbox is unavailable")
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	outbox := mocks.NewOutboxWriter(t)
+	trm := newDoMock(t, ctx, dbErr)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 1}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return("pr1", nil).Once()
+	outbox.On("AddOutboxEvent", ctx, models.WebhookPrCreated, mock.Anything).Return(dbErr).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{Outbox: outbox})
+	_, err := svc.Create(ctx, "pr1", "Add search", "a1", nil, nil)
+
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestPullRequestService_Merge_AlreadyMergedDoesNotPublish(t *testing.T) {
+	ctx := context.Background()
+	merged := &models.PullRequest{ID: "pr1", AuthorId: "a1", Status: pr.StatusMerged}
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	outbox := mocks.NewOutboxWriter(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, "pr1").Return(merged, nil).Twice()
+	reviewerProv.On("GetPrReviewers", ctx, "pr1").Return([]string{}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, "pr1").Return(nil, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{Outbox: outbox})
+	_, err := svc.Merge(ctx, "pr1")
+
+	assert.NoError(t, err)
+	outbox.AssertNotCalled(t, "AddOutboxEvent", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -2,17 +2,27 @@ package pr
 
 import (
 	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib"
 	"avito-intership-2025/internal/models"
 	repo "avito-intership-2025/internal/repository"
 	"avito-intership-2025/internal/service"
 	"context"
-	"math/rand/v2"
+	"fmt"
+	"maps"
 	"slices"
+	"time"
 )
 
 const (
+	VerdictApproved         = "APPROVED"
+	VerdictChangesRequested = "CHANGES_REQUESTED"
+)
+
+const (
+	StatusDraft  = "DRAFT"
 	StatusOpen   = "OPEN"
 	StatusMerged = "MERGED"
+	StatusClosed = "CLOSED"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=PrController
@@ -20,6 +30,12 @@ type PrController interface {
 	Create(ctx context.Context, pr *models.PullRequest) (string, error)
 	GetById(ctx context.Context, prID string) (*models.PullRequest, error)
 	MarkAsMerged(ctx context.Context, prID string) error
+	SetStatus(ctx context.Context, prID, status string) error
+	GetUnderstaffed(ctx context.Context) ([]*models.PullRequest, error)
+	SetNeedMoreReviewers(ctx context.Context, prID string, need bool) error
+	SetLabels(ctx context.Context, prID string, labels []string) error
+	List(ctx context.Context, filter *models.PrListFilter) ([]*models.PullRequestListItem, string, error)
+	Search(ctx context.Context, filter *models.PrSearchFilter) ([]*models.PullRequestSearchItem, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ReviewerProvider
@@ -28,74 +44,278 @@ type ReviewerProvider interface {
 	AssignReviewer(ctx context.Context, prID, userID string) error
 	ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) error
 	DeleteReviewer(ctx context.Context, prID, userID string) error
+	GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetOpenReviewsByUsers(ctx context.Context, userIDs []string) ([]*models.ReviewAssignment, error)
+	SetAssignmentReason(ctx context.Context, prID, userID, reason string) error
+	GetPendingReviews(ctx context.Context, olderThan time.Duration) ([]*models.PendingReview, error)
+	AddReview(ctx context.Context, review *models.Review) error
+	GetLatestReviews(ctx context.Context, prID string) ([]*models.Review, error)
+	GetPairingsInLastPRs(ctx context.Context, authorID string, reviewerIDs []string, lastPRs int) (map[string]int, error)
+	GetPairingsSince(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=UserGetter
 type UserGetter interface {
 	GetActiveUsersIDInTeam(ctx context.Context, teamID int) ([]string, error)
 	GetById(ctx context.Context, userID string) (*models.User, error)
+	GetSkills(ctx context.Context, userIDs []string) (map[string][]string, error)
+	GetSeniority(ctx context.Context, userIDs []string) (map[string]string, error)
+	GetTeamMembers(ctx context.Context, teamID int) ([]*models.User, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=TeamSettingsProvider
+type TeamSettingsProvider interface {
+	GetSettings(ctx context.Context, teamID int) (*models.TeamSettings, error)
 }
 
 type PullRequestService struct {
 	prController     PrController
 	userGetter       UserGetter
+	settingsProvider TeamSettingsProvider
 	reviewerProvider ReviewerProvider
+	selector         ReviewerSelector
+	codeOwners       CodeOwnersProvider
+	events           service.EventRecorder
+	outbox           OutboxWriter
+	exclusions       ExclusionProvider
+	capacities       CapacityProvider
 	trm              service.TransactionManager
 }
 
+// Options - необязательные зависимости сервиса PR. Незаданное поле выключает
+// соответствующую возможность: CODEOWNERS, историю событий, вебхуки, правила исключения
+// или личные лимиты ревьюеров.
+type Options struct {
+	CodeOwners CodeOwnersProvider
+	Events     service.EventRecorder
+	Outbox     OutboxWriter
+	Exclusions ExclusionProvider
+	Capacities CapacityProvider
+}
+
 func NewPullRequestService(
 	trm service.TransactionManager,
 	prController PrController,
 	reviewerProvider ReviewerProvider,
 	userGetter UserGetter,
+	settingsProvider TeamSettingsProvider,
+	selector ReviewerSelector,
+	opts Options,
 ) *PullRequestService {
 	return &PullRequestService{
 		trm:              trm,
 		prController:     prController,
 		userGetter:       userGetter,
+		settingsProvider: settingsProvider,
 		reviewerProvider: reviewerProvider,
+		selector:         selector,
+		codeOwners:       opts.CodeOwners,
+		events:           opts.Events,
+		outbox:           opts.Outbox,
+		exclusions:       opts.Exclusions,
+		capacities:       opts.Capacities,
 	}
 }
 
-func (s *PullRequestService) Create(ctx context.Context, prID, prName, authorId string) (*api.PullRequestSchema, error) {
+// Create создает PR и назначает ревьюеров. Если переданы измененные файлы, сначала выбирается
+// по владельцу на каждое совпавшее правило CODEOWNERS, оставшиеся места занимает команда автора.
+// При выборе предпочитаются кандидаты, чьи навыки пересекаются с метками PR.
+// Правила исключения действуют на оба этапа; если они отбросили всех кандидатов команды,
+// возвращается ErrExcludedByRules.
+func (s *PullRequestService) Create(
+	ctx context.Context,
+	prID, prName, authorId string,
+	files, labels []string,
+) (*api.PullRequestSchema, error) {
 
 	pr := &models.PullRequest{
 		ID:       prID,
 		Title:    prName,
 		AuthorId: authorId,
 		Status:   StatusOpen,
+		Labels:   lib.NormalizeTags(labels),
 	}
 
 	resp := &api.PullRequestSchema{
-		AssignedReviewers: make([]string, 0, 2),
+		AssignedReviewers: []string{},
 	}
 
 	err := s.trm.Do(ctx, func(ctx context.Context) error {
-		author, err := s.userGetter.GetById(ctx, authorId)
-		if err != nil {
-			return err
-		}
-
-		teamID := author.TeamID
-		activeUsers, err := s.userGetter.GetActiveUsersIDInTeam(ctx, teamID)
+		plan, err := s.planCreate(ctx, pr, files)
 		if err != nil {
 			return err
 		}
-		reviewers := getRandomUsers(activeUsers, 2, authorId)
+		reviewers := plan.reviewers
+		pr.NeedMoreReviewers = len(reviewers) < plan.settings.ReviewersCount
 
 		createdPrID, err := s.prController.Create(ctx, pr)
 		if err != nil {
 			return err
 		}
 
+		events := make([]*models.ReviewEvent, 0, len(reviewers))
 		for _, r := range reviewers {
 			err = s.reviewerProvider.AssignReviewer(ctx, createdPrID, r)
 			if err != nil {
 				return err
 			}
+
+			reason := ""
+			if slices.Contains(plan.owners, r) {
+				reason = reasonCodeOwner
+			}
+			events = append(events, assignedEvent(createdPrID, r, reason))
+		}
+
+		if err := service.RecordEvents(ctx, s.events, events...); err != nil {
+			return err
 		}
 
 		toPullRequestSchema(resp, pr, reviewers)
+		resp.ReviewerLoads = plan.loads
+		return s.publish(ctx, models.WebhookPrCreated, &api.PrEventData{PullRequest: *resp})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// assignmentPlan - подобранные ревьюеры и данные, на которых основан выбор. Ничего не записано.
+type assignmentPlan struct {
+	teamID   int
+	settings *models.TeamSettings
+	// active - активные участники команды, не находящиеся в отпуске
+	active []string
+	// excluded - запрещенные правилами конфликта интересов
+	excluded  []string
+	owners    []string
+	reviewers []string
+	loads     map[string]int
+}
+
+// planCreate подбирает ревьюеров нового PR: владельцев из CODEOWNERS и участников команды автора.
+func (s *PullRequestService) planCreate(ctx context.Context, pr *models.PullRequest, files []string) (*assignmentPlan, error) {
+	author, err := s.userGetter.GetById(ctx, pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &assignmentPlan{teamID: author.TeamID}
+	plan.active, err = s.userGetter.GetActiveUsersIDInTeam(ctx, plan.teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.settings, err = s.settingsProvider.GetSettings(ctx, plan.teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.excluded, err = s.excludedReviewers(ctx, pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+
+	// нагрузку фиксируем до назначения, т.е. без учета создаваемого PR
+	owners, ownerLoads, err := s.pickOwners(ctx, plan.settings, files, pr, plan.excluded)
+	if err != nil {
+		return nil, err
+	}
+	plan.owners = owners
+
+	candidates := excludeUsers(plan.active, append([]string{pr.AuthorId}, owners...)...)
+	need := plan.settings.ReviewersCount - len(owners)
+	if need > 0 {
+		candidates, err = applyExclusions(candidates, plan.excluded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	teamReviewers, loads, err := s.pickComposed(ctx, plan.settings, pr.AuthorId, owners, candidates, need, pr.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.reviewers = slices.Concat(owners, teamReviewers)
+	if len(owners) > 0 {
+		if loads == nil {
+			loads = make(map[string]int, len(ownerLoads))
+		}
+		maps.Copy(loads, ownerLoads)
+	}
+	plan.loads = loads
+
+	return plan, nil
+}
+
+// CreateDraft создает PR в статусе DRAFT без ревьюеров: они назначаются в Ready.
+func (s *PullRequestService) CreateDraft(
+	ctx context.Context,
+	prID, prName, authorId string,
+	labels []string,
+) (*api.PullRequestSchema, error) {
+	pr := &models.PullRequest{
+		ID:       prID,
+		Title:    prName,
+		AuthorId: authorId,
+		Status:   StatusDraft,
+		Labels:   lib.NormalizeTags(labels),
+	}
+
+	resp := &api.PullRequestSchema{
+		AssignedReviewers: []string{},
+	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		// автор должен существовать, как и для обычного PR
+		if _, err := s.userGetter.GetById(ctx, authorId); err != nil {
+			return err
+		}
+
+		if _, err := s.prController.Create(ctx, pr); err != nil {
+			return err
+		}
+
+		toPullRequestSchema(resp, pr, nil)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TopUpReviewers доназначает ревьюеров открытым PR с флагом needMoreReviewers,
+// если в команде автора появились свободные активные участники.
+// Возвращает только изменившиеся PR.
+func (s *PullRequestService) TopUpReviewers(ctx context.Context) ([]api.PullRequestSchema, error) {
+	resp := []api.PullRequestSchema{}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		prs, err := s.prController.GetUnderstaffed(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, pr := range prs {
+			reviewers, added, loads, err := s.fillReviewers(ctx, pr)
+			if err != nil {
+				return err
+			}
+			if len(added) == 0 && pr.NeedMoreReviewers {
+				continue
+			}
+
+			schema := api.PullRequestSchema{
+				AssignedReviewers: make([]string, 0, len(reviewers)),
+			}
+			toPullRequestSchema(&schema, pr, reviewers)
+			schema.ReviewerLoads = loads
+			resp = append(resp, schema)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -104,10 +324,67 @@ func (s *PullRequestService) Create(ctx context.Context, prID, prName, authorId
 	return resp, nil
 }
 
+// Get возвращает PR с ревьюерами и их текущими вердиктами, ничего не меняя.
+func (s *PullRequestService) Get(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	resp := &api.PullRequestSchema{
+		AssignedReviewers: []string{},
+	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		pr, err := s.prController.GetById(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		reviewers, err := s.reviewerProvider.GetPrReviewers(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		toPullRequestSchema(resp, pr, reviewers)
+		return s.fillVerdicts(ctx, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// List возвращает страницу PR по фильтру. Вердикты в списке не заполняются, их отдает Get.
+func (s *PullRequestService) List(ctx context.Context, filter *models.PrListFilter) (*api.PrListResponse, error) {
+	items, next, err := s.prController.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.PrListResponse{
+		PullRequests: make([]api.PullRequestSchema, 0, len(items)),
+		NextCursor:   next,
+	}
+	for _, item := range items {
+		schema := api.PullRequestSchema{AssignedReviewers: []string{}}
+		toPullRequestSchema(&schema, &item.PullRequest, item.Reviewers)
+		resp.PullRequests = append(resp.PullRequests, schema)
+	}
+
+	return resp, nil
+}
+
 func (s *PullRequestService) Merge(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	return s.merge(ctx, prID, false)
+}
+
+// SyncMerged отмечает PR смерженным по данным внешней системы: мерж там уже произошел,
+// поэтому политика апрувов и допустимые переходы статусов не проверяются.
+// Для уже смерженного PR ничего не меняет.
+func (s *PullRequestService) SyncMerged(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	return s.merge(ctx, prID, true)
+}
 
+// merge переводит PR в MERGED. synced - мерж уже случился снаружи и проверки не нужны.
+func (s *PullRequestService) merge(ctx context.Context, prID string, synced bool) (*api.PullRequestSchema, error) {
 	resp := &api.PullRequestSchema{
-		AssignedReviewers: make([]string, 0, 2),
+		AssignedReviewers: []string{},
 	}
 
 	err := s.trm.Do(ctx, func(ctx context.Context) error {
@@ -116,8 +393,26 @@ func (s *PullRequestService) Merge(ctx context.Context, prID string) (*api.PullR
 			return err
 		}
 
-		if pr.Status == StatusOpen {
-			_ = s.prController.MarkAsMerged(ctx, pr.ID)
+		merged := false
+		switch {
+		case pr.Status == StatusMerged:
+		case pr.Status == StatusOpen, synced:
+			if !synced {
+				if err := s.checkApprovals(ctx, pr); err != nil {
+					return err
+				}
+			}
+			if err := s.prController.MarkAsMerged(ctx, pr.ID); err != nil {
+				return err
+			}
+			if err := service.RecordEvents(ctx, s.events, statusEvent(pr.ID, StatusMerged)); err != nil {
+				return err
+			}
+			merged = true
+		case pr.Status == StatusClosed:
+			return repo.ErrPRClosed
+		case pr.Status == StatusDraft:
+			return repo.ErrTransition
 		}
 
 		pr, err = s.prController.GetById(ctx, prID)
@@ -131,7 +426,15 @@ func (s *PullRequestService) Merge(ctx context.Context, prID string) (*api.PullR
 		}
 
 		toPullRequestSchema(resp, pr, reviewers)
-		return nil
+		if err := s.fillVerdicts(ctx, resp); err != nil {
+			return err
+		}
+
+		// повторный мерж уже смерженного PR события не порождает
+		if !merged {
+			return nil
+		}
+		return s.publish(ctx, models.WebhookPrMerged, &api.PrEventData{PullRequest: *resp})
 	})
 	if err != nil {
 		return nil, err
@@ -140,9 +443,14 @@ func (s *PullRequestService) Merge(ctx context.Context, prID string) (*api.PullR
 }
 
 func (s *PullRequestService) Reassign(ctx context.Context, prID, oldRev string) (*api.ReassignResponse, error) {
+	return s.reassign(ctx, prID, oldRev, "")
+}
+
+// reassign заменяет ревьюера oldRev. Непустая причина сохраняется в назначении нового ревьюера.
+func (s *PullRequestService) reassign(ctx context.Context, prID, oldRev, reason string) (*api.ReassignResponse, error) {
 	resp := &api.ReassignResponse{
 		PullRequest: api.PullRequestSchema{
-			AssignedReviewers: make([]string, 0, 2),
+			AssignedReviewers: []string{},
 		},
 	}
 
@@ -152,41 +460,33 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldRev string)
 			return err
 		}
 
-		if pr.Status == StatusMerged {
-			return repo.ErrPRMerged
+		if err := checkNotFrozen(pr); err != nil {
+			return err
 		}
 
-		author, err := s.userGetter.GetById(ctx, pr.AuthorId)
+		assignedReviewers, err := s.reviewerProvider.GetPrReviewers(ctx, prID)
 		if err != nil {
 			return err
 		}
-		teamID := author.TeamID
-		activeUsers, err := s.userGetter.GetActiveUsersIDInTeam(ctx, teamID)
+
+		plan, err := s.planReassign(ctx, pr, assignedReviewers, oldRev)
 		if err != nil {
 			return err
 		}
+		newRev, loads := plan.reviewers[0], plan.loads
 
-		assignedReviewers, err := s.reviewerProvider.GetPrReviewers(ctx, prID)
+		err = s.reviewerProvider.ReassignReviewer(ctx, prID, oldRev, newRev)
 		if err != nil {
 			return err
 		}
 
-		if !slices.Contains(assignedReviewers, oldRev) {
-			return repo.ErrNotAssigned
+		if reason != "" {
+			if err := s.reviewerProvider.SetAssignmentReason(ctx, prID, newRev, reason); err != nil {
+				return err
+			}
 		}
 
-		exludedReviewers := []string{author.ID}
-		exludedReviewers = append(exludedReviewers, assignedReviewers...)
-
-		var newRev string
-		if len(exludedReviewers) >= len(activeUsers) {
-			return repo.ErrNoCandidate
-		} else {
-			newRev = getRandomUsers(activeUsers, 1, exludedReviewers...)[0]
-
-			err = s.reviewerProvider.ReassignReviewer(ctx, prID, oldRev, newRev)
-		}
-		if err != nil {
+		if err := service.RecordEvents(ctx, s.events, reassignedEvent(prID, oldRev, newRev, reason)); err != nil {
 			return err
 		}
 
@@ -201,8 +501,15 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldRev string)
 		}
 
 		toPullRequestSchema(&resp.PullRequest, pr, reviewers)
+		resp.PullRequest.ReviewerLoads = loads
 		resp.ReplacedBy = newRev
-		return nil
+
+		return s.publish(ctx, models.WebhookPrReassigned, &api.PrEventData{
+			PullRequest:   resp.PullRequest,
+			OldReviewerID: oldRev,
+			NewReviewerID: newRev,
+			Reason:        reason,
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -210,42 +517,782 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldRev string)
 	return resp, nil
 }
 
-func toPullRequestSchema(resp *api.PullRequestSchema, pr *models.PullRequest, reviewers []string) {
-	resp.ID = pr.ID
-	resp.Name = pr.Title
-	resp.AuthorID = pr.AuthorId
-	resp.Status = pr.Status
-	resp.AssignedReviewers = append(resp.AssignedReviewers, reviewers...)
-	resp.MergedAt = pr.MergedAt
-}
+// planReassign подбирает замену ревьюеру oldRev среди assigned.
+func (s *PullRequestService) planReassign(
+	ctx context.Context,
+	pr *models.PullRequest,
+	assigned []string,
+	oldRev string,
+) (*assignmentPlan, error) {
+	if !slices.Contains(assigned, oldRev) {
+		return nil, repo.ErrNotAssigned
+	}
 
-func getRandomUsers(candidates []string, maxCount int, excludedIDs ...string) []string {
-	if len(candidates) == 0 || maxCount <= 0 {
-		return []string{}
+	// замену ищем в команде заменяемого ревьюера, а не автора:
+	// ревьюер мог быть назначен из другой команды
+	oldReviewer, err := s.userGetter.GetById(ctx, oldRev)
+	if err != nil {
+		return nil, err
 	}
 
-	excluded := make(map[string]struct{}, len(excludedIDs))
-	for _, id := range excludedIDs {
-		excluded[id] = struct{}{}
+	plan := &assignmentPlan{teamID: oldReviewer.TeamID}
+	plan.active, err = s.userGetter.GetActiveUsersIDInTeam(ctx, plan.teamID)
+	if err != nil {
+		return nil, err
 	}
 
-	var available []string
-	for _, user := range candidates {
-		if _, skip := excluded[user]; !skip {
-			available = append(available, user)
+	candidates := excludeUsers(plan.active, append([]string{pr.AuthorId}, assigned...)...)
+	if len(candidates) == 0 {
+		return nil, repo.ErrNoCandidate
+	}
+
+	plan.excluded, err = s.excludedReviewers(ctx, pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err = applyExclusions(candidates, plan.excluded)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.settings, err = s.settingsProvider.GetSettings(ctx, plan.teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := excludeUsers(assigned, oldRev)
+	picked, loads, err := s.pickComposed(ctx, plan.settings, pr.AuthorId, remaining, candidates, 1, pr.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if len(picked) == 0 {
+		// активные кандидаты были, но их отбросил лимит открытых ревью или правила состава
+		full, err := s.allAtCapacity(ctx, plan.settings, candidates)
+		if err != nil {
+			return nil, err
+		}
+		if full {
+			return nil, fmt.Errorf("%w: all candidates have reached max open reviews", repo.ErrNoCandidate)
 		}
+		return nil, fmt.Errorf("%w: remaining candidates do not fit team seniority composition rules", repo.ErrNoCandidate)
 	}
 
-	if len(available) == 0 {
-		return []string{}
+	plan.reviewers = picked
+	plan.loads = loads
+	return plan, nil
+}
+
+// Ready переводит DRAFT в OPEN и назначает ревьюеров так же, как при создании PR.
+// Для уже открытого PR ничего не меняет.
+func (s *PullRequestService) Ready(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	return s.changeStatus(ctx, prID, StatusOpen, StatusDraft)
+}
+
+// Close закрывает PR без мержа. Ревьюеры остаются в истории PR,
+// но закрытый PR не учитывается в их нагрузке.
+func (s *PullRequestService) Close(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	return s.changeStatus(ctx, prID, StatusClosed, StatusDraft, StatusOpen)
+}
+
+// Reopen возвращает закрытый PR в OPEN и доназначает недостающих ревьюеров.
+func (s *PullRequestService) Reopen(ctx context.Context, prID string) (*api.PullRequestSchema, error) {
+	return s.changeStatus(ctx, prID, StatusOpen, StatusClosed)
+}
+
+// changeStatus переводит PR в статус to из одного из статусов from.
+// Повторный перевод в текущий статус ничего не меняет.
+func (s *PullRequestService) changeStatus(
+	ctx context.Context,
+	prID, to string,
+	from ...string,
+) (*api.PullRequestSchema, error) {
+	resp := &api.PullRequestSchema{
+		AssignedReviewers: []string{},
 	}
 
-	rand.Shuffle(len(available), func(i, j int) {
-		available[i], available[j] = available[j], available[i]
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		pr, err := s.prController.GetById(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		var (
+			reviewers []string
+			loads     map[string]int
+		)
+
+		switch {
+		case pr.Status == to:
+		case pr.Status == StatusMerged:
+			return repo.ErrPRMerged
+		case !slices.Contains(from, pr.Status):
+			if pr.Status == StatusClosed {
+				return repo.ErrPRClosed
+			}
+			return repo.ErrTransition
+		default:
+			if err := s.prController.SetStatus(ctx, prID, to); err != nil {
+				return err
+			}
+			pr.Status = to
+
+			if err := service.RecordEvents(ctx, s.events, statusEvent(prID, to)); err != nil {
+				return err
+			}
+
+			if to == StatusOpen {
+				reviewers, _, loads, err = s.fillReviewers(ctx, pr)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		pr, err = s.prController.GetById(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if reviewers == nil {
+			reviewers, err = s.reviewerProvider.GetPrReviewers(ctx, prID)
+			if err != nil {
+				return err
+			}
+		}
+
+		toPullRequestSchema(resp, pr, reviewers)
+		resp.ReviewerLoads = loads
+		return s.fillVerdicts(ctx, resp)
 	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
 
-	if len(available) > maxCount {
-		return available[:maxCount]
+// AddReviewer вручную назначает ревьюера на PR. Действуют те же правила, что и при Reassign:
+// PR не заморожен, автор, неактивные и исключенные правилами не назначаются, а число ревьюеров
+// и открытых ревью не превышает лимитов команды.
+func (s *PullRequestService) AddReviewer(ctx context.Context, prID, userID string) (*api.PullRequestSchema, error) {
+	resp := &api.PullRequestSchema{
+		AssignedReviewers: []string{},
 	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		pr, err := s.prController.GetById(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkNotFrozen(pr); err != nil {
+			return err
+		}
+		// черновику ревьюеры не назначаются до /pullRequest/ready
+		if pr.Status != StatusOpen {
+			return fmt.Errorf("%w: reviewers can only be added to an open PR", repo.ErrTransition)
+		}
+
+		if userID == pr.AuthorId {
+			return fmt.Errorf("%w: user is the PR author", repo.ErrNoCandidate)
+		}
+
+		reviewers, err := s.reviewerProvider.GetPrReviewers(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(reviewers, userID) {
+			return fmt.Errorf("%w: user is already assigned", repo.ErrNoCandidate)
+		}
+
+		excluded, err := s.excludedReviewers(ctx, pr.AuthorId)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(excluded, userID) {
+			return fmt.Errorf("%w: user may not review this author", repo.ErrExcludedByRules)
+		}
+
+		author, err := s.userGetter.GetById(ctx, pr.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		authorSettings, err := s.settingsProvider.GetSettings(ctx, author.TeamID)
+		if err != nil {
+			return err
+		}
+
+		if len(reviewers) >= authorSettings.ReviewersCount {
+			return fmt.Errorf("%w: PR already has %d reviewers", repo.ErrNoCandidate, len(reviewers))
+		}
+
+		user, err := s.userGetter.GetById(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if !user.IsActive {
+			return fmt.Errorf("%w: user is not active", repo.ErrNoCandidate)
+		}
+
+		// отсутствующий не назначается и вручную, как и при автоматическом подборе
+		available, err := s.userGetter.GetActiveUsersIDInTeam(ctx, user.TeamID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(available, userID) {
+			return fmt.Errorf("%w: user is absent", repo.ErrNoCandidate)
+		}
+
+		// лимит открытых ревью: личный или команды назначаемого, как в pickReviewers
+		userSettings := authorSettings
+		if user.TeamID != author.TeamID {
+			userSettings, err = s.settingsProvider.GetSettings(ctx, user.TeamID)
+			if err != nil {
+				return err
+			}
+		}
+
+		limits, err := s.reviewLimits(ctx, userSettings, []string{userID})
+		if err != nil {
+			return err
+		}
+
+		loads, err := s.reviewerProvider.GetOpenReviewsCount(ctx, []string{userID})
+		if err != nil {
+			return err
+		}
+
+		if atCapacity(limits, loads, userID) {
+			return fmt.Errorf("%w: user has reached max open reviews", repo.ErrNoCandidate)
+		}
+
+		if err := s.reviewerProvider.AssignReviewer(ctx, prID, userID); err != nil {
+			return err
+		}
+		reviewers = append(reviewers, userID)
+
+		if err := service.RecordEvents(ctx, s.events, assignedEvent(prID, userID, "")); err != nil {
+			return err
+		}
+
+		if err := s.syncNeedMoreReviewers(ctx, pr, len(reviewers), authorSettings); err != nil {
+			return err
+		}
+
+		toPullRequestSchema(resp, pr, reviewers)
+		resp.ReviewerLoads = map[string]int{userID: loads[userID]}
+		return s.fillVerdicts(ctx, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RemoveReviewer снимает ревьюера с PR без замены. Если ревьюеров становится меньше,
+// чем требует команда автора, PR помечается needMoreReviewers.
+func (s *PullRequestService) RemoveReviewer(ctx context.Context, prID, userID string) (*api.PullRequestSchema, error) {
+	resp := &api.PullRequestSchema{
+		AssignedReviewers: []string{},
+	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		pr, err := s.prController.GetById(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkNotFrozen(pr); err != nil {
+			return err
+		}
+
+		reviewers, err := s.reviewerProvider.GetPrReviewers(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(reviewers, userID) {
+			return repo.ErrNotAssigned
+		}
+
+		if err := s.reviewerProvider.DeleteReviewer(ctx, prID, userID); err != nil {
+			return err
+		}
+		reviewers = excludeUsers(reviewers, userID)
+
+		if err := service.RecordEvents(ctx, s.events, removedEvent(prID, userID, "")); err != nil {
+			return err
+		}
+
+		author, err := s.userGetter.GetById(ctx, pr.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		settings, err := s.settingsProvider.GetSettings(ctx, author.TeamID)
+		if err != nil {
+			return err
+		}
+
+		// у черновика ревьюеров еще нет, их доберет Ready
+		if pr.Status == StatusOpen {
+			if err := s.syncNeedMoreReviewers(ctx, pr, len(reviewers), settings); err != nil {
+				return err
+			}
+		}
+
+		toPullRequestSchema(resp, pr, reviewers)
+		return s.fillVerdicts(ctx, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetLabels заменяет метки PR. Уже назначенных ревьюеров не трогает:
+// метки учитываются при следующих подборах (Ready, доназначение, переназначение).
+func (s *PullRequestService) SetLabels(ctx context.Context, prID string, labels []string) (*api.PullRequestSchema, error) {
+	resp := &api.PullRequestSchema{
+		AssignedReviewers: []string{},
+	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		pr, err := s.prController.GetById(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkNotFrozen(pr); err != nil {
+			return err
+		}
+
+		pr.Labels = lib.NormalizeTags(labels)
+		if err := s.prController.SetLabels(ctx, prID, pr.Labels); err != nil {
+			return err
+		}
+
+		reviewers, err := s.reviewerProvider.GetPrReviewers(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		toPullRequestSchema(resp, pr, reviewers)
+		return s.fillVerdicts(ctx, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SubmitReview сохраняет вердикт назначенного ревьюера. Вердикт можно менять,
+// текущим считается последний.
+func (s *PullRequestService) SubmitReview(
+	ctx context.Context,
+	prID, reviewerID, verdict, comment string,
+) (*api.PullRequestSchema, error) {
+	resp := &api.PullRequestSchema{
+		AssignedReviewers: []string{},
+	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		pr, err := s.prController.GetById(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkNotFrozen(pr); err != nil {
+			return err
+		}
+
+		reviewers, err := s.reviewerProvider.GetPrReviewers(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(reviewers, reviewerID) {
+			return repo.ErrNotAssigned
+		}
+
+		review := &models.Review{
+			PullRequestID: prID,
+			ReviewerID:    reviewerID,
+			Verdict:       verdict,
+			Comment:       comment,
+		}
+		if err := s.reviewerProvider.AddReview(ctx, review); err != nil {
+			return err
+		}
+
+		toPullRequestSchema(resp, pr, reviewers)
+		return s.fillVerdicts(ctx, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReassignOpenReviews снимает пользователей команды teamID со всех открытых ревью
+// и заменяет их активными участниками той же команды. Если замены нет, ревьюер
+// все равно снимается, а PR помечается needMoreReviewers. Причина пишется в журнал ревью.
+// Возвращает выполненные замены и id PR, оставшихся без нужного числа ревьюеров.
+func (s *PullRequestService) ReassignOpenReviews(
+	ctx context.Context,
+	teamID int,
+	userIDs []string,
+	reason string,
+) ([]api.ReviewReplacement, []string, error) {
+	replacements := []api.ReviewReplacement{}
+	understaffed := []string{}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		assignments, err := s.reviewerProvider.GetOpenReviewsByUsers(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+		if len(assignments) == 0 {
+			return nil
+		}
+
+		activeUsers, err := s.userGetter.GetActiveUsersIDInTeam(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		// снимаемые пользователи могут быть еще не выключены
+		activeUsers = excludeUsers(activeUsers, userIDs...)
+
+		settings, err := s.settingsProvider.GetSettings(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		// ревьюеры PR меняются по ходу замен, поэтому держим их актуальный список
+		prReviewers := make(map[string][]string)
+		authorExclusions := make(map[string][]string)
+		for _, a := range assignments {
+			reviewers, ok := prReviewers[a.PullRequestID]
+			if !ok {
+				reviewers, err = s.reviewerProvider.GetPrReviewers(ctx, a.PullRequestID)
+				if err != nil {
+					return err
+				}
+			}
+
+			forbidden, ok := authorExclusions[a.AuthorID]
+			if !ok {
+				forbidden, err = s.excludedReviewers(ctx, a.AuthorID)
+				if err != nil {
+					return err
+				}
+				authorExclusions[a.AuthorID] = forbidden
+			}
+
+			// исключенные правилами отбрасываются молча: ревью все равно снимается
+			excluded := slices.Concat([]string{a.AuthorID}, reviewers, forbidden)
+			reviewers = excludeUsers(reviewers, a.ReviewerID)
+			candidates := excludeUsers(activeUsers, excluded...)
+			picked, _, err := s.pickComposed(ctx, settings, a.AuthorID, reviewers, candidates, 1, a.Labels)
+			if err != nil {
+				return err
+			}
+
+			if len(picked) == 0 {
+				if err := s.reviewerProvider.DeleteReviewer(ctx, a.PullRequestID, a.ReviewerID); err != nil {
+					return err
+				}
+				event := removedEvent(a.PullRequestID, a.ReviewerID, reason)
+				if err := service.RecordEvents(ctx, s.events, event); err != nil {
+					return err
+				}
+				if !slices.Contains(understaffed, a.PullRequestID) {
+					if err := s.prController.SetNeedMoreReviewers(ctx, a.PullRequestID, true); err != nil {
+						return err
+					}
+					understaffed = append(understaffed, a.PullRequestID)
+				}
+				prReviewers[a.PullRequestID] = reviewers
+				continue
+			}
+
+			if err := s.reviewerProvider.ReassignReviewer(ctx, a.PullRequestID, a.ReviewerID, picked[0]); err != nil {
+				return err
+			}
+			event := reassignedEvent(a.PullRequestID, a.ReviewerID, picked[0], reason)
+			if err := service.RecordEvents(ctx, s.events, event); err != nil {
+				return err
+			}
+			prReviewers[a.PullRequestID] = append(reviewers, picked[0])
+			replacements = append(replacements, api.ReviewReplacement{
+				PullRequestID: a.PullRequestID,
+				OldReviewerID: a.ReviewerID,
+				ReplacedBy:    picked[0],
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return replacements, understaffed, nil
+}
+
+// fillReviewers доназначает открытому PR ревьюеров из команды автора до числа,
+// заданного в настройках команды, и синхронизирует флаг needMoreReviewers.
+// Возвращает всех ревьюеров PR, добавленных и нагрузку добавленных.
+func (s *PullRequestService) fillReviewers(
+	ctx context.Context,
+	pr *models.PullRequest,
+) ([]string, []string, map[string]int, error) {
+	author, err := s.userGetter.GetById(ctx, pr.AuthorId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	activeUsers, err := s.userGetter.GetActiveUsersIDInTeam(ctx, author.TeamID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	assigned, err := s.reviewerProvider.GetPrReviewers(ctx, pr.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	settings, err := s.settingsProvider.GetSettings(ctx, author.TeamID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	forbidden, err := s.excludedReviewers(ctx, author.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	excluded := slices.Concat([]string{author.ID}, assigned, forbidden)
+	added, loads, err := s.pickComposed(
+		ctx,
+		settings,
+		author.ID,
+		assigned,
+		excludeUsers(activeUsers, excluded...),
+		settings.ReviewersCount-len(assigned),
+		pr.Labels,
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	events := make([]*models.ReviewEvent, 0, len(added))
+	for _, r := range added {
+		if err := s.reviewerProvider.AssignReviewer(ctx, pr.ID, r); err != nil {
+			return nil, nil, nil, err
+		}
+		events = append(events, assignedEvent(pr.ID, r, ""))
+	}
+
+	if err := service.RecordEvents(ctx, s.events, events...); err != nil {
+		return nil, nil, nil, err
+	}
+
+	reviewers := slices.Concat(assigned, added)
+	// флаг меняется и тогда, когда команде теперь нужно меньше ревьюеров
+	if err := s.syncNeedMoreReviewers(ctx, pr, len(reviewers), settings); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return reviewers, added, loads, nil
+}
+
+// syncNeedMoreReviewers выставляет флаг needMoreReviewers по числу ревьюеров PR.
+func (s *PullRequestService) syncNeedMoreReviewers(
+	ctx context.Context,
+	pr *models.PullRequest,
+	reviewersCount int,
+	settings *models.TeamSettings,
+) error {
+	needMore := reviewersCount < settings.ReviewersCount
+	if needMore == pr.NeedMoreReviewers {
+		return nil
+	}
+
+	if err := s.prController.SetNeedMoreReviewers(ctx, pr.ID, needMore); err != nil {
+		return err
+	}
+	pr.NeedMoreReviewers = needMore
+	return nil
+}
+
+// pickReviewers отбрасывает кандидатов, достигших лимита открытых ревью (личного или команды),
+// выбирает до count ревьюеров стратегией (с предпочтением навыков под метки PR и ротацией пар с автором)
+// и возвращает их нагрузку на момент выбора.
+func (s *PullRequestService) pickReviewers(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	authorID string,
+	candidates []string,
+	count int,
+	labels []string,
+) ([]string, map[string]int, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil, nil
+	}
+
+	limits, err := s.reviewLimits(ctx, settings, candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.pickWithinLimits(ctx, settings, limits, authorID, candidates, count, labels)
+}
+
+// pickWithinLimits - pickReviewers с уже посчитанными лимитами открытых ревью кандидатов.
+func (s *PullRequestService) pickWithinLimits(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	limits map[string]int,
+	authorID string,
+	candidates []string,
+	count int,
+	labels []string,
+) ([]string, map[string]int, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil, nil
+	}
+
+	var (
+		loads map[string]int
+		err   error
+	)
+	if len(limits) > 0 {
+		loads, err = s.reviewerProvider.GetOpenReviewsCount(ctx, candidates)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		candidates = slices.DeleteFunc(slices.Clone(candidates), func(id string) bool {
+			return atCapacity(limits, loads, id)
+		})
+	}
+
+	picked, err := s.selectBySkills(ctx, settings, authorID, candidates, count, labels)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(picked) == 0 {
+		return picked, nil, nil
+	}
+
+	if loads == nil {
+		loads, err = s.reviewerProvider.GetOpenReviewsCount(ctx, picked)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	pickedLoads := make(map[string]int, len(picked))
+	for _, id := range picked {
+		pickedLoads[id] = loads[id]
+	}
+
+	return picked, pickedLoads, nil
+}
+
+// checkApprovals проверяет политику команды автора: если она задана,
+// PR нельзя смержить, пока у него меньше RequiredApprovals апрувов текущих ревьюеров.
+func (s *PullRequestService) checkApprovals(ctx context.Context, pr *models.PullRequest) error {
+	author, err := s.userGetter.GetById(ctx, pr.AuthorId)
+	if err != nil {
+		return err
+	}
+
+	settings, err := s.settingsProvider.GetSettings(ctx, author.TeamID)
+	if err != nil {
+		return err
+	}
+	if settings.RequiredApprovals == nil {
+		return nil
+	}
+
+	reviews, err := s.reviewerProvider.GetLatestReviews(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+
+	approvals := 0
+	for _, r := range reviews {
+		if r.Verdict == VerdictApproved {
+			approvals++
+		}
+	}
+
+	if approvals < *settings.RequiredApprovals {
+		return fmt.Errorf("%w: %d of %d", repo.ErrNoApprovals, approvals, *settings.RequiredApprovals)
+	}
+	return nil
+}
+
+// fillVerdicts добавляет в ответ текущие вердикты ревьюеров PR.
+func (s *PullRequestService) fillVerdicts(ctx context.Context, resp *api.PullRequestSchema) error {
+	reviews, err := s.reviewerProvider.GetLatestReviews(ctx, resp.ID)
+	if err != nil {
+		return err
+	}
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	resp.Verdicts = make(map[string]api.VerdictSchema, len(reviews))
+	for _, r := range reviews {
+		resp.Verdicts[r.ReviewerID] = api.VerdictSchema{
+			Verdict:     r.Verdict,
+			Comment:     r.Comment,
+			SubmittedAt: r.CreatedAt,
+		}
+	}
+	return nil
+}
+
+// checkNotFrozen запрещает менять ревьюеров смерженного или закрытого PR.
+func checkNotFrozen(pr *models.PullRequest) error {
+	switch pr.Status {
+	case StatusMerged:
+		return repo.ErrPRMerged
+	case StatusClosed:
+		return repo.ErrPRClosed
+	}
+	return nil
+}
+
+func toPullRequestSchema(resp *api.PullRequestSchema, pr *models.PullRequest, reviewers []string) {
+	resp.ID = pr.ID
+	resp.Name = pr.Title
+	resp.AuthorID = pr.AuthorId
+	resp.Status = pr.Status
+	resp.NeedMoreReviewers = pr.NeedMoreReviewers
+	resp.AssignedReviewers = append(resp.AssignedReviewers, reviewers...)
+	resp.Labels = pr.Labels
+	resp.MergedAt = pr.MergedAt
+	resp.ClosedAt = pr.ClosedAt
+}
+
+// excludeUsers возвращает кандидатов без исключенных пользователей, сохраняя порядок.
+func excludeUsers(candidates []string, excludedIDs ...string) []string {
+	excluded := make(map[string]struct{}, len(excludedIDs))
+	for _, id := range excludedIDs {
+		excluded[id] = struct{}{}
+	}
+
+	available := make([]string, 0, len(candidates))
+	for _, user := range candidates {
+		if _, skip := excluded[user]; !skip {
+			available = append(available, user)
+		}
+	}
+
 	return available
 }
@@ -30,6 +30,7 @@ func TestPullRequestService_Create_HappyPath_TwoReviewers(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 
 	author := &models.User{ID: authorID, TeamID: teamID}
 	activeUsers := []string{"u2", "u3", "u4", authorID} // include author; must be excluded
@@ -43,6 +44,9 @@ func TestPullRequestService_Create_HappyPath_TwoReviewers(t *testing.T) {
 
 	// Two different calls for two reviewers; order is random, so we match by type
 	reviewerProv.On("AssignReviewer", ctx, prID, mock.AnythingOfType("string")).Return(nil).Twice()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.AnythingOfType("[]string")).Return(map[string]int{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -51,8 +55,8 @@ func TestPullRequestService_Create_HappyPath_TwoReviewers(t *testing.T) {
 		}).Return(nil).Once()
 
 	// SUT
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
-	resp, err := svc.Create(ctx, prID, prName, authorID)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, prName, authorID, nil, nil)
 
 	// Assert
 	assert.NoError(t, err)
@@ -87,6 +91,7 @@ func TestPullRequestService_Create_Error_CreateFails(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 
 	author := &models.User{ID: authorID, TeamID: teamID}
 	activeUsers := []string{"u2"}
@@ -98,6 +103,9 @@ func TestPullRequestService_Create_Error_CreateFails(t *testing.T) {
 	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).
 		Return("", createErr).
 		Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.AnythingOfType("[]string")).Return(map[string]int{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -107,8 +115,8 @@ func TestPullRequestService_Create_Error_CreateFails(t *testing.T) {
 			assert.Equal(t, createErr, err)
 		}).Return(createErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
-	resp, err := svc.Create(ctx, prID, prName, authorID)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, prName, authorID, nil, nil)
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
@@ -131,6 +139,7 @@ func TestPullRequestService_Create_Error_GetAuthor(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 
 	// Create НЕ должен вызываться, поэтому не задаём ожидание на prCtrl.Create
 
@@ -147,8 +156,8 @@ func TestPullRequestService_Create_Error_GetAuthor(t *testing.T) {
 			assert.Equal(t, getErr, err)
 		}).Return(getErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
-	resp, err := svc.Create(ctx, prID, prName, authorID)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, prName, authorID, nil, nil)
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
@@ -175,6 +184,7 @@ func TestPullRequestService_Create_Error_AssignFirst(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 
 	author := &models.User{ID: authorID, TeamID: teamID}
 	activeUsers := []string{"u2", "u3", authorID}
@@ -190,6 +200,9 @@ func TestPullRequestService_Create_Error_AssignFirst(t *testing.T) {
 	reviewerProv.On("AssignReviewer", ctx, prID, mock.AnythingOfType("string")).
 		Return(assignErr).
 		Once() // fail on first assignment; second never called
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.AnythingOfType("[]string")).Return(map[string]int{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -199,8 +212,8 @@ func TestPullRequestService_Create_Error_AssignFirst(t *testing.T) {
 			assert.Equal(t, assignErr, err)
 		}).Return(assignErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
-	resp, err := svc.Create(ctx, prID, prName, authorID)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, prName, authorID, nil, nil)
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
@@ -224,6 +237,7 @@ func TestPullRequestService_Create_HappyPath_OneReviewer(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 
 	author := &models.User{ID: authorID, TeamID: teamID}
 	activeUsers := []string{"u2", authorID} // only one non-author candidate
@@ -236,6 +250,9 @@ func TestPullRequestService_Create_HappyPath_OneReviewer(t *testing.T) {
 	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return(activeUsers, nil).Once()
 
 	reviewerProv.On("AssignReviewer", ctx, prID, mock.AnythingOfType("string")).Return(nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.AnythingOfType("[]string")).Return(map[string]int{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -243,8 +260,8 @@ func TestPullRequestService_Create_HappyPath_OneReviewer(t *testing.T) {
 			assert.NoError(t, fn(ctx))
 		}).Return(nil).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
-	resp, err := svc.Create(ctx, prID, prName, authorID)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, prName, authorID, nil, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
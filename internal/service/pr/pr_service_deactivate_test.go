@@ -0,0 +1,83 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_ReassignOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	teamID := 4
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	reviewerProv.On("GetOpenReviewsByUsers", ctx, []string{"u1", "u2"}).Return([]*models.ReviewAssignment{
+		{PullRequestID: "pr-1", ReviewerID: "u1", AuthorID: "a1"},
+		{PullRequestID: "pr-1", ReviewerID: "u2", AuthorID: "a1"},
+	}, nil).Once()
+	// u2 еще числится активным - исключается как снимаемый
+	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return([]string{"a1", "u2", "u3"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, teamID).Return(&models.TeamSettings{TeamID: teamID, ReviewersCount: 2}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr-1").Return([]string{"u1", "u2"}, nil).Once()
+
+	// первая замена забирает единственного свободного u3
+	selector.On("Select", ctx, teamID, []string{"u3"}, 1).Return([]string{"u3"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u3"}).Return(map[string]int{"u3": 0}, nil).Once()
+	reviewerProv.On("ReassignReviewer", ctx, "pr-1", "u1", "u3").Return(nil).Once()
+
+	// для второго ревьюера замены уже нет
+	reviewerProv.On("DeleteReviewer", ctx, "pr-1", "u2").Return(nil).Once()
+	prCtrl.On("SetNeedMoreReviewers", ctx, "pr-1", true).Return(nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	replacements, understaffed, err := svc.ReassignOpenReviews(ctx, teamID, []string{"u1", "u2"}, "reviewer deactivated")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.ReviewReplacement{{PullRequestID: "pr-1", OldReviewerID: "u1", ReplacedBy: "u3"}}, replacements)
+	assert.Equal(t, []string{"pr-1"}, understaffed)
+}
+
+func TestPullRequestService_ReassignOpenReviews_NothingToReassign(t *testing.T) {
+	ctx := context.Background()
+
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	reviewerProv.On("GetOpenReviewsByUsers", ctx, []string{"u1"}).Return([]*models.ReviewAssignment{}, nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, nil, reviewerProv, nil, nil, nil, pr.Options{})
+	replacements, understaffed, err := svc.ReassignOpenReviews(ctx, 1, []string{"u1"}, "reviewer deactivated")
+
+	assert.NoError(t, err)
+	assert.Empty(t, replacements)
+	assert.Empty(t, understaffed)
+}
@@ -0,0 +1,94 @@
+package pr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestService_Get_WithVerdicts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, "pr1").Return(&models.PullRequest{
+		ID: "pr1", Title: "Feature", AuthorId: "a1", Status: pr.StatusOpen, Labels: []string{"go"},
+	}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr1").Return([]string{"r1", "r2"}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, "pr1").Return([]*models.Review{
+		{PullRequestID: "pr1", ReviewerID: "r1", Verdict: pr.VerdictApproved, CreatedAt: &now},
+	}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
+	resp, err := svc.Get(ctx, "pr1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Feature", resp.Name)
+	assert.Equal(t, []string{"r1", "r2"}, resp.AssignedReviewers)
+	assert.Equal(t, []string{"go"}, resp.Labels)
+	assert.Equal(t, map[string]api.VerdictSchema{
+		"r1": {Verdict: pr.VerdictApproved, SubmittedAt: &now},
+	}, resp.Verdicts)
+}
+
+func TestPullRequestService_Get_NotFound(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+	prCtrl.On("GetById", ctx, "missing").Return(nil, repo.ErrNotFound).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	resp, err := svc.Get(ctx, "missing")
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	assert.Nil(t, resp)
+}
+
+func TestPullRequestService_List(t *testing.T) {
+	ctx := context.Background()
+	filter := &models.PrListFilter{AuthorID: "a1", SortBy: models.PrSortCreatedAt, Desc: true, Limit: 2}
+
+	prCtrl := mocks.NewPrController(t)
+	prCtrl.On("List", ctx, filter).Return([]*models.PullRequestListItem{
+		{PullRequest: models.PullRequest{ID: "pr2", AuthorId: "a1", Status: pr.StatusOpen}, Reviewers: []string{"r1"}},
+		{PullRequest: models.PullRequest{ID: "pr1", AuthorId: "a1", Status: pr.StatusMerged}},
+	}, "next", nil).Once()
+
+	svc := pr.NewPullRequestService(nil, prCtrl, nil, nil, nil, nil, pr.Options{})
+	resp, err := svc.List(ctx, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, "next", resp.NextCursor)
+	require.Len(t, resp.PullRequests, 2)
+	assert.Equal(t, []string{"r1"}, resp.PullRequests[0].AssignedReviewers)
+	assert.Equal(t, []string{}, resp.PullRequests[1].AssignedReviewers)
+	assert.Equal(t, pr.StatusMerged, resp.PullRequests[1].Status)
+}
+
+func TestPullRequestService_List_Error(t *testing.T) {
+	ctx := context.Background()
+	filter := &models.PrListFilter{Cursor: "bad", Limit: 20}
+
+	prCtrl := mocks.NewPrController(t)
+	prCtrl.On("List", ctx, filter).Return(nil, "", repo.ErrInvalidCursor).Once()
+
+	svc := pr.NewPullRequestService(nil, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.List(ctx, filter)
+
+	assert.True(t, errors.Is(err, repo.ErrInvalidCursor))
+}
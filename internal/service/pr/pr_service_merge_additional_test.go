@@ -38,13 +38,14 @@ func TestPullRequestService_Merge_Success_EmptyReviewers(t *testing.T) {
 	// The service asks again after the conditional
 	prCtrl.On("GetById", ctx, prID).Return(merged, nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
 		fn := args.Get(1).(func(context.Context) error)
 		assert.NoError(t, fn(ctx))
 	}).Return(nil).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.NoError(t, err)
@@ -84,6 +85,7 @@ func TestPullRequestService_Merge_TRMReturnsError_AfterInnerOK(t *testing.T) {
 	prCtrl.On("GetById", ctx, prID).Return(merged, nil).Once()
 	prCtrl.On("GetById", ctx, prID).Return(merged, nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(reviewers, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{}, nil).Once()
 
 	trmErr := errors.New("transaction failed")
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
@@ -92,7 +94,7 @@ func TestPullRequestService_Merge_TRMReturnsError_AfterInnerOK(t *testing.T) {
 		assert.NoError(t, fn(ctx))
 	}).Return(trmErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.Nil(t, resp)
@@ -100,74 +102,59 @@ func TestPullRequestService_Merge_TRMReturnsError_AfterInnerOK(t *testing.T) {
 	assert.Equal(t, trmErr, err)
 }
 
-func TestPullRequestService_Merge_MarkAsMergedError_PrStillOpen(t *testing.T) {
+func TestPullRequestService_Merge_MarkAsMergedError_NothingRecorded(t *testing.T) {
 	ctx := context.Background()
 	prID := "merge-mark-error-open"
 
-	trm := &mocks.MockManager{}
-	trm.Test(t)
-	t.Cleanup(func() { trm.AssertExpectations(t) })
-
 	prCtrl := mocks.NewPrController(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	userGetter := mocks.NewUserGetter(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	events := mocks.NewEventRecorder(t)
+	outbox := mocks.NewOutboxWriter(t)
+	markErr := errors.New("merge failed")
+	trm := newDoMock(t, ctx, markErr)
 
 	open := &models.PullRequest{ID: prID, Title: "PR", AuthorId: "u1", Status: pr.StatusOpen}
-	// After failed merge, the PR still reported as OPEN
-	stillOpen := &models.PullRequest{ID: prID, Title: "PR", AuthorId: "u1", Status: pr.StatusOpen}
-	reviewers := []string{"r1"}
 
 	prCtrl.On("GetById", ctx, prID).Return(open, nil).Once()
-	prCtrl.On("MarkAsMerged", ctx, prID).Return(errors.New("merge failed")).Once()
-	prCtrl.On("GetById", ctx, prID).Return(stillOpen, nil).Once()
-	reviewerProv.On("GetPrReviewers", ctx, prID).Return(reviewers, nil).Once()
-
-	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
-		fn := args.Get(1).(func(context.Context) error)
-		// Service ignores MarkAsMerged error and continues
-		assert.NoError(t, fn(ctx))
-	}).Return(nil).Once()
+	userGetter.On("GetById", ctx, open.AuthorId).Return(&models.User{ID: open.AuthorId, TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	prCtrl.On("MarkAsMerged", ctx, prID).Return(markErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{Events: events, Outbox: outbox})
 	resp, err := svc.Merge(ctx, prID)
 
-	assert.NoError(t, err)
-	assert.NotNil(t, resp)
-	// Status remains OPEN, because implementation uses second GetById result as-is
-	assert.Equal(t, pr.StatusOpen, resp.Status)
-	assert.Equal(t, reviewers, resp.AssignedReviewers)
+	// PR в базе остался OPEN: ни события в журнале, ни вебхука о мерже
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, markErr)
+	events.AssertNotCalled(t, "AddEvents", mock.Anything, mock.Anything)
+	outbox.AssertNotCalled(t, "AddOutboxEvent", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestPullRequestService_Merge_MarkAsMergedError_SecondGetByIdError(t *testing.T) {
+func TestPullRequestService_Merge_SecondGetByIdError(t *testing.T) {
 	ctx := context.Background()
-	prID := "merge-mark-error-second-get"
-
-	trm := &mocks.MockManager{}
-	trm.Test(t)
-	t.Cleanup(func() { trm.AssertExpectations(t) })
+	prID := "merge-second-get"
 
 	prCtrl := mocks.NewPrController(t)
-	reviewerProv := mocks.NewReviewerProvider(t)
+	userGetter := mocks.NewUserGetter(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	secondErr := errors.New("second get failed")
+	trm := newDoMock(t, ctx, secondErr)
 
 	open := &models.PullRequest{ID: prID, Title: "PR", AuthorId: "u1", Status: pr.StatusOpen}
-	secondErr := errors.New("second get failed")
 
 	prCtrl.On("GetById", ctx, prID).Return(open, nil).Once()
-	prCtrl.On("MarkAsMerged", ctx, prID).Return(errors.New("merge failed")).Once()
+	userGetter.On("GetById", ctx, open.AuthorId).Return(&models.User{ID: open.AuthorId, TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	prCtrl.On("MarkAsMerged", ctx, prID).Return(nil).Once()
 	prCtrl.On("GetById", ctx, prID).Return((*models.PullRequest)(nil), secondErr).Once()
 
-	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
-		fn := args.Get(1).(func(context.Context) error)
-		err := fn(ctx)
-		assert.Error(t, err)
-		assert.Equal(t, secondErr, err)
-	}).Return(secondErr).Once()
-
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, userGetter, settingsProv, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.Nil(t, resp)
-	assert.Error(t, err)
-	assert.Equal(t, secondErr, err)
+	assert.ErrorIs(t, err, secondErr)
 }
 
 func TestPullRequestService_Merge_Success_NilReviewersSlice(t *testing.T) {
@@ -187,13 +174,14 @@ func TestPullRequestService_Merge_Success_NilReviewersSlice(t *testing.T) {
 	prCtrl.On("GetById", ctx, prID).Return(merged, nil).Once()
 	// reviewerProv returns nil slice without error
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(([]string)(nil), nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
 		fn := args.Get(1).(func(context.Context) error)
 		assert.NoError(t, fn(ctx))
 	}).Return(nil).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.NoError(t, err)
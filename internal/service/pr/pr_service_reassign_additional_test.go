@@ -21,6 +21,7 @@ func TestPullRequestService_Reassign_ReturnsErrPRMerged_WhenPRIsMerged(t *testin
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 
 	trm := &mocks.MockManager{}
 	trm.Test(t)
@@ -46,7 +47,7 @@ func TestPullRequestService_Reassign_ReturnsErrPRMerged_WhenPRIsMerged(t *testin
 		Once()
 
 	// SUT
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
 	resp, err := svc.Reassign(ctx, prID, oldRev)
 
 	// Assert
@@ -59,3 +60,46 @@ func TestPullRequestService_Reassign_ReturnsErrPRMerged_WhenPRIsMerged(t *testin
 	reviewerProv.AssertNotCalled(t, "GetPrReviewers", mock.Anything, mock.Anything)
 	reviewerProv.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
+
+func TestPullRequestService_Reassign_PicksFromReplacedReviewerTeam(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-cross"
+	oldRev := "ext-1"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	// автор из команды 1, заменяемый ревьюер - из команды 2
+	current := &models.PullRequest{ID: prID, Title: "Cross team", AuthorId: "a1", Status: pr.StatusOpen}
+	prCtrl.On("GetById", ctx, prID).Return(current, nil).Twice()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{oldRev, "ext-2"}, nil).Once()
+	userGetter.On("GetById", ctx, oldRev).Return(&models.User{ID: oldRev, TeamID: 2}, nil).Once()
+	// автор может состоять и в команде ревьюера - он все равно исключается
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 2).Return([]string{"a1", oldRev, "ext-2", "ext-3"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 2).Return(&models.TeamSettings{TeamID: 2, ReviewersCount: 2}, nil).Once()
+	selector.On("Select", ctx, 2, []string{"ext-3"}, 1).Return([]string{"ext-3"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"ext-3"}).Return(map[string]int{"ext-3": 0}, nil).Once()
+	reviewerProv.On("ReassignReviewer", ctx, prID, oldRev, "ext-3").Return(nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"ext-2", "ext-3"}, nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.Reassign(ctx, prID, oldRev)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ext-3", resp.ReplacedBy)
+	assert.Equal(t, []string{"ext-2", "ext-3"}, resp.PullRequest.AssignedReviewers)
+	userGetter.AssertNotCalled(t, "GetActiveUsersIDInTeam", ctx, 1)
+}
@@ -0,0 +1,180 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_SubmitReview_Success(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-rev"
+	now := time.Now()
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1", "u2"}, nil).Once()
+	reviewerProv.On("AddReview", ctx, &models.Review{
+		PullRequestID: prID,
+		ReviewerID:    "u1",
+		Verdict:       pr.VerdictApproved,
+		Comment:       "lgtm",
+	}).Return(nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{
+		{PullRequestID: prID, ReviewerID: "u1", Verdict: pr.VerdictApproved, Comment: "lgtm", CreatedAt: &now},
+	}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
+	resp, err := svc.SubmitReview(ctx, prID, "u1", pr.VerdictApproved, "lgtm")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, resp.AssignedReviewers)
+	assert.Equal(t, map[string]api.VerdictSchema{
+		"u1": {Verdict: pr.VerdictApproved, Comment: "lgtm", SubmittedAt: &now},
+	}, resp.Verdicts)
+}
+
+func TestPullRequestService_SubmitReview_NotAssigned(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-rev"
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrNotAssigned)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
+	_, err := svc.SubmitReview(ctx, prID, "stranger", pr.VerdictApproved, "")
+
+	assert.ErrorIs(t, err, repo.ErrNotAssigned)
+	reviewerProv.AssertNotCalled(t, "AddReview", mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_SubmitReview_MergedPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-rev"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrPRMerged)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusMerged}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.SubmitReview(ctx, prID, "u1", pr.VerdictChangesRequested, "")
+
+	assert.ErrorIs(t, err, repo.ErrPRMerged)
+}
+
+func TestPullRequestService_Merge_NotEnoughApprovals(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-policy"
+	required := 2
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrNoApprovals)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 3}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 3).
+		Return(&models.TeamSettings{TeamID: 3, ReviewersCount: 2, RequiredApprovals: &required}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{
+		{ReviewerID: "u1", Verdict: pr.VerdictApproved},
+		{ReviewerID: "u2", Verdict: pr.VerdictChangesRequested},
+	}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
+	resp, err := svc.Merge(ctx, prID)
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, repo.ErrNoApprovals)
+	prCtrl.AssertNotCalled(t, "MarkAsMerged", mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_SyncMerged_IgnoresPolicy(t *testing.T) {
+	for _, status := range []string{pr.StatusOpen, pr.StatusDraft, pr.StatusClosed} {
+		t.Run(status, func(t *testing.T) {
+			ctx := context.Background()
+			prID := "pr-synced"
+
+			prCtrl := mocks.NewPrController(t)
+			userGetter := mocks.NewUserGetter(t)
+			reviewerProv := mocks.NewReviewerProvider(t)
+			settingsProv := mocks.NewTeamSettingsProvider(t)
+			events := mocks.NewEventRecorder(t)
+			trm := newDoMock(t, ctx, nil)
+
+			// апрувов нет, но мерж уже случился во внешней системе
+			prCtrl.On("GetById", ctx, prID).
+				Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: status}, nil).Once()
+			prCtrl.On("MarkAsMerged", ctx, prID).Return(nil).Once()
+			events.On("AddEvents", ctx, mock.MatchedBy(func(e []*models.ReviewEvent) bool {
+				return len(e) == 1 && e[0].PullRequestID == prID
+			})).Return(nil).Once()
+			prCtrl.On("GetById", ctx, prID).
+				Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusMerged}, nil).Once()
+			reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1"}, nil).Once()
+			reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{
+				{ReviewerID: "u1", Verdict: pr.VerdictChangesRequested},
+			}, nil).Once()
+
+			svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{Events: events})
+			resp, err := svc.SyncMerged(ctx, prID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, pr.StatusMerged, resp.Status)
+			settingsProv.AssertNotCalled(t, "GetSettings", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPullRequestService_Merge_EnoughApprovals(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-policy"
+	required := 1
+	approved := []*models.Review{{ReviewerID: "u1", Verdict: pr.VerdictApproved}}
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 3}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 3).
+		Return(&models.TeamSettings{TeamID: 3, ReviewersCount: 2, RequiredApprovals: &required}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return(approved, nil).Twice()
+	prCtrl.On("MarkAsMerged", ctx, prID).Return(nil).Once()
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusMerged}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
+	resp, err := svc.Merge(ctx, prID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pr.StatusMerged, resp.Status)
+	assert.Equal(t, pr.VerdictApproved, resp.Verdicts["u1"].Verdict)
+}
@@ -0,0 +1,261 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_AddReviewer_Success(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-add"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen, NeedMoreReviewers: true}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1"}, nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	userGetter.On("GetById", ctx, "u2").Return(&models.User{ID: "u2", TeamID: 1, IsActive: true}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u1", "u2"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u2"}).Return(map[string]int{"u2": 3}, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u2").Return(nil).Once()
+	// PR укомплектован, флаг снимается
+	prCtrl.On("SetNeedMoreReviewers", ctx, prID, false).Return(nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return(nil, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
+	resp, err := svc.AddReviewer(ctx, prID, "u2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, resp.AssignedReviewers)
+	assert.False(t, resp.NeedMoreReviewers)
+	assert.Equal(t, map[string]int{"u2": 3}, resp.ReviewerLoads)
+}
+
+func TestPullRequestService_AddReviewer_Author(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-add"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrNoCandidate)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.AddReviewer(ctx, prID, "a1")
+
+	assert.ErrorIs(t, err, repo.ErrNoCandidate)
+}
+
+func TestPullRequestService_AddReviewer_Draft(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-add"
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrTransition)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusDraft}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
+	_, err := svc.AddReviewer(ctx, prID, "u2")
+
+	assert.ErrorIs(t, err, repo.ErrTransition)
+	reviewerProv.AssertNotCalled(t, "AssignReviewer", mock.Anything, mock.Anything, mock.Anything)
+	prCtrl.AssertNotCalled(t, "SetNeedMoreReviewers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_AddReviewer_LimitReached(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-add"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrNoCandidate)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1", "u2"}, nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
+	_, err := svc.AddReviewer(ctx, prID, "u3")
+
+	assert.ErrorIs(t, err, repo.ErrNoCandidate)
+	reviewerProv.AssertNotCalled(t, "AssignReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_AddReviewer_InactiveUser(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-add"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrNoCandidate)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{}, nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	userGetter.On("GetById", ctx, "u3").Return(&models.User{ID: "u3", TeamID: 1, IsActive: false}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
+	_, err := svc.AddReviewer(ctx, prID, "u3")
+
+	assert.ErrorIs(t, err, repo.ErrNoCandidate)
+}
+
+func TestPullRequestService_AddReviewer_AbsentUser(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-add"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrNoCandidate)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{}, nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	userGetter.On("GetById", ctx, "u3").Return(&models.User{ID: "u3", TeamID: 1, IsActive: true}, nil).Once()
+	// u3 активен, но в отпуске
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u2"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
+	_, err := svc.AddReviewer(ctx, prID, "u3")
+
+	assert.ErrorIs(t, err, repo.ErrNoCandidate)
+	reviewerProv.AssertNotCalled(t, "AssignReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_AddReviewer_MaxOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-add"
+	maxOpen := 2
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrNoCandidate)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{}, nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	// лимит открытых ревью берется из команды назначаемого
+	userGetter.On("GetById", ctx, "u9").Return(&models.User{ID: "u9", TeamID: 2, IsActive: true}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 2).Return([]string{"u9"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 2).
+		Return(&models.TeamSettings{TeamID: 2, ReviewersCount: 1, MaxOpenReviews: &maxOpen}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u9"}).Return(map[string]int{"u9": 2}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
+	_, err := svc.AddReviewer(ctx, prID, "u9")
+
+	assert.ErrorIs(t, err, repo.ErrNoCandidate)
+	reviewerProv.AssertNotCalled(t, "AssignReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_AddReviewer_MergedPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-add"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrPRMerged)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusMerged}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.AddReviewer(ctx, prID, "u1")
+
+	assert.ErrorIs(t, err, repo.ErrPRMerged)
+}
+
+func TestPullRequestService_RemoveReviewer_Success(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-rm"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1", "u2"}, nil).Once()
+	reviewerProv.On("DeleteReviewer", ctx, prID, "u1").Return(nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	prCtrl.On("SetNeedMoreReviewers", ctx, prID, true).Return(nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return(nil, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
+	resp, err := svc.RemoveReviewer(ctx, prID, "u1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, resp.AssignedReviewers)
+	assert.True(t, resp.NeedMoreReviewers)
+}
+
+func TestPullRequestService_RemoveReviewer_NotAssigned(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-rm"
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrNotAssigned)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u2"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
+	_, err := svc.RemoveReviewer(ctx, prID, "u1")
+
+	assert.ErrorIs(t, err, repo.ErrNotAssigned)
+	reviewerProv.AssertNotCalled(t, "DeleteReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_RemoveReviewer_ClosedPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-rm"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrPRClosed)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusClosed}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.RemoveReviewer(ctx, prID, "u1")
+
+	assert.ErrorIs(t, err, repo.ErrPRClosed)
+}
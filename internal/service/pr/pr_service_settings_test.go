@@ -0,0 +1,139 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_Create_UsesTeamReviewersCount(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-three"
+	authorID := "a1"
+	teamID := 5
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: teamID}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return([]string{"a1", "u1", "u2", "u3", "u4"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, teamID).Return(&models.TeamSettings{TeamID: teamID, ReviewersCount: 3}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.AnythingOfType("[]string")).Return(map[string]int{}, nil).Once()
+	prCtrl.On("Create", ctx, mock.MatchedBy(func(p *models.PullRequest) bool {
+		return !p.NeedMoreReviewers
+	})).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, mock.AnythingOfType("string")).Return(nil).Times(3)
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, "Three reviewers", authorID, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.AssignedReviewers, 3)
+	assert.NotContains(t, resp.AssignedReviewers, authorID)
+	assert.False(t, resp.NeedMoreReviewers)
+}
+
+func TestPullRequestService_Create_SkipsReviewersAtCapacity(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-cap"
+	authorID := "a1"
+	teamID := 6
+	maxOpen := 2
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	settings := &models.TeamSettings{TeamID: teamID, ReviewersCount: 2, MaxOpenReviews: &maxOpen}
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: teamID}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return([]string{"a1", "u1", "u2", "u3"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, teamID).Return(settings, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u1", "u2", "u3"}).
+		Return(map[string]int{"u1": 2, "u2": 1, "u3": 5}, nil).Once()
+	// u1 и u3 достигли лимита и не попадают в стратегию
+	selector.On("Select", ctx, teamID, []string{"u2"}, 2).Return([]string{"u2"}, nil).Once()
+	prCtrl.On("Create", ctx, mock.MatchedBy(func(p *models.PullRequest) bool {
+		return p.NeedMoreReviewers
+	})).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u2").Return(nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.Create(ctx, prID, "Capacity", authorID, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, resp.AssignedReviewers)
+	assert.Equal(t, map[string]int{"u2": 1}, resp.ReviewerLoads)
+	assert.True(t, resp.NeedMoreReviewers)
+}
+
+func TestPullRequestService_Reassign_NoCandidate_AllAtCapacity(t *testing.T) {
+	ctx := context.Background()
+	prID := "re-cap"
+	maxOpen := 1
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	current := &models.PullRequest{ID: prID, Title: "Capacity", AuthorId: "a1", Status: pr.StatusOpen}
+	settings := &models.TeamSettings{TeamID: 9, ReviewersCount: 2, MaxOpenReviews: &maxOpen}
+
+	prCtrl.On("GetById", ctx, prID).Return(current, nil).Once()
+	userGetter.On("GetById", ctx, "r1").Return(&models.User{ID: "r1", TeamID: 9}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 9).Return([]string{"a1", "r1", "r2"}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"r1"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 9).Return(settings, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"r2"}).Return(map[string]int{"r2": 1}, nil).Twice()
+	selector.On("Select", ctx, 9, []string{}, 1).Return([]string{}, nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			err := fn(ctx)
+			assert.ErrorIs(t, err, repo.ErrNoCandidate)
+			// причина - лимит, а не отсутствие активных кандидатов
+			assert.ErrorContains(t, err, "max open reviews")
+		}).Return(repo.ErrNoCandidate).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.Reassign(ctx, prID, "r1")
+
+	assert.Nil(t, resp)
+	assert.Equal(t, repo.ErrNoCandidate, err)
+	reviewerProv.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,187 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDoMock(t *testing.T, ctx context.Context, wantErr error) *mocks.MockManager {
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), wantErr)
+		}).Return(wantErr).Once()
+
+	return trm
+}
+
+func TestPullRequestService_CreateDraft_NoReviewers(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	prCtrl.On("Create", ctx, mock.MatchedBy(func(p *models.PullRequest) bool {
+		return p.Status == pr.StatusDraft && !p.NeedMoreReviewers
+	})).Return("pr-d", nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, userGetter, nil, nil, pr.Options{})
+	resp, err := svc.CreateDraft(ctx, "pr-d", "Draft PR", "a1", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pr.StatusDraft, resp.Status)
+	assert.Empty(t, resp.AssignedReviewers)
+}
+
+func TestPullRequestService_Ready_AssignsReviewers(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-d"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := newDoMock(t, ctx, nil)
+
+	draft := &models.PullRequest{ID: prID, Title: "Draft PR", AuthorId: "a1", Status: pr.StatusDraft}
+	opened := &models.PullRequest{ID: prID, Title: "Draft PR", AuthorId: "a1", Status: pr.StatusOpen}
+
+	prCtrl.On("GetById", ctx, prID).Return(draft, nil).Once()
+	prCtrl.On("SetStatus", ctx, prID, pr.StatusOpen).Return(nil).Once()
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 2}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 2).Return([]string{"a1", "u1", "u2"}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 2).Return(&models.TeamSettings{TeamID: 2, ReviewersCount: 2}, nil).Once()
+	selector.On("Select", ctx, 2, []string{"u1", "u2"}, 2).Return([]string{"u2", "u1"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u2", "u1"}).Return(map[string]int{"u2": 1, "u1": 0}, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u2").Return(nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u1").Return(nil).Once()
+	prCtrl.On("GetById", ctx, prID).Return(opened, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.Ready(ctx, prID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pr.StatusOpen, resp.Status)
+	assert.Equal(t, []string{"u2", "u1"}, resp.AssignedReviewers)
+	assert.False(t, resp.NeedMoreReviewers)
+	assert.Equal(t, map[string]int{"u2": 1, "u1": 0}, resp.ReviewerLoads)
+}
+
+func TestPullRequestService_Ready_ClosedPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-c"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrPRClosed)
+
+	prCtrl.On("GetById", ctx, prID).Return(&models.PullRequest{ID: prID, Status: pr.StatusClosed}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	resp, err := svc.Ready(ctx, prID)
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, repo.ErrPRClosed)
+}
+
+func TestPullRequestService_Close_OpenPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-o"
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, prID).Return(&models.PullRequest{ID: prID, Status: pr.StatusOpen}, nil).Once()
+	prCtrl.On("SetStatus", ctx, prID, pr.StatusClosed).Return(nil).Once()
+	prCtrl.On("GetById", ctx, prID).Return(&models.PullRequest{ID: prID, Status: pr.StatusClosed}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1"}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
+	resp, err := svc.Close(ctx, prID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, pr.StatusClosed, resp.Status)
+	assert.Equal(t, []string{"u1"}, resp.AssignedReviewers)
+}
+
+func TestPullRequestService_Close_MergedPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-m"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrPRMerged)
+
+	prCtrl.On("GetById", ctx, prID).Return(&models.PullRequest{ID: prID, Status: pr.StatusMerged}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.Close(ctx, prID)
+
+	assert.ErrorIs(t, err, repo.ErrPRMerged)
+	prCtrl.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_Reopen_DraftPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-d"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrTransition)
+
+	prCtrl.On("GetById", ctx, prID).Return(&models.PullRequest{ID: prID, Status: pr.StatusDraft}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.Reopen(ctx, prID)
+
+	assert.ErrorIs(t, err, repo.ErrTransition)
+}
+
+func TestPullRequestService_Merge_ClosedPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-c"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrPRClosed)
+
+	prCtrl.On("GetById", ctx, prID).Return(&models.PullRequest{ID: prID, Status: pr.StatusClosed}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.Merge(ctx, prID)
+
+	assert.ErrorIs(t, err, repo.ErrPRClosed)
+	prCtrl.AssertNotCalled(t, "MarkAsMerged", mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_Reassign_ClosedPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-c"
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrPRClosed)
+
+	prCtrl.On("GetById", ctx, prID).Return(&models.PullRequest{ID: prID, Status: pr.StatusClosed}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
+	_, err := svc.Reassign(ctx, prID, "u1")
+
+	assert.ErrorIs(t, err, repo.ErrPRClosed)
+	reviewerProv.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -25,6 +25,7 @@ func TestPullRequestService_Create_Success_NoAvailableCandidates(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
@@ -36,6 +37,8 @@ func TestPullRequestService_Create_Success_NoAvailableCandidates(t *testing.T) {
 	userGetter.On("GetById", ctx, authorID).Return(author, nil).Once()
 	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return(activeUsers, nil).Once()
 	// AssignReviewer should NOT be called because there are no candidates
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -43,8 +46,8 @@ func TestPullRequestService_Create_Success_NoAvailableCandidates(t *testing.T) {
 			assert.NoError(t, fn(ctx))
 		}).Return(nil).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
-	resp, err := svc.Create(ctx, prID, prName, authorID)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, prName, authorID, nil, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
@@ -65,6 +68,7 @@ func TestPullRequestService_Create_AssignReviewerFailsOnSecond(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
@@ -83,6 +87,9 @@ func TestPullRequestService_Create_AssignReviewerFailsOnSecond(t *testing.T) {
 	reviewerProv.
 		On("AssignReviewer", ctx, prID, mock.AnythingOfType("string")).
 		Return(assignErr).Once() // second assignment fails
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.AnythingOfType("[]string")).Return(map[string]int{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -92,8 +99,8 @@ func TestPullRequestService_Create_AssignReviewerFailsOnSecond(t *testing.T) {
 			assert.Equal(t, assignErr, err)
 		}).Return(assignErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
-	resp, err := svc.Create(ctx, prID, prName, authorID)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, prName, authorID, nil, nil)
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
@@ -110,6 +117,7 @@ func TestPullRequestService_Create_GetActiveUsersError(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
@@ -130,8 +138,8 @@ func TestPullRequestService_Create_GetActiveUsersError(t *testing.T) {
 			assert.Equal(t, activeErr, err)
 		}).Return(activeErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
-	resp, err := svc.Create(ctx, prID, prName, authorID)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, prName, authorID, nil, nil)
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
@@ -148,6 +156,8 @@ func TestPullRequestService_Merge_Success_FromOpen(t *testing.T) {
 
 	prCtrl := mocks.NewPrController(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	userGetter := mocks.NewUserGetter(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
@@ -158,9 +168,12 @@ func TestPullRequestService_Merge_Success_FromOpen(t *testing.T) {
 	reviewers := []string{"r1", "r2"}
 
 	prCtrl.On("GetById", ctx, prID).Return(open, nil).Once()
+	userGetter.On("GetById", ctx, open.AuthorId).Return(&models.User{ID: open.AuthorId, TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
 	prCtrl.On("MarkAsMerged", ctx, prID).Return(nil).Once()
 	prCtrl.On("GetById", ctx, prID).Return(merged, nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(reviewers, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -168,7 +181,7 @@ func TestPullRequestService_Merge_Success_FromOpen(t *testing.T) {
 			assert.NoError(t, fn(ctx))
 		}).Return(nil).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.NoError(t, err)
@@ -205,6 +218,7 @@ func TestPullRequestService_Merge_Success_AlreadyMerged_NoMarkCall(t *testing.T)
 	// The service requests it again after the (skipped) merge branch
 	prCtrl.On("GetById", ctx, prID).Return(merged, nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(reviewers, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return([]*models.Review{}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -212,7 +226,7 @@ func TestPullRequestService_Merge_Success_AlreadyMerged_NoMarkCall(t *testing.T)
 			assert.NoError(t, fn(ctx))
 		}).Return(nil).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.NoError(t, err)
@@ -244,7 +258,7 @@ func TestPullRequestService_Merge_Error_FirstGetById(t *testing.T) {
 			assert.Equal(t, getErr, err)
 		}).Return(getErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.Nil(t, resp)
@@ -258,6 +272,8 @@ func TestPullRequestService_Merge_Error_SecondGetById(t *testing.T) {
 
 	prCtrl := mocks.NewPrController(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	userGetter := mocks.NewUserGetter(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
@@ -266,6 +282,8 @@ func TestPullRequestService_Merge_Error_SecondGetById(t *testing.T) {
 	secondErr := errors.New("second get error")
 
 	prCtrl.On("GetById", ctx, prID).Return(open, nil).Once()
+	userGetter.On("GetById", ctx, open.AuthorId).Return(&models.User{ID: open.AuthorId, TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
 	prCtrl.On("MarkAsMerged", ctx, prID).Return(nil).Once()
 	prCtrl.On("GetById", ctx, prID).Return((*models.PullRequest)(nil), secondErr).Once()
 
@@ -277,7 +295,7 @@ func TestPullRequestService_Merge_Error_SecondGetById(t *testing.T) {
 			assert.Equal(t, secondErr, err)
 		}).Return(secondErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.Nil(t, resp)
@@ -310,7 +328,7 @@ func TestPullRequestService_Merge_Error_GetReviewers(t *testing.T) {
 			assert.Equal(t, revErr, err)
 		}).Return(revErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
 	assert.Nil(t, resp)
@@ -318,40 +336,29 @@ func TestPullRequestService_Merge_Error_GetReviewers(t *testing.T) {
 	assert.Equal(t, revErr, err)
 }
 
-func TestPullRequestService_Merge_Ignores_MarkAsMergedError(t *testing.T) {
+func TestPullRequestService_Merge_MarkAsMergedError(t *testing.T) {
 	ctx := context.Background()
-	prID := "merge-ign-1"
+	prID := "merge-err-1"
 
 	prCtrl := mocks.NewPrController(t)
-	reviewerProv := mocks.NewReviewerProvider(t)
-	trm := &mocks.MockManager{}
-	trm.Test(t)
-	t.Cleanup(func() { trm.AssertExpectations(t) })
+	userGetter := mocks.NewUserGetter(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	mergeErr := errors.New("merge failed")
+	trm := newDoMock(t, ctx, mergeErr)
 
 	open := &models.PullRequest{ID: prID, Title: "X", AuthorId: "a1", Status: pr.StatusOpen}
-	merged := &models.PullRequest{ID: prID, Title: "X", AuthorId: "a1", Status: pr.StatusMerged}
-	reviewers := []string{"r1"}
-	mergeErr := errors.New("merge failed") // Will be ignored by implementation
 
 	prCtrl.On("GetById", ctx, prID).Return(open, nil).Once()
+	userGetter.On("GetById", ctx, open.AuthorId).Return(&models.User{ID: open.AuthorId, TeamID: 1}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
 	prCtrl.On("MarkAsMerged", ctx, prID).Return(mergeErr).Once()
-	prCtrl.On("GetById", ctx, prID).Return(merged, nil).Once()
-	reviewerProv.On("GetPrReviewers", ctx, prID).Return(reviewers, nil).Once()
 
-	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
-		Run(func(args mock.Arguments) {
-			fn := args.Get(1).(func(context.Context) error)
-			// Despite mark error, flow continues and returns success
-			assert.NoError(t, fn(ctx))
-		}).Return(nil).Once()
-
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil)
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, userGetter, settingsProv, nil, pr.Options{})
 	resp, err := svc.Merge(ctx, prID)
 
-	assert.NoError(t, err)
-	assert.NotNil(t, resp)
-	assert.Equal(t, pr.StatusMerged, resp.Status)
-	assert.Equal(t, reviewers, resp.AssignedReviewers)
+	// ошибка обновления откатывает транзакцию, PR не перечитывается
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, mergeErr)
 }
 
 func TestPullRequestService_Reassign_Success(t *testing.T) {
@@ -362,22 +369,26 @@ func TestPullRequestService_Reassign_Success(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
 
 	current := &models.PullRequest{ID: prID, Title: "Reassign", AuthorId: "a1", Status: pr.StatusOpen}
-	author := &models.User{ID: "a1", TeamID: 77}
+	oldReviewer := &models.User{ID: oldRev, TeamID: 77}
 	active := []string{"a1", "r1", "r2", "r3"}
 	assigned := []string{"r1", "r2"}
 	final := []string{"r2", "r3"} // after reassign
 
 	prCtrl.On("GetById", ctx, prID).Return(current, nil).Twice()
-	userGetter.On("GetById", ctx, "a1").Return(author, nil).Once()
+	userGetter.On("GetById", ctx, oldRev).Return(oldReviewer, nil).Once()
 	userGetter.On("GetActiveUsersIDInTeam", ctx, 77).Return(active, nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(assigned, nil).Once()
 	reviewerProv.On("ReassignReviewer", ctx, prID, oldRev, mock.AnythingOfType("string")).Return(nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(final, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.AnythingOfType("[]string")).Return(map[string]int{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -385,7 +396,7 @@ func TestPullRequestService_Reassign_Success(t *testing.T) {
 			assert.NoError(t, fn(ctx))
 		}).Return(nil).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
 	resp, err := svc.Reassign(ctx, prID, oldRev)
 
 	assert.NoError(t, err)
@@ -407,17 +418,18 @@ func TestPullRequestService_Reassign_NoCandidate(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
 
 	current := &models.PullRequest{ID: prID, Title: "No cand", AuthorId: "a1", Status: pr.StatusOpen}
-	author := &models.User{ID: "a1", TeamID: 5}
+	oldReviewer := &models.User{ID: oldRev, TeamID: 5}
 	active := []string{"a1", "b1"} // excluded = author + assigned = active -> no candidate
 	assigned := []string{"b1"}
 
 	prCtrl.On("GetById", ctx, prID).Return(current, nil).Once()
-	userGetter.On("GetById", ctx, "a1").Return(author, nil).Once()
+	userGetter.On("GetById", ctx, oldRev).Return(oldReviewer, nil).Once()
 	userGetter.On("GetActiveUsersIDInTeam", ctx, 5).Return(active, nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(assigned, nil).Once()
 
@@ -429,7 +441,7 @@ func TestPullRequestService_Reassign_NoCandidate(t *testing.T) {
 			assert.Equal(t, repo.ErrNoCandidate, err)
 		}).Return(repo.ErrNoCandidate).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
 	resp, err := svc.Reassign(ctx, prID, oldRev)
 
 	assert.Nil(t, resp)
@@ -446,21 +458,25 @@ func TestPullRequestService_Reassign_ReassignReviewerError(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
 
 	current := &models.PullRequest{ID: prID, Title: "Reassign err", AuthorId: "a1", Status: pr.StatusOpen}
-	author := &models.User{ID: "a1", TeamID: 3}
+	oldReviewer := &models.User{ID: oldRev, TeamID: 3}
 	active := []string{"a1", "r1", "r2"}
 	assigned := []string{"r1"}
 	reassignErr := errors.New("reassign failed")
 
 	prCtrl.On("GetById", ctx, prID).Return(current, nil).Once()
-	userGetter.On("GetById", ctx, "a1").Return(author, nil).Once()
+	userGetter.On("GetById", ctx, oldRev).Return(oldReviewer, nil).Once()
 	userGetter.On("GetActiveUsersIDInTeam", ctx, 3).Return(active, nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(assigned, nil).Once()
 	reviewerProv.On("ReassignReviewer", ctx, prID, oldRev, mock.AnythingOfType("string")).Return(reassignErr).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.AnythingOfType("[]string")).Return(map[string]int{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -470,7 +486,7 @@ func TestPullRequestService_Reassign_ReassignReviewerError(t *testing.T) {
 			assert.Equal(t, reassignErr, err)
 		}).Return(reassignErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
 	resp, err := svc.Reassign(ctx, prID, oldRev)
 
 	assert.Nil(t, resp)
@@ -486,6 +502,7 @@ func TestPullRequestService_Reassign_GetByIdError(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
@@ -501,7 +518,7 @@ func TestPullRequestService_Reassign_GetByIdError(t *testing.T) {
 			assert.Equal(t, getErr, err)
 		}).Return(getErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
 	resp, err := svc.Reassign(ctx, prID, oldRev)
 
 	assert.Nil(t, resp)
@@ -517,16 +534,18 @@ func TestPullRequestService_Reassign_GetActiveUsersError(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
 
 	current := &models.PullRequest{ID: prID, Title: "Err active", AuthorId: "a1", Status: pr.StatusOpen}
-	author := &models.User{ID: "a1", TeamID: 13}
+	oldReviewer := &models.User{ID: oldRev, TeamID: 13}
 	actErr := errors.New("active users failed")
 
 	prCtrl.On("GetById", ctx, prID).Return(current, nil).Once()
-	userGetter.On("GetById", ctx, "a1").Return(author, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{oldRev}, nil).Once()
+	userGetter.On("GetById", ctx, oldRev).Return(oldReviewer, nil).Once()
 	userGetter.On("GetActiveUsersIDInTeam", ctx, 13).Return(([]string)(nil), actErr).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
@@ -536,7 +555,7 @@ func TestPullRequestService_Reassign_GetActiveUsersError(t *testing.T) {
 		assert.Equal(t, actErr, err)
 	}).Return(actErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
 	resp, err := svc.Reassign(ctx, prID, oldRev)
 
 	assert.Nil(t, resp)
@@ -552,17 +571,15 @@ func TestPullRequestService_Reassign_GetReviewersError(t *testing.T) {
 	prCtrl := mocks.NewPrController(t)
 	userGetter := mocks.NewUserGetter(t)
 	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
 	trm := &mocks.MockManager{}
 	trm.Test(t)
 	t.Cleanup(func() { trm.AssertExpectations(t) })
 
 	current := &models.PullRequest{ID: prID, Title: "Err reviewers", AuthorId: "a1", Status: pr.StatusOpen}
-	author := &models.User{ID: "a1", TeamID: 1}
 	revErr := errors.New("get reviewers failed")
 
 	prCtrl.On("GetById", ctx, prID).Return(current, nil).Once()
-	userGetter.On("GetById", ctx, "a1").Return(author, nil).Once()
-	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "r1", "r2"}, nil).Once()
 	reviewerProv.On("GetPrReviewers", ctx, prID).Return(([]string)(nil), revErr).Once()
 
 	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
@@ -572,7 +589,7 @@ func TestPullRequestService_Reassign_GetReviewersError(t *testing.T) {
 		assert.Equal(t, revErr, err)
 	}).Return(revErr).Once()
 
-	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter)
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
 	resp, err := svc.Reassign(ctx, prID, oldRev)
 
 	assert.Nil(t, resp)
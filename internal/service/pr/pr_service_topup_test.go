@@ -0,0 +1,127 @@
+package pr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_Create_SetsNeedMoreReviewers(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-need"
+	authorID := "a1"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{authorID, "u2"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u2"}).Return(map[string]int{"u2": 0}, nil).Once()
+	prCtrl.On("Create", ctx, mock.MatchedBy(func(p *models.PullRequest) bool {
+		return p.ID == prID && p.NeedMoreReviewers
+	})).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u2").Return(nil).Once()
+	settingsProv.On("GetSettings", ctx, mock.AnythingOfType("int")).
+		Return(&models.TeamSettings{ReviewersCount: 2}, nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, prID, "Only one reviewer", authorID, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, resp.AssignedReviewers)
+	assert.True(t, resp.NeedMoreReviewers)
+}
+
+func TestPullRequestService_TopUpReviewers_FillsAndClearsFlag(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	understaffed := []*models.PullRequest{
+		{ID: "pr-1", Title: "One reviewer", AuthorId: "a1", Status: pr.StatusOpen, NeedMoreReviewers: true},
+		{ID: "pr-2", Title: "Still alone", AuthorId: "a2", Status: pr.StatusOpen, NeedMoreReviewers: true},
+	}
+
+	prCtrl.On("GetUnderstaffed", ctx).Return(understaffed, nil).Once()
+
+	// pr-1: в команде появился u3
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u2", "u3"}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr-1").Return([]string{"u2"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	selector.On("Select", ctx, 1, []string{"u3"}, 1).Return([]string{"u3"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u3"}).Return(map[string]int{"u3": 0}, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, "pr-1", "u3").Return(nil).Once()
+	prCtrl.On("SetNeedMoreReviewers", ctx, "pr-1", false).Return(nil).Once()
+
+	// pr-2: новых участников нет
+	userGetter.On("GetById", ctx, "a2").Return(&models.User{ID: "a2", TeamID: 2}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 2).Return([]string{"a2"}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr-2").Return([]string{}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 2).Return(&models.TeamSettings{TeamID: 2, ReviewersCount: 2}, nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.TopUpReviewers(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, "pr-1", resp[0].ID)
+	assert.Equal(t, []string{"u2", "u3"}, resp[0].AssignedReviewers)
+	assert.False(t, resp[0].NeedMoreReviewers)
+	assert.Equal(t, map[string]int{"u3": 0}, resp[0].ReviewerLoads)
+}
+
+func TestPullRequestService_TopUpReviewers_GetUnderstaffedError(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	getErr := errors.New("db error")
+	prCtrl.On("GetUnderstaffed", ctx).Return(nil, getErr).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.Equal(t, getErr, fn(ctx))
+		}).Return(getErr).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	resp, err := svc.TopUpReviewers(ctx)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, getErr, err)
+}
@@ -0,0 +1,218 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"context"
+	"errors"
+	"slices"
+)
+
+// Причины, по которым участник команды не попал в пул кандидатов.
+const (
+	ReasonAuthor             = "author"
+	ReasonReplaced           = "replaced"
+	ReasonAlreadyAssigned    = "already_assigned"
+	ReasonInactive           = "inactive"
+	ReasonAbsent             = "absent"
+	ReasonConflictOfInterest = "conflict_of_interest"
+	ReasonAtCapacity         = "at_capacity"
+)
+
+// PreviewCreate показывает, кого назначил бы Create для PR автора, и почему остальные
+// участники команды не подошли. Ничего не записывает и не сдвигает состояние стратегии,
+// но при случайной стратегии итог может отличаться от настоящего вызова.
+func (s *PullRequestService) PreviewCreate(
+	ctx context.Context,
+	authorID string,
+	files, labels []string,
+) (*api.AssignmentPreviewResponse, error) {
+	pr := &models.PullRequest{
+		AuthorId: authorID,
+		Status:   StatusOpen,
+		Labels:   lib.NormalizeTags(labels),
+	}
+
+	resp := &api.AssignmentPreviewResponse{AuthorID: authorID}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		author, err := s.userGetter.GetById(ctx, authorID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.explainPool(ctx, resp, author.TeamID, authorID, nil, ""); err != nil {
+			return err
+		}
+
+		plan, err := s.forPreview().planCreate(ctx, pr, files)
+		if err != nil {
+			return previewError(resp, err)
+		}
+
+		resp.CodeOwners = plan.owners
+		resp.Picked = plan.reviewers
+		resp.ReviewerLoads = plan.loads
+		resp.NeedMoreReviewers = len(plan.reviewers) < plan.settings.ReviewersCount
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PreviewReassign показывает, кем Reassign заменил бы ревьюера oldRev, ничего не записывая.
+func (s *PullRequestService) PreviewReassign(ctx context.Context, prID, oldRev string) (*api.AssignmentPreviewResponse, error) {
+	resp := &api.AssignmentPreviewResponse{ReplacedReviewer: oldRev}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		pr, err := s.prController.GetById(ctx, prID)
+		if err != nil {
+			return err
+		}
+		resp.AuthorID = pr.AuthorId
+
+		if err := checkNotFrozen(pr); err != nil {
+			return err
+		}
+
+		assigned, err := s.reviewerProvider.GetPrReviewers(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(assigned, oldRev) {
+			return repo.ErrNotAssigned
+		}
+
+		oldReviewer, err := s.userGetter.GetById(ctx, oldRev)
+		if err != nil {
+			return err
+		}
+
+		if err := s.explainPool(ctx, resp, oldReviewer.TeamID, pr.AuthorId, assigned, oldRev); err != nil {
+			return err
+		}
+
+		plan, err := s.forPreview().planReassign(ctx, pr, assigned, oldRev)
+		if err != nil {
+			return previewError(resp, err)
+		}
+
+		resp.Picked = plan.reviewers
+		resp.ReviewerLoads = plan.loads
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// forPreview возвращает копию сервиса с копией стратегии выбора, чтобы предпросмотр
+// не сдвигал, например, позицию round robin для следующего настоящего назначения.
+func (s *PullRequestService) forPreview() *PullRequestService {
+	preview := *s
+	preview.selector = dryRun(s.selector)
+	return &preview
+}
+
+// explainPool раскладывает участников команды на пул кандидатов и исключенных с причиной.
+// Проверки идут в том же порядке, что и при подборе, у каждого исключенного - первая сработавшая.
+func (s *PullRequestService) explainPool(
+	ctx context.Context,
+	resp *api.AssignmentPreviewResponse,
+	teamID int,
+	authorID string,
+	assigned []string,
+	replaced string,
+) error {
+	members, err := s.userGetter.GetTeamMembers(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	active, err := s.userGetter.GetActiveUsersIDInTeam(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	excluded, err := s.excludedReviewers(ctx, authorID)
+	if err != nil {
+		return err
+	}
+
+	settings, err := s.settingsProvider.GetSettings(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	resp.Eligible = []string{}
+	resp.Excluded = []api.ExcludedCandidate{}
+	resp.Picked = []string{}
+
+	var pool []string
+	for _, m := range members {
+		reason := ""
+		switch {
+		case m.ID == authorID:
+			reason = ReasonAuthor
+		case m.ID == replaced:
+			reason = ReasonReplaced
+		case slices.Contains(assigned, m.ID):
+			reason = ReasonAlreadyAssigned
+		case !m.IsActive:
+			reason = ReasonInactive
+		case !slices.Contains(active, m.ID):
+			reason = ReasonAbsent
+		case slices.Contains(excluded, m.ID):
+			reason = ReasonConflictOfInterest
+		}
+
+		if reason != "" {
+			resp.Excluded = append(resp.Excluded, api.ExcludedCandidate{UserID: m.ID, Reason: reason})
+			continue
+		}
+		pool = append(pool, m.ID)
+	}
+
+	limits, err := s.reviewLimits(ctx, settings, pool)
+	if err != nil {
+		return err
+	}
+
+	var loads map[string]int
+	if len(limits) > 0 && len(pool) > 0 {
+		loads, err = s.reviewerProvider.GetOpenReviewsCount(ctx, pool)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range pool {
+		if atCapacity(limits, loads, id) {
+			resp.Excluded = append(resp.Excluded, api.ExcludedCandidate{UserID: id, Reason: ReasonAtCapacity})
+			continue
+		}
+		resp.Eligible = append(resp.Eligible, id)
+	}
+
+	return nil
+}
+
+// previewError переносит в ответ ошибки подбора, которыми завершился бы настоящий вызов.
+// Остальные ошибки возвращаются как есть.
+func previewError(resp *api.AssignmentPreviewResponse, err error) error {
+	switch {
+	case errors.Is(err, repo.ErrExcludedByRules):
+		resp.Error = &api.ErrorDetail{Code: api.ErrCodeExcludedByRules, Message: err.Error()}
+	case errors.Is(err, repo.ErrNoCandidate):
+		resp.Error = &api.ErrorDetail{Code: api.ErrCodeNoCandidate, Message: err.Error()}
+	default:
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_PreviewCreate_ExplainsPool(t *testing.T) {
+	ctx := context.Background()
+	maxOpen := 2
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	exclusions := mocks.NewExclusionProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil)
+	userGetter.On("GetTeamMembers", ctx, 1).Return([]*models.User{
+		{ID: "a1", IsActive: true},
+		{ID: "u2", IsActive: false},
+		{ID: "u3", IsActive: true},
+		{ID: "u4", IsActive: true},
+		{ID: "u5", IsActive: true},
+		{ID: "u6", IsActive: true},
+	}, nil).Once()
+	// u3 в отпуске
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u4", "u5", "u6"}, nil)
+	settingsProv.On("GetSettings", ctx, 1).
+		Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2, MaxOpenReviews: &maxOpen}, nil)
+	exclusions.On("GetExcludedReviewers", ctx, "a1").Return([]string{"u4"}, nil)
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u5", "u6"}).Return(map[string]int{"u5": 2, "u6": 0}, nil)
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{Exclusions: exclusions})
+	resp, err := svc.PreviewCreate(ctx, "a1", nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u6"}, resp.Eligible)
+	assert.Equal(t, []api.ExcludedCandidate{
+		{UserID: "a1", Reason: pr.ReasonAuthor},
+		{UserID: "u2", Reason: pr.ReasonInactive},
+		{UserID: "u3", Reason: pr.ReasonAbsent},
+		{UserID: "u4", Reason: pr.ReasonConflictOfInterest},
+		{UserID: "u5", Reason: pr.ReasonAtCapacity},
+	}, resp.Excluded)
+	assert.Equal(t, []string{"u6"}, resp.Picked)
+	assert.True(t, resp.NeedMoreReviewers)
+	assert.Nil(t, resp.Error)
+	prCtrl.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_PreviewCreate_AllExcludedReportsError(t *testing.T) {
+	ctx := context.Background()
+
+	userGetter := mocks.NewUserGetter(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	exclusions := mocks.NewExclusionProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil)
+	userGetter.On("GetTeamMembers", ctx, 1).Return([]*models.User{
+		{ID: "a1", IsActive: true},
+		{ID: "m1", IsActive: true},
+	}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "m1"}, nil)
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 1}, nil)
+	exclusions.On("GetExcludedReviewers", ctx, "a1").Return([]string{"m1"}, nil)
+
+	svc := pr.NewPullRequestService(trm, nil, nil, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{Exclusions: exclusions})
+	resp, err := svc.PreviewCreate(ctx, "a1", nil, nil)
+
+	// ошибка подбора попадает в ответ, а не в err, чтобы объяснение не терялось
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Eligible)
+	assert.Empty(t, resp.Picked)
+	if assert.NotNil(t, resp.Error) {
+		assert.Equal(t, api.ErrCodeExcludedByRules, resp.Error.Code)
+	}
+}
+
+func TestPullRequestService_PreviewReassign(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, "pr-1").
+		Return(&models.PullRequest{ID: "pr-1", AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr-1").Return([]string{"r1", "r2"}, nil).Once()
+	userGetter.On("GetById", ctx, "r1").Return(&models.User{ID: "r1", TeamID: 1}, nil)
+	userGetter.On("GetTeamMembers", ctx, 1).Return([]*models.User{
+		{ID: "a1", IsActive: true},
+		{ID: "r1", IsActive: true},
+		{ID: "r2", IsActive: true},
+		{ID: "u3", IsActive: true},
+	}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "r1", "r2", "u3"}, nil)
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil)
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u3"}).Return(map[string]int{"u3": 1}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.PreviewReassign(ctx, "pr-1", "r1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a1", resp.AuthorID)
+	assert.Equal(t, "r1", resp.ReplacedReviewer)
+	assert.Equal(t, []api.ExcludedCandidate{
+		{UserID: "a1", Reason: pr.ReasonAuthor},
+		{UserID: "r1", Reason: pr.ReasonReplaced},
+		{UserID: "r2", Reason: pr.ReasonAlreadyAssigned},
+	}, resp.Excluded)
+	assert.Equal(t, []string{"u3"}, resp.Picked)
+	reviewerProv.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_PreviewCreate_KeepsRoundRobinPosition(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Times(3)
+
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil)
+	userGetter.On("GetTeamMembers", ctx, 1).Return([]*models.User{
+		{ID: "a1", IsActive: true},
+		{ID: "u2", IsActive: true},
+		{ID: "u3", IsActive: true},
+		{ID: "u4", IsActive: true},
+	}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u4", "u3", "u2"}, nil)
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 1}, nil)
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.Anything).Return(map[string]int{}, nil)
+	prCtrl.On("Create", ctx, mock.Anything).Return(func(_ context.Context, p *models.PullRequest) string { return p.ID }, nil).Twice()
+	reviewerProv.On("AssignReviewer", ctx, mock.Anything, mock.Anything).Return(nil).Twice()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRoundRobinSelector(), pr.Options{})
+
+	preview, err := svc.PreviewCreate(ctx, "a1", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, preview.Picked)
+
+	// предпросмотр не сдвигает позицию: первый PR получает того же ревьюера, что и без него
+	first, err := svc.Create(ctx, "pr-1", "First", "a1", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, first.AssignedReviewers)
+
+	second, err := svc.Create(ctx, "pr-2", "Second", "a1", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, second.AssignedReviewers)
+}
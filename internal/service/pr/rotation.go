@@ -0,0 +1,70 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/models"
+	"context"
+	"slices"
+	"time"
+)
+
+// recentPairings считает, сколько раз кандидаты ревьюили автора в окне ротации команды.
+// nil - ротация в команде выключена.
+func (s *PullRequestService) recentPairings(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	authorID string,
+	candidates []string,
+) (map[string]int, error) {
+	switch {
+	case authorID == "" || len(candidates) == 0:
+		return nil, nil
+	case settings.RotationWindowPRs != nil:
+		return s.reviewerProvider.GetPairingsInLastPRs(ctx, authorID, candidates, *settings.RotationWindowPRs)
+	case settings.RotationWindowDays != nil:
+		since := time.Now().AddDate(0, 0, -*settings.RotationWindowDays)
+		return s.reviewerProvider.GetPairingsSince(ctx, authorID, candidates, since)
+	default:
+		return nil, nil
+	}
+}
+
+// selectRotated отдает стратегии кандидатов группами по числу недавних пар с автором:
+// частые напарники выбираются, только если реже встречавшихся не хватило.
+// Без ротации - обычный выбор.
+func (s *PullRequestService) selectRotated(
+	ctx context.Context,
+	teamID int,
+	candidates []string,
+	count int,
+	pairings map[string]int,
+) ([]string, error) {
+	if pairings == nil {
+		return s.selector.Select(ctx, teamID, candidates, count)
+	}
+
+	tiers := map[int][]string{}
+	for _, id := range candidates {
+		tiers[pairings[id]] = append(tiers[pairings[id]], id)
+	}
+
+	levels := make([]int, 0, len(tiers))
+	for n := range tiers {
+		levels = append(levels, n)
+	}
+	slices.Sort(levels)
+
+	picked := []string{}
+	for _, n := range levels {
+		if len(picked) >= count {
+			break
+		}
+
+		ids, err := s.selector.Select(ctx, teamID, tiers[n], count-len(picked))
+		if err != nil {
+			return nil, err
+		}
+		picked = append(picked, ids...)
+	}
+
+	return picked, nil
+}
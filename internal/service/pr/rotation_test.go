@@ -0,0 +1,77 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// createWithRotation создает PR автора a1 в команде из u2, u3, u4, где u2 и u3 недавно ревьюили автора.
+func createWithRotation(t *testing.T, settings *models.TeamSettings) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	pairings := map[string]int{"u2": 2, "u3": 1}
+
+	settings.TeamID = 1
+	userGetter.On("GetById", ctx, "a1").Return(&models.User{ID: "a1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "u2", "u3", "u4"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(settings, nil).Once()
+	if settings.RotationWindowPRs != nil {
+		reviewerProv.On("GetPairingsInLastPRs", ctx, "a1", mock.Anything, *settings.RotationWindowPRs).
+			Return(pairings, nil).Once()
+	} else {
+		reviewerProv.On("GetPairingsSince", ctx, "a1", mock.Anything, mock.AnythingOfType("time.Time")).
+			Return(pairings, nil).Once()
+	}
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.Anything).Return(map[string]int{}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return("pr-1", nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, "pr-1", mock.Anything).Return(nil)
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, "pr-1", "Rotation", "a1", nil, nil)
+
+	assert.NoError(t, err)
+	return resp.AssignedReviewers
+}
+
+func TestPullRequestService_Create_RotationPrefersNewPairs(t *testing.T) {
+	lastPRs := 5
+
+	for range 10 {
+		reviewers := createWithRotation(t, &models.TeamSettings{ReviewersCount: 2, RotationWindowPRs: &lastPRs})
+
+		// u2 - самый частый напарник автора, он остается без назначения
+		assert.ElementsMatch(t, []string{"u3", "u4"}, reviewers)
+	}
+}
+
+func TestPullRequestService_Create_RotationByDays(t *testing.T) {
+	days := 14
+
+	for range 10 {
+		reviewers := createWithRotation(t, &models.TeamSettings{ReviewersCount: 1, RotationWindowDays: &days})
+
+		assert.Equal(t, []string{"u4"}, reviewers)
+	}
+}
+
+func TestPullRequestService_Create_RotationFallsBackToFrequentPairs(t *testing.T) {
+	lastPRs := 5
+
+	reviewers := createWithRotation(t, &models.TeamSettings{ReviewersCount: 3, RotationWindowPRs: &lastPRs})
+
+	assert.ElementsMatch(t, []string{"u2", "u3", "u4"}, reviewers)
+}
@@ -0,0 +1,55 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"context"
+	"regexp"
+	"strings"
+)
+
+// searchWord - слово запроса: буквы и цифры, остальное (в том числе операторы tsquery) - разделители.
+var searchWord = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// Search ищет PR по словам в названии. Каждое слово ищется как префикс, PR должен содержать все слова:
+// "migr user" найдет "Migrate users table".
+func (s *PullRequestService) Search(ctx context.Context, filter *models.PrSearchFilter) (*api.PrSearchResponse, error) {
+	query := prefixQuery(filter.Query)
+	if query == "" {
+		return nil, repo.ErrInvalidQuery
+	}
+
+	tsFilter := *filter
+	tsFilter.Query = query
+
+	items, err := s.prController.Search(ctx, &tsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.PrSearchResponse{
+		Query:        filter.Query,
+		PullRequests: make([]api.PrSearchResult, 0, len(items)),
+	}
+	for _, item := range items {
+		result := api.PrSearchResult{
+			PullRequestSchema: api.PullRequestSchema{AssignedReviewers: []string{}},
+			Rank:              item.Rank,
+		}
+		toPullRequestSchema(&result.PullRequestSchema, &item.PullRequest, item.Reviewers)
+		resp.PullRequests = append(resp.PullRequests, result)
+	}
+
+	return resp, nil
+}
+
+// prefixQuery собирает из текста tsquery, где каждое слово - префикс, а слова объединены через И.
+// Пустая строка - в тексте нет ни одного слова.
+func prefixQuery(text string) string {
+	words := searchWord.FindAllString(strings.ToLower(text), -1)
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
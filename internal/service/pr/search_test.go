@@ -0,0 +1,71 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestService_Search_PrefixQuery(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	prCtrl.On("Search", ctx, &models.PrSearchFilter{
+		Query:    "migr:* & user:* & s:* & 2:*",
+		Status:   pr.StatusOpen,
+		TeamName: "backend",
+		Limit:    10,
+	}).Return([]*models.PullRequestSearchItem{
+		{
+			PullRequestListItem: models.PullRequestListItem{
+				PullRequest: models.PullRequest{ID: "pr1", Title: "Migrate users v2", Status: pr.StatusOpen},
+				Reviewers:   []string{"r1"},
+			},
+			Rank: 0.5,
+		},
+	}, nil).Once()
+
+	svc := pr.NewPullRequestService(nil, prCtrl, nil, nil, nil, nil, pr.Options{})
+	resp, err := svc.Search(ctx, &models.PrSearchFilter{
+		Query:    "Migr USER's (2)!",
+		Status:   pr.StatusOpen,
+		TeamName: "backend",
+		Limit:    10,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Migr USER's (2)!", resp.Query)
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, "Migrate users v2", resp.PullRequests[0].Name)
+	assert.Equal(t, []string{"r1"}, resp.PullRequests[0].AssignedReviewers)
+	assert.Equal(t, 0.5, resp.PullRequests[0].Rank)
+}
+
+func TestPullRequestService_Search_Cyrillic(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	prCtrl.On("Search", ctx, &models.PrSearchFilter{Query: "миграц:*", Limit: 20}).
+		Return([]*models.PullRequestSearchItem{}, nil).Once()
+
+	svc := pr.NewPullRequestService(nil, prCtrl, nil, nil, nil, nil, pr.Options{})
+	resp, err := svc.Search(ctx, &models.PrSearchFilter{Query: "Миграц", Limit: 20})
+
+	require.NoError(t, err)
+	assert.Empty(t, resp.PullRequests)
+}
+
+func TestPullRequestService_Search_NoWords(t *testing.T) {
+	svc := pr.NewPullRequestService(nil, mocks.NewPrController(t), nil, nil, nil, nil, pr.Options{})
+
+	_, err := svc.Search(context.Background(), &models.PrSearchFilter{Query: "& | !:*", Limit: 20})
+
+	assert.ErrorIs(t, err, repo.ErrInvalidQuery)
+}
@@ -0,0 +1,255 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+const (
+	StrategyRandom         = "random"
+	StrategyRoundRobin     = "round_robin"
+	StrategyLeastLoaded    = "least_loaded"
+	StrategyWeightedRandom = "weighted_random"
+)
+
+// ReviewerSelector выбирает до count ревьюеров из уже отфильтрованного списка кандидатов.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ReviewerSelector
+type ReviewerSelector interface {
+	Select(ctx context.Context, teamID int, candidates []string, count int) ([]string, error)
+}
+
+// dryRunner - стратегия с состоянием, которая умеет выдать копию для предпросмотра:
+// копия выбирает так же, как исходная, но не сдвигает ее состояние.
+type dryRunner interface {
+	DryRun() ReviewerSelector
+}
+
+// dryRun возвращает копию стратегии для предпросмотра, стратегии без состояния - как есть.
+func dryRun(selector ReviewerSelector) ReviewerSelector {
+	if d, ok := selector.(dryRunner); ok {
+		return d.DryRun()
+	}
+	return selector
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=LoadProvider
+type LoadProvider interface {
+	GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=TeamNameProvider
+type TeamNameProvider interface {
+	GetTeamNameByID(ctx context.Context, teamID int) (string, error)
+}
+
+// NewSelector возвращает встроенную стратегию по её имени из конфига.
+func NewSelector(strategy string, loads LoadProvider) (ReviewerSelector, error) {
+	switch strategy {
+	case StrategyRandom, "":
+		return NewRandomSelector(), nil
+	case StrategyRoundRobin:
+		return NewRoundRobinSelector(), nil
+	case StrategyLeastLoaded:
+		return NewLeastLoadedSelector(loads), nil
+	case StrategyWeightedRandom:
+		return NewWeightedRandomSelector(loads), nil
+	default:
+		return nil, fmt.Errorf("unknown reviewer selection strategy %q", strategy)
+	}
+}
+
+// RandomSelector - равномерный случайный выбор.
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) Select(_ context.Context, _ int, candidates []string, count int) ([]string, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil
+	}
+
+	available := slices.Clone(candidates)
+	rand.Shuffle(len(available), func(i, j int) {
+		available[i], available[j] = available[j], available[i]
+	})
+
+	return available[:min(count, len(available))], nil
+}
+
+// RoundRobinSelector идет по кандидатам команды по кругу, запоминая позицию для каждой команды.
+type RoundRobinSelector struct {
+	mu      sync.Mutex
+	cursors map[int]int
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{
+		cursors: make(map[int]int),
+	}
+}
+
+func (s *RoundRobinSelector) Select(_ context.Context, teamID int, candidates []string, count int) ([]string, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil
+	}
+
+	// сортируем, чтобы порядок обхода не зависел от порядка строк из БД
+	sorted := slices.Clone(candidates)
+	slices.Sort(sorted)
+	count = min(count, len(sorted))
+
+	s.mu.Lock()
+	start := s.cursors[teamID] % len(sorted)
+	s.cursors[teamID] = start + count
+	s.mu.Unlock()
+
+	res := make([]string, 0, count)
+	for i := range count {
+		res = append(res, sorted[(start+i)%len(sorted)])
+	}
+
+	return res, nil
+}
+
+// DryRun копирует текущие позиции: выбор копии совпадет со следующим выбором исходной.
+func (s *RoundRobinSelector) DryRun() ReviewerSelector {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &RoundRobinSelector{
+		cursors: maps.Clone(s.cursors),
+	}
+}
+
+// LeastLoadedSelector выбирает кандидатов с наименьшим числом открытых ревью.
+type LeastLoadedSelector struct {
+	loads LoadProvider
+}
+
+func NewLeastLoadedSelector(loads LoadProvider) *LeastLoadedSelector {
+	return &LeastLoadedSelector{
+		loads: loads,
+	}
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, _ int, candidates []string, count int) ([]string, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil
+	}
+
+	loads, err := s.loads.GetOpenReviewsCount(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// перемешиваем до стабильной сортировки, чтобы при равной нагрузке выбор был случайным
+	available := slices.Clone(candidates)
+	rand.Shuffle(len(available), func(i, j int) {
+		available[i], available[j] = available[j], available[i]
+	})
+	slices.SortStableFunc(available, func(a, b string) int {
+		return loads[a] - loads[b]
+	})
+
+	return available[:min(count, len(available))], nil
+}
+
+// WeightedRandomSelector выбирает случайно, но с весом 1/(1+нагрузка):
+// менее загруженные ревьюеры выпадают чаще.
+type WeightedRandomSelector struct {
+	loads LoadProvider
+}
+
+func NewWeightedRandomSelector(loads LoadProvider) *WeightedRandomSelector {
+	return &WeightedRandomSelector{
+		loads: loads,
+	}
+}
+
+func (s *WeightedRandomSelector) Select(ctx context.Context, _ int, candidates []string, count int) ([]string, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil
+	}
+
+	loads, err := s.loads.GetOpenReviewsCount(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	available := slices.Clone(candidates)
+	count = min(count, len(available))
+	res := make([]string, 0, count)
+
+	for range count {
+		var total float64
+		for _, c := range available {
+			total += 1 / float64(1+loads[c])
+		}
+
+		point := rand.Float64() * total
+		idx := len(available) - 1
+		for i, c := range available {
+			point -= 1 / float64(1+loads[c])
+			if point < 0 {
+				idx = i
+				break
+			}
+		}
+
+		res = append(res, available[idx])
+		available = slices.Delete(available, idx, idx+1)
+	}
+
+	return res, nil
+}
+
+// TeamSelector выбирает стратегию по имени команды, для остальных команд использует стратегию по умолчанию.
+type TeamSelector struct {
+	teamNames TeamNameProvider
+	def       ReviewerSelector
+	byTeam    map[string]ReviewerSelector
+}
+
+func NewTeamSelector(
+	teamNames TeamNameProvider,
+	def ReviewerSelector,
+	byTeam map[string]ReviewerSelector,
+) *TeamSelector {
+	return &TeamSelector{
+		teamNames: teamNames,
+		def:       def,
+		byTeam:    byTeam,
+	}
+}
+
+func (s *TeamSelector) Select(ctx context.Context, teamID int, candidates []string, count int) ([]string, error) {
+	if len(s.byTeam) == 0 {
+		return s.def.Select(ctx, teamID, candidates, count)
+	}
+
+	teamName, err := s.teamNames.GetTeamNameByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if selector, ok := s.byTeam[teamName]; ok {
+		return selector.Select(ctx, teamID, candidates, count)
+	}
+	return s.def.Select(ctx, teamID, candidates, count)
+}
+
+func (s *TeamSelector) DryRun() ReviewerSelector {
+	byTeam := make(map[string]ReviewerSelector, len(s.byTeam))
+	for name, selector := range s.byTeam {
+		byTeam[name] = dryRun(selector)
+	}
+
+	return NewTeamSelector(s.teamNames, dryRun(s.def), byTeam)
+}
@@ -0,0 +1,236 @@
+package pr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewSelector_KnownStrategies(t *testing.T) {
+	loads := mocks.NewLoadProvider(t)
+
+	for _, name := range []string{
+		"",
+		pr.StrategyRandom,
+		pr.StrategyRoundRobin,
+		pr.StrategyLeastLoaded,
+		pr.StrategyWeightedRandom,
+	} {
+		selector, err := pr.NewSelector(name, loads)
+		assert.NoError(t, err, name)
+		assert.NotNil(t, selector, name)
+	}
+}
+
+func TestNewSelector_UnknownStrategy(t *testing.T) {
+	selector, err := pr.NewSelector("by_mood", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, selector)
+}
+
+func TestRandomSelector_Select(t *testing.T) {
+	ctx := context.Background()
+	candidates := []string{"u1", "u2", "u3"}
+
+	res, err := pr.NewRandomSelector().Select(ctx, 1, candidates, 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.NotEqual(t, res[0], res[1])
+	assert.Subset(t, candidates, res)
+	// входной слайс не должен перемешиваться
+	assert.Equal(t, []string{"u1", "u2", "u3"}, candidates)
+}
+
+func TestRandomSelector_Select_NotEnoughCandidates(t *testing.T) {
+	ctx := context.Background()
+
+	res, err := pr.NewRandomSelector().Select(ctx, 1, []string{"u1"}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, res)
+
+	res, err = pr.NewRandomSelector().Select(ctx, 1, nil, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestRoundRobinSelector_Select_RotatesPerTeam(t *testing.T) {
+	ctx := context.Background()
+	selector := pr.NewRoundRobinSelector()
+	candidates := []string{"u3", "u1", "u2"}
+
+	first, err := selector.Select(ctx, 1, candidates, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, first)
+
+	second, err := selector.Select(ctx, 1, candidates, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u1"}, second)
+
+	// у другой команды свой счетчик
+	other, err := selector.Select(ctx, 2, candidates, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, other)
+}
+
+func TestLeastLoadedSelector_Select(t *testing.T) {
+	ctx := context.Background()
+	loads := mocks.NewLoadProvider(t)
+	candidates := []string{"u1", "u2", "u3"}
+
+	loads.On("GetOpenReviewsCount", ctx, candidates).
+		Return(map[string]int{"u1": 4, "u2": 0, "u3": 1}, nil).Once()
+
+	res, err := pr.NewLeastLoadedSelector(loads).Select(ctx, 1, candidates, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u3"}, res)
+}
+
+func TestLeastLoadedSelector_Select_LoadsError(t *testing.T) {
+	ctx := context.Background()
+	loads := mocks.NewLoadProvider(t)
+	loadErr := errors.New("loads failed")
+
+	loads.On("GetOpenReviewsCount", ctx, mock.Anything).Return(nil, loadErr).Once()
+
+	res, err := pr.NewLeastLoadedSelector(loads).Select(ctx, 1, []string{"u1"}, 1)
+
+	assert.Nil(t, res)
+	assert.Equal(t, loadErr, err)
+}
+
+func TestWeightedRandomSelector_Select(t *testing.T) {
+	ctx := context.Background()
+	loads := mocks.NewLoadProvider(t)
+	candidates := []string{"u1", "u2", "u3"}
+
+	loads.On("GetOpenReviewsCount", ctx, candidates).
+		Return(map[string]int{"u1": 0, "u2": 3, "u3": 10}, nil).Once()
+
+	res, err := pr.NewWeightedRandomSelector(loads).Select(ctx, 1, candidates, 3)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, candidates, res)
+}
+
+func TestTeamSelector_Select_UsesTeamStrategy(t *testing.T) {
+	ctx := context.Background()
+	teamNames := mocks.NewTeamNameProvider(t)
+	def := mocks.NewReviewerSelector(t)
+	backend := mocks.NewReviewerSelector(t)
+	candidates := []string{"u1", "u2"}
+
+	teamNames.On("GetTeamNameByID", ctx, 7).Return("backend", nil).Once()
+	backend.On("Select", ctx, 7, candidates, 1).Return([]string{"u2"}, nil).Once()
+
+	selector := pr.NewTeamSelector(teamNames, def, map[string]pr.ReviewerSelector{"backend": backend})
+	res, err := selector.Select(ctx, 7, candidates, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, res)
+	def.AssertNotCalled(t, "Select", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamSelector_Select_FallsBackToDefault(t *testing.T) {
+	ctx := context.Background()
+	teamNames := mocks.NewTeamNameProvider(t)
+	def := mocks.NewReviewerSelector(t)
+	backend := mocks.NewReviewerSelector(t)
+	candidates := []string{"u1", "u2"}
+
+	teamNames.On("GetTeamNameByID", ctx, 8).Return("frontend", nil).Once()
+	def.On("Select", ctx, 8, candidates, 2).Return(candidates, nil).Once()
+
+	selector := pr.NewTeamSelector(teamNames, def, map[string]pr.ReviewerSelector{"backend": backend})
+	res, err := selector.Select(ctx, 8, candidates, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, candidates, res)
+}
+
+func TestPullRequestService_Create_UsesSelector(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-sel"
+	authorID := "a1"
+	teamID := 3
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: teamID}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return([]string{"a1", "u1", "u2", "u3"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, teamID).Return(&models.TeamSettings{TeamID: teamID, ReviewersCount: 2}, nil).Once()
+	// автор исключается до передачи кандидатов в стратегию
+	selector.On("Select", ctx, teamID, []string{"u1", "u2", "u3"}, 2).Return([]string{"u3", "u1"}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u3").Return(nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u1").Return(nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u3", "u1"}).Return(map[string]int{"u3": 2, "u1": 0}, nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.Create(ctx, prID, "Selector test", authorID, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u1"}, resp.AssignedReviewers)
+	assert.Equal(t, map[string]int{"u3": 2, "u1": 0}, resp.ReviewerLoads)
+}
+
+func TestPullRequestService_Reassign_ReturnsNewReviewerLoad(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-load"
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	current := &models.PullRequest{ID: prID, Title: "Load", AuthorId: "a1", Status: pr.StatusOpen}
+
+	prCtrl.On("GetById", ctx, prID).Return(current, nil).Twice()
+	userGetter.On("GetById", ctx, "r1").Return(&models.User{ID: "r1", TeamID: 4}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 4).Return([]string{"a1", "r1", "r2", "r3"}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"r1", "r2"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 4).Return(&models.TeamSettings{TeamID: 4, ReviewersCount: 2}, nil).Once()
+	selector.On("Select", ctx, 4, []string{"r3"}, 1).Return([]string{"r3"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"r3"}).Return(map[string]int{"r3": 1}, nil).Once()
+	reviewerProv.On("ReassignReviewer", ctx, prID, "r1", "r3").Return(nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"r2", "r3"}, nil).Once()
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.Reassign(ctx, prID, "r1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "r3", resp.ReplacedBy)
+	assert.Equal(t, map[string]int{"r3": 1}, resp.PullRequest.ReviewerLoads)
+}
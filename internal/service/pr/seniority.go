@@ -0,0 +1,114 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/models"
+	"context"
+	"maps"
+	"slices"
+)
+
+// hasComposition сообщает, действуют ли в команде правила состава ревьюеров по уровням.
+func hasComposition(settings *models.TeamSettings) bool {
+	return settings.MinSeniorReviewers != nil ||
+		settings.MinJuniorReviewers != nil ||
+		settings.MaxJuniorReviewers != nil ||
+		settings.SeniorForJuniorAuthors
+}
+
+// pickComposed выбирает до count ревьюеров по правилам состава команды: сначала недостающих
+// сеньоров, затем недостающих джунов, а оставшиеся места занимают кандидаты любого уровня,
+// джуны - только в пределах max_junior_reviewers. Уже назначенные ревьюеры assigned
+// засчитываются в правила. Если кандидатов нужного уровня нет, места занимают остальные.
+func (s *PullRequestService) pickComposed(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	authorID string,
+	assigned, candidates []string,
+	count int,
+	labels []string,
+) ([]string, map[string]int, error) {
+	if !hasComposition(settings) || len(candidates) == 0 || count <= 0 {
+		return s.pickReviewers(ctx, settings, authorID, candidates, count, labels)
+	}
+
+	levels, err := s.userGetter.GetSeniority(ctx, slices.Concat([]string{authorID}, assigned, candidates))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	countLevel := func(ids []string, level string) int {
+		n := 0
+		for _, id := range ids {
+			if levels[id] == level {
+				n++
+			}
+		}
+		return n
+	}
+	ofLevel := func(level string, want bool) []string {
+		return slices.DeleteFunc(slices.Clone(candidates), func(id string) bool {
+			return (levels[id] == level) != want
+		})
+	}
+
+	needSeniors := 0
+	if settings.MinSeniorReviewers != nil {
+		needSeniors = *settings.MinSeniorReviewers
+	}
+	if settings.SeniorForJuniorAuthors && levels[authorID] == models.SeniorityJunior {
+		needSeniors = max(needSeniors, 1)
+	}
+	needSeniors -= countLevel(assigned, models.SenioritySenior)
+
+	needJuniors := 0
+	if settings.MinJuniorReviewers != nil {
+		needJuniors = *settings.MinJuniorReviewers - countLevel(assigned, models.SeniorityJunior)
+	}
+
+	picked := []string{}
+	loads := map[string]int{}
+	pick := func(pool []string, n int) error {
+		n = min(n, count-len(picked))
+		if n <= 0 {
+			return nil
+		}
+
+		ids, idsLoads, err := s.pickReviewers(ctx, settings, authorID, excludeUsers(pool, picked...), n, labels)
+		if err != nil {
+			return err
+		}
+		picked = append(picked, ids...)
+		maps.Copy(loads, idsLoads)
+		return nil
+	}
+
+	if err := pick(ofLevel(models.SenioritySenior, true), needSeniors); err != nil {
+		return nil, nil, err
+	}
+
+	if settings.MaxJuniorReviewers == nil {
+		if err := pick(ofLevel(models.SeniorityJunior, true), needJuniors); err != nil {
+			return nil, nil, err
+		}
+		if err := pick(candidates, count); err != nil {
+			return nil, nil, err
+		}
+		return picked, loads, nil
+	}
+
+	// джуны сверх минимума занимают только места, на которые не нашлось остальных
+	juniorsLeft := *settings.MaxJuniorReviewers - countLevel(assigned, models.SeniorityJunior)
+	if err := pick(ofLevel(models.SeniorityJunior, true), min(needJuniors, juniorsLeft)); err != nil {
+		return nil, nil, err
+	}
+	juniorsLeft -= countLevel(picked, models.SeniorityJunior)
+
+	if err := pick(ofLevel(models.SeniorityJunior, false), count); err != nil {
+		return nil, nil, err
+	}
+	if err := pick(ofLevel(models.SeniorityJunior, true), juniorsLeft); err != nil {
+		return nil, nil, err
+	}
+
+	return picked, loads, nil
+}
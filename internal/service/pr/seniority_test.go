@@ -0,0 +1,150 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var teamLevels = map[string]string{
+	"j1": models.SeniorityJunior,
+	"j2": models.SeniorityJunior,
+	"m1": models.SeniorityMiddle,
+	"s1": models.SenioritySenior,
+	"s2": models.SenioritySenior,
+}
+
+// createWithComposition создает PR автора author в команде из teamLevels с заданными правилами состава.
+func createWithComposition(t *testing.T, author string, settings *models.TeamSettings) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	active := make([]string, 0, len(teamLevels))
+	for id := range teamLevels {
+		active = append(active, id)
+	}
+
+	settings.TeamID = 1
+	userGetter.On("GetById", ctx, author).Return(&models.User{ID: author, TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return(active, nil).Once()
+	userGetter.On("GetSeniority", ctx, mock.Anything).Return(teamLevels, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(settings, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, mock.Anything).Return(map[string]int{}, nil)
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return("pr-1", nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, "pr-1", mock.Anything).Return(nil)
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	resp, err := svc.Create(ctx, "pr-1", "Composition", author, nil, nil)
+
+	assert.NoError(t, err)
+	return resp.AssignedReviewers
+}
+
+func levelsOf(ids []string) []string {
+	levels := make([]string, 0, len(ids))
+	for _, id := range ids {
+		levels = append(levels, teamLevels[id])
+	}
+	return levels
+}
+
+func TestPullRequestService_Create_JuniorAuthorGetsSenior(t *testing.T) {
+	for range 10 {
+		reviewers := createWithComposition(t, "j1", &models.TeamSettings{
+			ReviewersCount:         1,
+			SeniorForJuniorAuthors: true,
+		})
+
+		assert.Equal(t, []string{models.SenioritySenior}, levelsOf(reviewers))
+	}
+}
+
+func TestPullRequestService_Create_SeniorAndJuniorSlots(t *testing.T) {
+	one := 1
+
+	for range 10 {
+		reviewers := createWithComposition(t, "m1", &models.TeamSettings{
+			ReviewersCount:     2,
+			MinSeniorReviewers: &one,
+			MinJuniorReviewers: &one,
+		})
+
+		assert.ElementsMatch(t, []string{models.SenioritySenior, models.SeniorityJunior}, levelsOf(reviewers))
+	}
+}
+
+func TestPullRequestService_Create_MaxJuniors(t *testing.T) {
+	one := 1
+
+	for range 10 {
+		// без джунов остаются только s1 и s2
+		reviewers := createWithComposition(t, "m1", &models.TeamSettings{
+			ReviewersCount:     3,
+			MaxJuniorReviewers: &one,
+		})
+
+		assert.Len(t, reviewers, 3)
+		assert.Equal(t, 1, countLevel(levelsOf(reviewers), models.SeniorityJunior))
+	}
+}
+
+func countLevel(levels []string, level string) int {
+	n := 0
+	for _, l := range levels {
+		if l == level {
+			n++
+		}
+	}
+	return n
+}
+
+func TestPullRequestService_Reassign_NoCandidate_Composition(t *testing.T) {
+	ctx := context.Background()
+	one := 1
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			err := fn(ctx)
+			assert.ErrorIs(t, err, repo.ErrNoCandidate)
+			// j2 свободен, но второй джун сверх max_junior_reviewers - дело в составе, а не в лимите
+			assert.ErrorContains(t, err, "seniority composition")
+			assert.NotContains(t, err.Error(), "max open reviews")
+		}).Return(repo.ErrNoCandidate).Once()
+
+	prCtrl.On("GetById", ctx, "pr-1").
+		Return(&models.PullRequest{ID: "pr-1", AuthorId: "m1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr-1").Return([]string{"s1", "j1"}, nil).Once()
+	userGetter.On("GetById", ctx, "s1").Return(&models.User{ID: "s1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"m1", "s1", "j1", "j2"}, nil).Once()
+	userGetter.On("GetSeniority", ctx, mock.Anything).Return(teamLevels, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).
+		Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2, MaxJuniorReviewers: &one}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, pr.NewRandomSelector(), pr.Options{})
+	_, err := svc.Reassign(ctx, "pr-1", "s1")
+
+	assert.ErrorIs(t, err, repo.ErrNoCandidate)
+	reviewerProv.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,62 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/models"
+	"context"
+	"slices"
+)
+
+// selectBySkills сначала отдает стратегии кандидатов, чьи навыки пересекаются с метками PR,
+// и добирает остальных, только если подходящих не хватило. Без меток - обычный выбор.
+// Внутри каждой группы действует ротация пар с автором, если она включена в команде.
+func (s *PullRequestService) selectBySkills(
+	ctx context.Context,
+	settings *models.TeamSettings,
+	authorID string,
+	candidates []string,
+	count int,
+	labels []string,
+) ([]string, error) {
+	teamID := settings.TeamID
+
+	pairings, err := s.recentPairings(ctx, settings, authorID, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(labels) == 0 || len(candidates) == 0 {
+		return s.selectRotated(ctx, teamID, candidates, count, pairings)
+	}
+
+	skills, err := s.userGetter.GetSkills(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching, rest []string
+	for _, id := range candidates {
+		if slices.ContainsFunc(skills[id], func(skill string) bool { return slices.Contains(labels, skill) }) {
+			matching = append(matching, id)
+		} else {
+			rest = append(rest, id)
+		}
+	}
+
+	if len(matching) == 0 {
+		return s.selectRotated(ctx, teamID, rest, count, pairings)
+	}
+
+	picked, err := s.selectRotated(ctx, teamID, matching, count, pairings)
+	if err != nil {
+		return nil, err
+	}
+	if len(picked) >= count || len(rest) == 0 {
+		return picked, nil
+	}
+
+	more, err := s.selectRotated(ctx, teamID, rest, count-len(picked), pairings)
+	if err != nil {
+		return nil, err
+	}
+	return append(picked, more...), nil
+}
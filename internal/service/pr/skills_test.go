@@ -0,0 +1,122 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_Create_PrefersMatchingSkills(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-skills"
+	authorID := "a1"
+	teamID := 1
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: teamID}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return([]string{"a1", "u1", "u2", "u3"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, teamID).Return(&models.TeamSettings{TeamID: teamID, ReviewersCount: 2}, nil).Once()
+	userGetter.On("GetSkills", ctx, []string{"u1", "u2", "u3"}).Return(map[string][]string{
+		"u1": {"frontend"},
+		"u3": {"go", "postgres"},
+	}, nil).Once()
+	// сначала кандидаты с совпадающими навыками, затем остальные
+	selector.On("Select", ctx, teamID, []string{"u3"}, 2).Return([]string{"u3"}, nil).Once()
+	selector.On("Select", ctx, teamID, []string{"u1", "u2"}, 1).Return([]string{"u2"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u3", "u2"}).Return(map[string]int{"u3": 1, "u2": 0}, nil).Once()
+	prCtrl.On("Create", ctx, mock.MatchedBy(func(p *models.PullRequest) bool {
+		return assert.ObjectsAreEqual([]string{"go", "postgres"}, []string(p.Labels))
+	})).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u3").Return(nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u2").Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.Create(ctx, prID, "Skills", authorID, nil, []string{" Postgres", "go", "go"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u2"}, resp.AssignedReviewers)
+	assert.Equal(t, []string{"go", "postgres"}, resp.Labels)
+}
+
+func TestPullRequestService_Create_NoMatchingSkills(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-skills"
+	authorID := "a1"
+	teamID := 1
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+	trm := newDoMock(t, ctx, nil)
+
+	userGetter.On("GetById", ctx, authorID).Return(&models.User{ID: authorID, TeamID: teamID}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, teamID).Return([]string{"a1", "u1", "u2"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, teamID).Return(&models.TeamSettings{TeamID: teamID, ReviewersCount: 1}, nil).Once()
+	userGetter.On("GetSkills", ctx, []string{"u1", "u2"}).Return(map[string][]string{}, nil).Once()
+	selector.On("Select", ctx, teamID, []string{"u1", "u2"}, 1).Return([]string{"u1"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"u1"}).Return(map[string]int{"u1": 0}, nil).Once()
+	prCtrl.On("Create", ctx, mock.AnythingOfType("*models.PullRequest")).Return(prID, nil).Once()
+	reviewerProv.On("AssignReviewer", ctx, prID, "u1").Return(nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	resp, err := svc.Create(ctx, prID, "Skills", authorID, nil, []string{"rust"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, resp.AssignedReviewers)
+}
+
+func TestPullRequestService_SetLabels_Success(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-labels"
+
+	prCtrl := mocks.NewPrController(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	trm := newDoMock(t, ctx, nil)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	prCtrl.On("SetLabels", ctx, prID, mock.MatchedBy(func(labels []string) bool {
+		return assert.ObjectsAreEqual([]string{"frontend", "go"}, labels)
+	})).Return(nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, prID).Return([]string{"u1"}, nil).Once()
+	reviewerProv.On("GetLatestReviews", ctx, prID).Return(nil, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, nil, nil, nil, pr.Options{})
+	resp, err := svc.SetLabels(ctx, prID, []string{"go", "Frontend"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "go"}, resp.Labels)
+	assert.Equal(t, []string{"u1"}, resp.AssignedReviewers)
+}
+
+func TestPullRequestService_SetLabels_MergedPR(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr-labels"
+
+	prCtrl := mocks.NewPrController(t)
+	trm := newDoMock(t, ctx, repo.ErrPRMerged)
+
+	prCtrl.On("GetById", ctx, prID).
+		Return(&models.PullRequest{ID: prID, AuthorId: "a1", Status: pr.StatusMerged}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, nil, nil, nil, nil, pr.Options{})
+	_, err := svc.SetLabels(ctx, prID, []string{"go"})
+
+	assert.ErrorIs(t, err, repo.ErrPRMerged)
+	prCtrl.AssertNotCalled(t, "SetLabels", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,133 @@
+package pr
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/sl"
+	"avito-intership-2025/internal/lib/worker"
+	repo "avito-intership-2025/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// SLAPolicy - сроки, за которые ревьюер должен оставить вердикт. Срок выбирается
+// по команде автора PR, нулевой срок отключает переназначение.
+type SLAPolicy struct {
+	Default time.Duration
+	Teams   map[string]time.Duration
+}
+
+// For возвращает срок для команды автора PR.
+func (p SLAPolicy) For(teamName string) time.Duration {
+	if sla, ok := p.Teams[teamName]; ok {
+		return sla
+	}
+	return p.Default
+}
+
+// Enabled сообщает, действует ли срок хотя бы для одной команды.
+func (p SLAPolicy) Enabled() bool {
+	return p.shortest() > 0
+}
+
+// shortest возвращает минимальный ненулевой срок: назначения моложе него точно не просрочены.
+func (p SLAPolicy) shortest() time.Duration {
+	shortest := p.Default
+	for _, sla := range p.Teams {
+		if sla > 0 && (shortest == 0 || sla < shortest) {
+			shortest = sla
+		}
+	}
+	return shortest
+}
+
+// ReassignStale переназначает ревью, по которым ревьюер не оставил вердикт в срок.
+// Замена выбирается так же, как в Reassign, причина сохраняется в назначении нового ревьюера.
+// Каждое ревью переназначается в своей транзакции; ревью без подходящей замены
+// остаются на прежнем ревьюере и проверяются снова при следующем запуске.
+func (s *PullRequestService) ReassignStale(ctx context.Context, policy SLAPolicy) ([]api.ReviewReplacement, error) {
+	replacements := []api.ReviewReplacement{}
+	if !policy.Enabled() {
+		return replacements, nil
+	}
+
+	pending, err := s.reviewerProvider.GetPendingReviews(ctx, policy.shortest())
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, p := range pending {
+		sla := policy.For(p.AuthorTeam)
+		waiting := time.Duration(p.WaitingSeconds * float64(time.Second))
+		if sla == 0 || waiting < sla {
+			continue
+		}
+
+		reason := fmt.Sprintf("review SLA expired: %s left no verdict within %s", p.ReviewerID, sla)
+		resp, err := s.reassign(ctx, p.PullRequestID, p.ReviewerID, reason)
+		switch {
+		case err == nil:
+			replacements = append(replacements, api.ReviewReplacement{
+				PullRequestID: p.PullRequestID,
+				OldReviewerID: p.ReviewerID,
+				ReplacedBy:    resp.ReplacedBy,
+			})
+
+		// заменить некем (в том числе из-за правил конфликта интересов) или ревью уже изменилось с момента выборки
+		case errors.Is(err, repo.ErrNoCandidate),
+			errors.Is(err, repo.ErrExcludedByRules),
+			errors.Is(err, repo.ErrNotAssigned),
+			errors.Is(err, repo.ErrPRMerged),
+			errors.Is(err, repo.ErrPRClosed):
+
+		default:
+			errs = append(errs, fmt.Errorf("pr %s, reviewer %s: %w", p.PullRequestID, p.ReviewerID, err))
+		}
+	}
+
+	return replacements, errors.Join(errs...)
+}
+
+type staleReassigner interface {
+	ReassignStale(ctx context.Context, policy SLAPolicy) ([]api.ReviewReplacement, error)
+}
+
+// SLAWorker периодически переназначает ревью, просроченные по SLA.
+type SLAWorker struct {
+	log      *slog.Logger
+	service  staleReassigner
+	policy   SLAPolicy
+	interval time.Duration
+}
+
+func NewSLAWorker(log *slog.Logger, s staleReassigner, policy SLAPolicy, interval time.Duration) *SLAWorker {
+	return &SLAWorker{
+		log:      log.With(slog.String("worker", "review_sla")),
+		service:  s,
+		policy:   policy,
+		interval: interval,
+	}
+}
+
+// Run блокируется до отмены ctx. Первая проверка выполняется сразу при запуске.
+func (w *SLAWorker) Run(ctx context.Context) {
+	worker.Every(ctx, w.interval, w.tick)
+	w.log.Info("review sla worker stopped")
+}
+
+func (w *SLAWorker) tick(ctx context.Context) {
+	replacements, err := w.service.ReassignStale(ctx, w.policy)
+	if err != nil && ctx.Err() == nil {
+		w.log.Error("failed to reassign stale reviews", sl.Err(err))
+	}
+	for _, r := range replacements {
+		w.log.Info("stale review reassigned",
+			slog.String("pull_request_id", r.PullRequestID),
+			slog.String("old_reviewer_id", r.OldReviewerID),
+			slog.String("replaced_by", r.ReplacedBy),
+		)
+	}
+}
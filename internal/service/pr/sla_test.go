@@ -0,0 +1,133 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/pr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSLAPolicy(t *testing.T) {
+	policy := pr.SLAPolicy{
+		Default: 48 * time.Hour,
+		Teams:   map[string]time.Duration{"backend": 24 * time.Hour, "infra": 0},
+	}
+
+	assert.Equal(t, 24*time.Hour, policy.For("backend"))
+	assert.Equal(t, time.Duration(0), policy.For("infra"))
+	assert.Equal(t, 48*time.Hour, policy.For("frontend"))
+	assert.True(t, policy.Enabled())
+	assert.True(t, pr.SLAPolicy{Teams: map[string]time.Duration{"backend": time.Hour}}.Enabled())
+	assert.False(t, pr.SLAPolicy{Teams: map[string]time.Duration{"infra": 0}}.Enabled())
+}
+
+func TestPullRequestService_ReassignStale(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	settingsProv := mocks.NewTeamSettingsProvider(t)
+	selector := mocks.NewReviewerSelector(t)
+
+	// переназначаются два ревью, Do вызывается на каждое
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+	for _, wantErr := range []error{nil, repo.ErrNoCandidate} {
+		trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(context.Context) error)
+				assert.ErrorIs(t, fn(ctx), wantErr)
+			}).Return(wantErr).Once()
+	}
+
+	policy := pr.SLAPolicy{
+		Default: 48 * time.Hour,
+		Teams:   map[string]time.Duration{"backend": 24 * time.Hour},
+	}
+
+	hours := func(h float64) float64 { return h * time.Hour.Seconds() }
+	reviewerProv.On("GetPendingReviews", ctx, 24*time.Hour).Return([]*models.PendingReview{
+		{PullRequestID: "pr1", ReviewerID: "r1", AuthorTeam: "backend", WaitingSeconds: hours(30)},
+		// для команды frontend действует срок по умолчанию, он еще не истек
+		{PullRequestID: "pr2", ReviewerID: "r2", AuthorTeam: "frontend", WaitingSeconds: hours(30)},
+		{PullRequestID: "pr3", ReviewerID: "r3", AuthorTeam: "frontend", WaitingSeconds: hours(50)},
+	}, nil).Once()
+
+	// pr1: замена находится
+	prCtrl.On("GetById", ctx, "pr1").
+		Return(&models.PullRequest{ID: "pr1", AuthorId: "a1", Status: pr.StatusOpen}, nil).Twice()
+	reviewerProv.On("GetPrReviewers", ctx, "pr1").Return([]string{"r1"}, nil).Once()
+	userGetter.On("GetById", ctx, "r1").Return(&models.User{ID: "r1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "r1", "r4"}, nil).Once()
+	settingsProv.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, ReviewersCount: 2}, nil).Once()
+	selector.On("Select", ctx, 1, []string{"r4"}, 1).Return([]string{"r4"}, nil).Once()
+	reviewerProv.On("GetOpenReviewsCount", ctx, []string{"r4"}).Return(map[string]int{"r4": 0}, nil).Once()
+	reviewerProv.On("ReassignReviewer", ctx, "pr1", "r1", "r4").Return(nil).Once()
+	reviewerProv.On("SetAssignmentReason", ctx, "pr1", "r4",
+		"review SLA expired: r1 left no verdict within 24h0m0s").Return(nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr1").Return([]string{"r4"}, nil).Once()
+
+	// pr3: в команде больше некого назначить
+	prCtrl.On("GetById", ctx, "pr3").
+		Return(&models.PullRequest{ID: "pr3", AuthorId: "a2", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr3").Return([]string{"r3"}, nil).Once()
+	userGetter.On("GetById", ctx, "r3").Return(&models.User{ID: "r3", TeamID: 2}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 2).Return([]string{"a2", "r3"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, settingsProv, selector, pr.Options{})
+	replacements, err := svc.ReassignStale(ctx, policy)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.ReviewReplacement{
+		{PullRequestID: "pr1", OldReviewerID: "r1", ReplacedBy: "r4"},
+	}, replacements)
+}
+
+func TestPullRequestService_ReassignStale_OnlyCandidateExcluded(t *testing.T) {
+	ctx := context.Background()
+
+	prCtrl := mocks.NewPrController(t)
+	userGetter := mocks.NewUserGetter(t)
+	reviewerProv := mocks.NewReviewerProvider(t)
+	exclusions := mocks.NewExclusionProvider(t)
+	trm := newDoMock(t, ctx, repo.ErrExcludedByRules)
+
+	reviewerProv.On("GetPendingReviews", ctx, 24*time.Hour).Return([]*models.PendingReview{
+		{PullRequestID: "pr1", ReviewerID: "r1", AuthorTeam: "backend", WaitingSeconds: 30 * time.Hour.Seconds()},
+	}, nil).Once()
+
+	// единственный кандидат r2 запрещен правилами конфликта интересов
+	prCtrl.On("GetById", ctx, "pr1").
+		Return(&models.PullRequest{ID: "pr1", AuthorId: "a1", Status: pr.StatusOpen}, nil).Once()
+	reviewerProv.On("GetPrReviewers", ctx, "pr1").Return([]string{"r1"}, nil).Once()
+	userGetter.On("GetById", ctx, "r1").Return(&models.User{ID: "r1", TeamID: 1}, nil).Once()
+	userGetter.On("GetActiveUsersIDInTeam", ctx, 1).Return([]string{"a1", "r1", "r2"}, nil).Once()
+	exclusions.On("GetExcludedReviewers", ctx, "a1").Return([]string{"r2"}, nil).Once()
+
+	svc := pr.NewPullRequestService(trm, prCtrl, reviewerProv, userGetter, nil, nil, pr.Options{Exclusions: exclusions})
+	replacements, err := svc.ReassignStale(ctx, pr.SLAPolicy{Default: 24 * time.Hour})
+
+	assert.NoError(t, err)
+	assert.Empty(t, replacements)
+}
+
+func TestPullRequestService_ReassignStale_Disabled(t *testing.T) {
+	reviewerProv := mocks.NewReviewerProvider(t)
+
+	svc := pr.NewPullRequestService(nil, nil, reviewerProv, nil, nil, nil, pr.Options{})
+	replacements, err := svc.ReassignStale(context.Background(), pr.SLAPolicy{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, replacements)
+	reviewerProv.AssertNotCalled(t, "GetPendingReviews", mock.Anything, mock.Anything)
+}
@@ -2,9 +2,13 @@ package team
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"avito-intership-2025/internal/http/api"
 	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
 	"avito-intership-2025/internal/service"
 )
 
@@ -12,29 +16,51 @@ import (
 type TeamProvider interface {
 	Create(ctx context.Context, teamName string) (int, error)
 	GetByTeamName(ctx context.Context, teamName string) (*models.Team, error)
+	GetSettings(ctx context.Context, teamID int) (*models.TeamSettings, error)
+	SaveSettings(ctx context.Context, settings *models.TeamSettings) error
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=UserProvider
 type UserProvider interface {
 	Save(ctx context.Context, user *models.User) (string, error)
 	GetUsersInTeam(ctx context.Context, teamName string) ([]*models.User, error)
+	DeactivateUsers(ctx context.Context, userIDs []string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ReviewReassigner
+type ReviewReassigner interface {
+	ReassignOpenReviews(ctx context.Context, teamID int, userIDs []string, reason string) ([]api.ReviewReplacement, []string, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=PairingProvider
+type PairingProvider interface {
+	GetTeamPairings(ctx context.Context, teamID int, since *time.Time) ([]*models.ReviewPairing, error)
 }
 
 type TeamService struct {
-	teamProvider TeamProvider
-	userProvider UserProvider
-	trm          service.TransactionManager
+	teamProvider     TeamProvider
+	userProvider     UserProvider
+	reviewReassigner ReviewReassigner
+	events           service.EventRecorder
+	pairings         PairingProvider
+	trm              service.TransactionManager
 }
 
 func NewTeamService(
 	trm service.TransactionManager,
 	teamProvider TeamProvider,
 	userProvider UserProvider,
+	reviewReassigner ReviewReassigner,
+	events service.EventRecorder,
+	pairings PairingProvider,
 ) *TeamService {
 	return &TeamService{
-		teamProvider: teamProvider,
-		userProvider: userProvider,
-		trm:          trm,
+		teamProvider:     teamProvider,
+		userProvider:     userProvider,
+		reviewReassigner: reviewReassigner,
+		events:           events,
+		pairings:         pairings,
+		trm:              trm,
 	}
 }
 
@@ -50,10 +76,11 @@ func (s *TeamService) Add(ctx context.Context, teamName string, users []api.Team
 
 		for _, u := range users {
 			user := &models.User{
-				ID:       u.UserID,
-				Name:     u.Username,
-				TeamID:   teamID,
-				IsActive: u.IsActive,
+				ID:        u.UserID,
+				Name:      u.Username,
+				TeamID:    teamID,
+				IsActive:  u.IsActive,
+				Seniority: u.Seniority,
 			}
 
 			_, err := s.userProvider.Save(ctx, user)
@@ -62,9 +89,10 @@ func (s *TeamService) Add(ctx context.Context, teamName string, users []api.Team
 			}
 
 			member := api.TeamMember{
-				UserID:   user.ID,
-				Username: user.Name,
-				IsActive: user.IsActive,
+				UserID:    user.ID,
+				Username:  user.Name,
+				IsActive:  user.IsActive,
+				Seniority: user.Seniority,
 			}
 
 			members = append(members, member)
@@ -98,9 +126,10 @@ func (s *TeamService) Get(ctx context.Context, teamName string) (*api.TeamSchema
 	members := make([]api.TeamMember, 0, len(users))
 	for _, u := range users {
 		member := api.TeamMember{
-			UserID:   u.ID,
-			Username: u.Name,
-			IsActive: u.IsActive,
+			UserID:    u.ID,
+			Username:  u.Name,
+			IsActive:  u.IsActive,
+			Seniority: u.Seniority,
 		}
 
 		members = append(members, member)
@@ -111,3 +140,311 @@ func (s *TeamService) Get(ctx context.Context, teamName string) (*api.TeamSchema
 
 	return resp, nil
 }
+
+func (s *TeamService) GetSettings(ctx context.Context, teamName string) (*api.TeamSettingsSchema, error) {
+	team, err := s.teamProvider.GetByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.teamProvider.GetSettings(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTeamSettingsSchema(teamName, settings), nil
+}
+
+// SetSettings обновляет настройки команды частично: заданные в input поля перекрывают сохраненные,
+// незаданные остаются как были, поля из input.Reset сбрасываются к значениям по умолчанию.
+// Правила проверяются на итоговых настройках.
+func (s *TeamService) SetSettings(
+	ctx context.Context,
+	input *api.TeamSettingsUpdate,
+) (*api.TeamSettingsSchema, error) {
+	if input.RotationWindowPRs != nil && input.RotationWindowDays != nil {
+		return nil, fmt.Errorf("%w: only one of rotation_window_prs and rotation_window_days can be set",
+			repo.ErrInvalidSettings)
+	}
+
+	var resp *api.TeamSettingsSchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		team, err := s.teamProvider.GetByTeamName(ctx, input.TeamName)
+		if err != nil {
+			return err
+		}
+
+		settings, err := s.teamProvider.GetSettings(ctx, team.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := applySettingsUpdate(settings, input); err != nil {
+			return err
+		}
+		if err := checkComposition(settings); err != nil {
+			return err
+		}
+
+		if err := s.teamProvider.SaveSettings(ctx, settings); err != nil {
+			return err
+		}
+
+		resp = toTeamSettingsSchema(input.TeamName, settings)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// settingsResets сбрасывает поле настроек к значению по умолчанию, ключ - имя поля в JSON.
+var settingsResets = map[string]func(*models.TeamSettings){
+	"reviewers_count":           func(ts *models.TeamSettings) { ts.ReviewersCount = repo.DefaultReviewersCount },
+	"max_open_reviews":          func(ts *models.TeamSettings) { ts.MaxOpenReviews = nil },
+	"required_approvals":        func(ts *models.TeamSettings) { ts.RequiredApprovals = nil },
+	"min_senior_reviewers":      func(ts *models.TeamSettings) { ts.MinSeniorReviewers = nil },
+	"min_junior_reviewers":      func(ts *models.TeamSettings) { ts.MinJuniorReviewers = nil },
+	"max_junior_reviewers":      func(ts *models.TeamSettings) { ts.MaxJuniorReviewers = nil },
+	"senior_for_junior_authors": func(ts *models.TeamSettings) { ts.SeniorForJuniorAuthors = false },
+	"rotation_window_prs":       func(ts *models.TeamSettings) { ts.RotationWindowPRs = nil },
+	"rotation_window_days":      func(ts *models.TeamSettings) { ts.RotationWindowDays = nil },
+}
+
+// applySettingsUpdate накладывает input на сохраненные настройки settings.
+func applySettingsUpdate(settings *models.TeamSettings, input *api.TeamSettingsUpdate) error {
+	set := map[string]bool{
+		"reviewers_count":           input.ReviewersCount != nil,
+		"max_open_reviews":          input.MaxOpenReviews != nil,
+		"required_approvals":        input.RequiredApprovals != nil,
+		"min_senior_reviewers":      input.MinSeniorReviewers != nil,
+		"min_junior_reviewers":      input.MinJuniorReviewers != nil,
+		"max_junior_reviewers":      input.MaxJuniorReviewers != nil,
+		"senior_for_junior_authors": input.SeniorForJuniorAuthors != nil,
+		"rotation_window_prs":       input.RotationWindowPRs != nil,
+		"rotation_window_days":      input.RotationWindowDays != nil,
+	}
+
+	for _, field := range input.Reset {
+		reset, ok := settingsResets[field]
+		if !ok {
+			return fmt.Errorf("%w: unknown field %q in reset", repo.ErrInvalidSettings, field)
+		}
+		if set[field] {
+			return fmt.Errorf("%w: field %q is both set and reset", repo.ErrInvalidSettings, field)
+		}
+		reset(settings)
+	}
+
+	if input.ReviewersCount != nil {
+		settings.ReviewersCount = *input.ReviewersCount
+	}
+	if input.MaxOpenReviews != nil {
+		settings.MaxOpenReviews = input.MaxOpenReviews
+	}
+	if input.RequiredApprovals != nil {
+		settings.RequiredApprovals = input.RequiredApprovals
+	}
+	if input.MinSeniorReviewers != nil {
+		settings.MinSeniorReviewers = input.MinSeniorReviewers
+	}
+	if input.MinJuniorReviewers != nil {
+		settings.MinJuniorReviewers = input.MinJuniorReviewers
+	}
+	if input.MaxJuniorReviewers != nil {
+		settings.MaxJuniorReviewers = input.MaxJuniorReviewers
+	}
+	if input.SeniorForJuniorAuthors != nil {
+		settings.SeniorForJuniorAuthors = *input.SeniorForJuniorAuthors
+	}
+
+	// окно ротации одно: новое окно одного вида заменяет сохраненное окно другого
+	if input.RotationWindowPRs != nil {
+		settings.RotationWindowPRs = input.RotationWindowPRs
+		settings.RotationWindowDays = nil
+	}
+	if input.RotationWindowDays != nil {
+		settings.RotationWindowDays = input.RotationWindowDays
+		settings.RotationWindowPRs = nil
+	}
+
+	return nil
+}
+
+// GetPairings строит матрицу пар автор-ревьюер команды за последние days дней (nil - за все время).
+// В матрицу попадают все участники, в том числе пары без общих PR, чтобы были видны пробелы.
+func (s *TeamService) GetPairings(ctx context.Context, teamName string, days *int) (*api.PairingMatrixResponse, error) {
+	team, err := s.teamProvider.GetByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userProvider.GetUsersInTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	var since *time.Time
+	if days != nil {
+		t := time.Now().AddDate(0, 0, -*days)
+		since = &t
+	}
+
+	pairings, err := s.pairings.GetTeamPairings(ctx, team.ID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.PairingMatrixResponse{
+		TeamName: teamName,
+		Days:     days,
+		Members:  make([]string, 0, len(users)),
+		Matrix:   make(map[string]map[string]int, len(users)),
+	}
+	for _, author := range users {
+		resp.Members = append(resp.Members, author.ID)
+
+		row := make(map[string]int, len(users))
+		for _, reviewer := range users {
+			if reviewer.ID != author.ID {
+				row[reviewer.ID] = 0
+			}
+		}
+		resp.Matrix[author.ID] = row
+	}
+	slices.Sort(resp.Members)
+
+	for _, p := range pairings {
+		// бывшие участники команды в матрицу не попадают
+		if row, ok := resp.Matrix[p.AuthorID]; ok {
+			if _, ok := row[p.ReviewerID]; ok {
+				row[p.ReviewerID] = p.Count
+				resp.Total += p.Count
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// DeactivateUsers выключает пользователей команды (всех, если userIDs пуст)
+// и в той же транзакции переназначает их открытые ревью на оставшихся активных участников.
+func (s *TeamService) DeactivateUsers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) (*api.DeactivateUsersResponse, error) {
+	resp := &api.DeactivateUsersResponse{
+		TeamName:         teamName,
+		DeactivatedUsers: []string{},
+	}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		team, err := s.teamProvider.GetByTeamName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		users, err := s.userProvider.GetUsersInTeam(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		members := make(map[string]*models.User, len(users))
+		for _, u := range users {
+			members[u.ID] = u
+		}
+
+		selected := userIDs
+		if len(selected) == 0 {
+			selected = make([]string, 0, len(users))
+			for _, u := range users {
+				selected = append(selected, u.ID)
+			}
+		}
+
+		for _, id := range selected {
+			user, ok := members[id]
+			if !ok {
+				return fmt.Errorf("user %s is not a member of team %s: %w", id, teamName, repo.ErrNotFound)
+			}
+			if user.IsActive {
+				resp.DeactivatedUsers = append(resp.DeactivatedUsers, id)
+			}
+		}
+
+		if len(resp.DeactivatedUsers) > 0 {
+			if err := s.userProvider.DeactivateUsers(ctx, resp.DeactivatedUsers); err != nil {
+				return err
+			}
+
+			events := make([]*models.ReviewEvent, 0, len(resp.DeactivatedUsers))
+			for _, id := range resp.DeactivatedUsers {
+				events = append(events, activityEvent(id, false, reasonTeamDeactivation))
+			}
+			if err := service.RecordEvents(ctx, s.events, events...); err != nil {
+				return err
+			}
+		}
+
+		// ревью снимаем и с уже неактивных пользователей, если они на них остались
+		resp.Reassigned, resp.Understaffed, err = s.reviewReassigner.ReassignOpenReviews(
+			ctx, team.ID, selected, reasonTeamDeactivation,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// reasonTeamDeactivation - причина в журнале ревью для массового выключения пользователей.
+const reasonTeamDeactivation = "reviewer deactivated"
+
+func activityEvent(userID string, isActive bool, reason string) *models.ReviewEvent {
+	return &models.ReviewEvent{
+		Type:     models.EventActivityChanged,
+		UserID:   userID,
+		IsActive: &isActive,
+		Reason:   reason,
+	}
+}
+
+// checkComposition проверяет, что правила состава ревьюеров выполнимы при reviewers_count команды.
+func checkComposition(input *models.TeamSettings) error {
+	required := 0
+	if input.MinSeniorReviewers != nil {
+		required += *input.MinSeniorReviewers
+	}
+	if input.MinJuniorReviewers != nil {
+		required += *input.MinJuniorReviewers
+		if input.MaxJuniorReviewers != nil && *input.MinJuniorReviewers > *input.MaxJuniorReviewers {
+			return fmt.Errorf("%w: min_junior_reviewers exceeds max_junior_reviewers", repo.ErrInvalidSettings)
+		}
+	}
+	if required > input.ReviewersCount {
+		return fmt.Errorf("%w: composition rules need %d reviewers, reviewers_count is %d",
+			repo.ErrInvalidSettings, required, input.ReviewersCount)
+	}
+	return nil
+}
+
+func toTeamSettingsSchema(teamName string, settings *models.TeamSettings) *api.TeamSettingsSchema {
+	return &api.TeamSettingsSchema{
+		TeamName:               teamName,
+		ReviewersCount:         settings.ReviewersCount,
+		MaxOpenReviews:         settings.MaxOpenReviews,
+		RequiredApprovals:      settings.RequiredApprovals,
+		MinSeniorReviewers:     settings.MinSeniorReviewers,
+		MinJuniorReviewers:     settings.MinJuniorReviewers,
+		MaxJuniorReviewers:     settings.MaxJuniorReviewers,
+		SeniorForJuniorAuthors: settings.SeniorForJuniorAuthors,
+		RotationWindowPRs:      settings.RotationWindowPRs,
+		RotationWindowDays:     settings.RotationWindowDays,
+	}
+}
@@ -57,7 +57,7 @@ func TestTeamService_Add_Success(t *testing.T) {
 		}).
 		Return(nil).Once()
 
-	service := team.NewTeamService(mockTRM, mockTeamProvider, mockUserProvider)
+	service := team.NewTeamService(mockTRM, mockTeamProvider, mockUserProvider, nil, nil, nil)
 
 	resp, err := service.Add(ctx, teamName, users)
 
@@ -104,7 +104,7 @@ func TestTeamService_Add_TeamExists(t *testing.T) {
 		Return(repo.ErrTeamExists).
 		Once()
 
-	service := team.NewTeamService(mockTRM, mockTeamProvider, nil)
+	service := team.NewTeamService(mockTRM, mockTeamProvider, nil, nil, nil, nil)
 
 	resp, err := service.Add(ctx, teamName, users)
 
@@ -135,7 +135,7 @@ func TestTeamService_Get_Success(t *testing.T) {
 
 	mockUserProvider.On("GetUsersInTeam", ctx, teamName).Return(users, nil)
 
-	service := team.NewTeamService(nil, mockTeamProvider, mockUserProvider)
+	service := team.NewTeamService(nil, mockTeamProvider, mockUserProvider, nil, nil, nil)
 
 	resp, err := service.Get(ctx, teamName)
 
@@ -155,7 +155,7 @@ func TestTeamService_Get_NotFound(t *testing.T) {
 
 	mockTeamProvider.On("GetByTeamName", ctx, teamName).Return((*models.Team)(nil), repo.ErrNotFound)
 
-	service := team.NewTeamService(nil, mockTeamProvider, nil)
+	service := team.NewTeamService(nil, mockTeamProvider, nil, nil, nil, nil)
 
 	resp, err := service.Get(ctx, teamName)
 
@@ -202,7 +202,7 @@ func TestTeamService_Add_SaveError(t *testing.T) {
 		Return(saveErr).
 		Once()
 
-	service := team.NewTeamService(mockTRM, mockTeamProvider, mockUserProvider)
+	service := team.NewTeamService(mockTRM, mockTeamProvider, mockUserProvider, nil, nil, nil)
 	resp, err := service.Add(ctx, teamName, users)
 
 	assert.Nil(t, resp)
@@ -223,10 +223,364 @@ func TestTeamService_Get_GetUsersError(t *testing.T) {
 	mockTeamProvider.On("GetByTeamName", ctx, teamName).Return(tm, nil)
 	mockUserProvider.On("GetUsersInTeam", ctx, teamName).Return(([]*models.User)(nil), getErr)
 
-	service := team.NewTeamService(nil, mockTeamProvider, mockUserProvider)
+	service := team.NewTeamService(nil, mockTeamProvider, mockUserProvider, nil, nil, nil)
 	resp, err := service.Get(ctx, teamName)
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, getErr)
 }
+
+func TestTeamService_GetSettings_Success(t *testing.T) {
+	ctx := context.Background()
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	maxOpen := 3
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+	mockTeamProvider.On("GetSettings", ctx, 4).
+		Return(&models.TeamSettings{TeamID: 4, ReviewersCount: 2, MaxOpenReviews: &maxOpen}, nil)
+
+	service := team.NewTeamService(nil, mockTeamProvider, nil, nil, nil, nil)
+	resp, err := service.GetSettings(ctx, "backend")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &api.TeamSettingsSchema{TeamName: "backend", ReviewersCount: 2, MaxOpenReviews: &maxOpen}, resp)
+}
+
+func TestTeamService_GetSettings_TeamNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	mockTeamProvider.On("GetByTeamName", ctx, "ghost").Return(nil, repo.ErrNotFound)
+
+	service := team.NewTeamService(nil, mockTeamProvider, nil, nil, nil, nil)
+	resp, err := service.GetSettings(ctx, "ghost")
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+// newSettingsDoMock - менеджер транзакций, который один раз выполняет функцию и ждет от нее wantErr.
+func newSettingsDoMock(t *testing.T, ctx context.Context, wantErr error) *mocks.MockManager {
+	mockTRM := &mocks.MockManager{}
+	mockTRM.Test(t)
+	t.Cleanup(func() { mockTRM.AssertExpectations(t) })
+
+	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), wantErr)
+		}).
+		Return(wantErr).Once()
+
+	return mockTRM
+}
+
+func TestTeamService_SetSettings_Success(t *testing.T) {
+	ctx := context.Background()
+	three := 3
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	mockTRM := newSettingsDoMock(t, ctx, nil)
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+	mockTeamProvider.On("GetSettings", ctx, 4).Return(&models.TeamSettings{TeamID: 4, ReviewersCount: 2}, nil).Once()
+	mockTeamProvider.On("SaveSettings", ctx, &models.TeamSettings{TeamID: 4, ReviewersCount: 3}).Return(nil)
+
+	service := team.NewTeamService(mockTRM, mockTeamProvider, nil, nil, nil, nil)
+	resp, err := service.SetSettings(ctx, &api.TeamSettingsUpdate{TeamName: "backend", ReviewersCount: &three})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &api.TeamSettingsSchema{TeamName: "backend", ReviewersCount: 3}, resp)
+}
+
+func TestTeamService_SetSettings_OmittedFieldsKeepStoredValues(t *testing.T) {
+	ctx := context.Background()
+	one, three, four, five := 1, 3, 4, 5
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	mockTRM := newSettingsDoMock(t, ctx, nil)
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+	mockTeamProvider.On("GetSettings", ctx, 4).Return(&models.TeamSettings{
+		TeamID:             4,
+		ReviewersCount:     2,
+		MaxOpenReviews:     &four,
+		RequiredApprovals:  &one,
+		MinSeniorReviewers: &one,
+		RotationWindowDays: &five,
+	}, nil).Once()
+	// меняется только reviewers_count, остальное сохраняется как было
+	mockTeamProvider.On("SaveSettings", ctx, &models.TeamSettings{
+		TeamID:             4,
+		ReviewersCount:     3,
+		MaxOpenReviews:     &four,
+		RequiredApprovals:  &one,
+		MinSeniorReviewers: &one,
+		RotationWindowDays: &five,
+	}).Return(nil).Once()
+
+	service := team.NewTeamService(mockTRM, mockTeamProvider, nil, nil, nil, nil)
+	resp, err := service.SetSettings(ctx, &api.TeamSettingsUpdate{TeamName: "backend", ReviewersCount: &three})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &four, resp.MaxOpenReviews)
+	assert.Equal(t, &one, resp.RequiredApprovals)
+	assert.Equal(t, &five, resp.RotationWindowDays)
+}
+
+func TestTeamService_SetSettings_ResetAndRotationSwitch(t *testing.T) {
+	ctx := context.Background()
+	one, four, five, ten := 1, 4, 5, 10
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	mockTRM := newSettingsDoMock(t, ctx, nil)
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+	mockTeamProvider.On("GetSettings", ctx, 4).Return(&models.TeamSettings{
+		TeamID:             4,
+		ReviewersCount:     2,
+		MaxOpenReviews:     &four,
+		RequiredApprovals:  &one,
+		RotationWindowDays: &five,
+	}, nil).Once()
+	// лимит сброшен явно, окно по дням заменено окном по PR
+	mockTeamProvider.On("SaveSettings", ctx, &models.TeamSettings{
+		TeamID:            4,
+		ReviewersCount:    2,
+		RequiredApprovals: &one,
+		RotationWindowPRs: &ten,
+	}).Return(nil).Once()
+
+	service := team.NewTeamService(mockTRM, mockTeamProvider, nil, nil, nil, nil)
+	_, err := service.SetSettings(ctx, &api.TeamSettingsUpdate{
+		TeamName:          "backend",
+		RotationWindowPRs: &ten,
+		Reset:             []string{"max_open_reviews"},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestTeamService_SetSettings_SaveError(t *testing.T) {
+	ctx := context.Background()
+	one := 1
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	saveErr := errors.New("save failed")
+	mockTRM := newSettingsDoMock(t, ctx, saveErr)
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+	mockTeamProvider.On("GetSettings", ctx, 4).Return(&models.TeamSettings{TeamID: 4, ReviewersCount: 2}, nil).Once()
+	mockTeamProvider.On("SaveSettings", ctx, mock.AnythingOfType("*models.TeamSettings")).Return(saveErr)
+
+	service := team.NewTeamService(mockTRM, mockTeamProvider, nil, nil, nil, nil)
+	resp, err := service.SetSettings(ctx, &api.TeamSettingsUpdate{TeamName: "backend", ReviewersCount: &one})
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, saveErr)
+}
+
+func TestTeamService_SetSettings_InvalidResult(t *testing.T) {
+	one, two := 1, 2
+
+	cases := []struct {
+		name   string
+		stored *models.TeamSettings
+		input  *api.TeamSettingsUpdate
+	}{
+		{
+			name:   "more than reviewers_count",
+			stored: &models.TeamSettings{TeamID: 4, ReviewersCount: 2, MinSeniorReviewers: &two},
+			// сохраненный минимум сеньоров вместе с новым минимумом джунов не помещается
+			input: &api.TeamSettingsUpdate{TeamName: "backend", MinJuniorReviewers: &one},
+		},
+		{
+			name:   "min juniors above max",
+			stored: &models.TeamSettings{TeamID: 4, ReviewersCount: 3, MaxJuniorReviewers: &one},
+			input:  &api.TeamSettingsUpdate{TeamName: "backend", MinJuniorReviewers: &two},
+		},
+		{
+			name:   "unknown reset field",
+			stored: &models.TeamSettings{TeamID: 4, ReviewersCount: 2},
+			input:  &api.TeamSettingsUpdate{TeamName: "backend", Reset: []string{"team_name"}},
+		},
+		{
+			name:   "set and reset",
+			stored: &models.TeamSettings{TeamID: 4, ReviewersCount: 2},
+			input:  &api.TeamSettingsUpdate{TeamName: "backend", MaxOpenReviews: &two, Reset: []string{"max_open_reviews"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockTeamProvider := mocks.NewTeamProvider(t)
+			mockTRM := newSettingsDoMock(t, ctx, repo.ErrInvalidSettings)
+
+			mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+			mockTeamProvider.On("GetSettings", ctx, 4).Return(tc.stored, nil).Once()
+
+			service := team.NewTeamService(mockTRM, mockTeamProvider, nil, nil, nil, nil)
+			_, err := service.SetSettings(ctx, tc.input)
+
+			assert.ErrorIs(t, err, repo.ErrInvalidSettings)
+			mockTeamProvider.AssertNotCalled(t, "SaveSettings", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTeamService_SetSettings_BothRotationWindows(t *testing.T) {
+	one, two := 1, 2
+
+	service := team.NewTeamService(nil, nil, nil, nil, nil, nil)
+	_, err := service.SetSettings(context.Background(), &api.TeamSettingsUpdate{
+		TeamName:           "backend",
+		RotationWindowPRs:  &two,
+		RotationWindowDays: &one,
+	})
+
+	assert.ErrorIs(t, err, repo.ErrInvalidSettings)
+}
+
+func TestTeamService_GetPairings_FillsMatrix(t *testing.T) {
+	ctx := context.Background()
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	mockUserProvider := mocks.NewUserProvider(t)
+	mockPairings := mocks.NewPairingProvider(t)
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 1, Name: "backend"}, nil).Once()
+	mockUserProvider.On("GetUsersInTeam", ctx, "backend").Return([]*models.User{
+		{ID: "u2", TeamID: 1}, {ID: "u1", TeamID: 1}, {ID: "u3", TeamID: 1},
+	}, nil).Once()
+	mockPairings.On("GetTeamPairings", ctx, 1, mock.AnythingOfType("*time.Time")).Return([]*models.ReviewPairing{
+		{AuthorID: "u1", ReviewerID: "u2", Count: 4},
+		{AuthorID: "u2", ReviewerID: "u1", Count: 1},
+		// ушедший из команды ревьюер
+		{AuthorID: "u1", ReviewerID: "gone", Count: 2},
+	}, nil).Once()
+
+	days := 30
+	service := team.NewTeamService(nil, mockTeamProvider, mockUserProvider, nil, nil, mockPairings)
+	resp, err := service.GetPairings(ctx, "backend", &days)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2", "u3"}, resp.Members)
+	assert.Equal(t, map[string]map[string]int{
+		"u1": {"u2": 4, "u3": 0},
+		"u2": {"u1": 1, "u3": 0},
+		"u3": {"u1": 0, "u2": 0},
+	}, resp.Matrix)
+	assert.Equal(t, 5, resp.Total)
+}
+
+func TestTeamService_DeactivateUsers_WholeTeam(t *testing.T) {
+	ctx := context.Background()
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	mockUserProvider := mocks.NewUserProvider(t)
+	mockReassigner := mocks.NewReviewReassigner(t)
+	mockTRM := &mocks.MockManager{}
+	mockTRM.Test(t)
+	t.Cleanup(func() { mockTRM.AssertExpectations(t) })
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+	mockUserProvider.On("GetUsersInTeam", ctx, "backend").Return([]*models.User{
+		{ID: "u1", TeamID: 4, IsActive: true},
+		{ID: "u2", TeamID: 4, IsActive: false},
+		{ID: "u3", TeamID: 4, IsActive: true},
+	}, nil)
+	// уже неактивный u2 повторно не выключается, но его ревью тоже снимаются
+	mockUserProvider.On("DeactivateUsers", ctx, []string{"u1", "u3"}).Return(nil)
+	mockReassigner.On("ReassignOpenReviews", ctx, 4, []string{"u1", "u2", "u3"}, "reviewer deactivated").Return(
+		[]api.ReviewReplacement{},
+		[]string{"pr-1"},
+		nil,
+	)
+
+	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).
+		Return(nil).Once()
+
+	service := team.NewTeamService(mockTRM, mockTeamProvider, mockUserProvider, mockReassigner, nil, nil)
+	resp, err := service.DeactivateUsers(ctx, "backend", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &api.DeactivateUsersResponse{
+		TeamName:         "backend",
+		DeactivatedUsers: []string{"u1", "u3"},
+		Reassigned:       []api.ReviewReplacement{},
+		Understaffed:     []string{"pr-1"},
+	}, resp)
+}
+
+func TestTeamService_DeactivateUsers_SelectedUsers(t *testing.T) {
+	ctx := context.Background()
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	mockUserProvider := mocks.NewUserProvider(t)
+	mockReassigner := mocks.NewReviewReassigner(t)
+	mockTRM := &mocks.MockManager{}
+	mockTRM.Test(t)
+	t.Cleanup(func() { mockTRM.AssertExpectations(t) })
+
+	replacements := []api.ReviewReplacement{{PullRequestID: "pr-1", OldReviewerID: "u1", ReplacedBy: "u3"}}
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+	mockUserProvider.On("GetUsersInTeam", ctx, "backend").Return([]*models.User{
+		{ID: "u1", TeamID: 4, IsActive: true},
+		{ID: "u3", TeamID: 4, IsActive: true},
+	}, nil)
+	mockUserProvider.On("DeactivateUsers", ctx, []string{"u1"}).Return(nil)
+	mockReassigner.On("ReassignOpenReviews", ctx, 4, []string{"u1"}, "reviewer deactivated").Return(replacements, []string{}, nil)
+
+	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).
+		Return(nil).Once()
+
+	service := team.NewTeamService(mockTRM, mockTeamProvider, mockUserProvider, mockReassigner, nil, nil)
+	resp, err := service.DeactivateUsers(ctx, "backend", []string{"u1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, resp.DeactivatedUsers)
+	assert.Equal(t, replacements, resp.Reassigned)
+}
+
+func TestTeamService_DeactivateUsers_UserNotInTeam(t *testing.T) {
+	ctx := context.Background()
+
+	mockTeamProvider := mocks.NewTeamProvider(t)
+	mockUserProvider := mocks.NewUserProvider(t)
+	mockReassigner := mocks.NewReviewReassigner(t)
+	mockTRM := &mocks.MockManager{}
+	mockTRM.Test(t)
+	t.Cleanup(func() { mockTRM.AssertExpectations(t) })
+
+	mockTeamProvider.On("GetByTeamName", ctx, "backend").Return(&models.Team{ID: 4, Name: "backend"}, nil)
+	mockUserProvider.On("GetUsersInTeam", ctx, "backend").Return([]*models.User{
+		{ID: "u1", TeamID: 4, IsActive: true},
+	}, nil)
+
+	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), repo.ErrNotFound)
+		}).
+		Return(repo.ErrNotFound).Once()
+
+	service := team.NewTeamService(mockTRM, mockTeamProvider, mockUserProvider, mockReassigner, nil, nil)
+	resp, err := service.DeactivateUsers(ctx, "backend", []string{"u1", "stranger"})
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	mockUserProvider.AssertNotCalled(t, "DeactivateUsers", mock.Anything, mock.Anything)
+}
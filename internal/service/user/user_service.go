@@ -4,8 +4,10 @@ import (
 	"context"
 
 	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib"
 	"avito-intership-2025/internal/models"
 	"avito-intership-2025/internal/service"
+	prservice "avito-intership-2025/internal/service/pr"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=PrProvider
@@ -16,12 +18,16 @@ type PrProvider interface {
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=TeamIDProvider
 type TeamIDProvider interface {
 	GetTeamNameByID(ctx context.Context, teamID int) (string, error)
+	GetSettings(ctx context.Context, teamID int) (*models.TeamSettings, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=UserChanger
 type UserChanger interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
 	GetById(ctx context.Context, userID string) (*models.User, error)
+	SetSkills(ctx context.Context, userID string, skills []string) error
+	SetSeniority(ctx context.Context, userID, seniority string) error
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) error
 }
 
 type UserService struct {
@@ -29,6 +35,7 @@ type UserService struct {
 	prProvider     PrProvider
 	userChanger    UserChanger
 	teamIDProvider TeamIDProvider
+	events         service.EventRecorder
 }
 
 func NewUserService(
@@ -36,12 +43,14 @@ func NewUserService(
 	prProvider PrProvider,
 	userChanger UserChanger,
 	teamIDProvider TeamIDProvider,
+	events service.EventRecorder,
 ) *UserService {
 	return &UserService{
 		trm:            trm,
 		prProvider:     prProvider,
 		userChanger:    userChanger,
 		teamIDProvider: teamIDProvider,
+		events:         events,
 	}
 }
 
@@ -49,7 +58,53 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 	resp := &api.UserSchema{}
 
 	err := s.trm.Do(ctx, func(ctx context.Context) error {
-		err := s.userChanger.SetIsActive(ctx, userID, isActive)
+		user, err := s.userChanger.GetById(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		// повторная установка того же статуса ничего не меняет и не попадает в историю
+		if user.IsActive != isActive {
+			err = s.userChanger.SetIsActive(ctx, userID, isActive)
+			if err != nil {
+				return err
+			}
+			user.IsActive = isActive
+
+			err = service.RecordEvents(ctx, s.events, &models.ReviewEvent{
+				Type:     models.EventActivityChanged,
+				UserID:   user.ID,
+				IsActive: &user.IsActive,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		teamName, err := s.teamIDProvider.GetTeamNameByID(ctx, user.TeamID)
+		if err != nil {
+			return err
+		}
+
+		resp.UserID = user.ID
+		resp.Username = user.Name
+		resp.TeamName = teamName
+		resp.IsActive = user.IsActive
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetSkills заменяет навыки пользователя, по ним ревьюеры подбираются под метки PR.
+func (s *UserService) SetSkills(ctx context.Context, userID string, skills []string) (*api.UserSchema, error) {
+	resp := &api.UserSchema{}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		err := s.userChanger.SetSkills(ctx, userID, lib.NormalizeTags(skills))
 		if err != nil {
 			return err
 		}
@@ -68,6 +123,8 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 		resp.Username = user.Name
 		resp.TeamName = teamName
 		resp.IsActive = user.IsActive
+		resp.Skills = user.Skills
+		resp.Seniority = user.Seniority
 
 		return nil
 	})
@@ -77,6 +134,79 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 	return resp, nil
 }
 
+// SetSeniority меняет уровень пользователя, по нему собирается состав ревьюеров PR.
+func (s *UserService) SetSeniority(ctx context.Context, userID, seniority string) (*api.UserSchema, error) {
+	resp := &api.UserSchema{}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		err := s.userChanger.SetSeniority(ctx, userID, seniority)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userChanger.GetById(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		teamName, err := s.teamIDProvider.GetTeamNameByID(ctx, user.TeamID)
+		if err != nil {
+			return err
+		}
+
+		resp.UserID = user.ID
+		resp.Username = user.Name
+		resp.TeamName = teamName
+		resp.IsActive = user.IsActive
+		resp.Skills = user.Skills
+		resp.Seniority = user.Seniority
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetMaxOpenReviews задает личный лимит открытых ревью пользователя, nil возвращает лимит команды.
+func (s *UserService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*api.UserSchema, error) {
+	resp := &api.UserSchema{}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		err := s.userChanger.SetMaxOpenReviews(ctx, userID, limit)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userChanger.GetById(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		teamName, err := s.teamIDProvider.GetTeamNameByID(ctx, user.TeamID)
+		if err != nil {
+			return err
+		}
+
+		resp.UserID = user.ID
+		resp.Username = user.Name
+		resp.TeamName = teamName
+		resp.IsActive = user.IsActive
+		resp.Skills = user.Skills
+		resp.Seniority = user.Seniority
+		resp.MaxOpenReviews = user.MaxOpenReviews
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetReview возвращает PR, где пользователь ревьюер, и его нагрузку относительно лимита:
+// личного, а без него - лимита команды.
 func (s *UserService) GetReview(ctx context.Context, userID string) (*api.GetReviewResponse, error) {
 	resp := &api.GetReviewResponse{
 		UserID:       userID,
@@ -84,7 +214,7 @@ func (s *UserService) GetReview(ctx context.Context, userID string) (*api.GetRev
 	}
 
 	err := s.trm.Do(ctx, func(ctx context.Context) error {
-		_, err := s.userChanger.GetById(ctx, userID)
+		user, err := s.userChanger.GetById(ctx, userID)
 		if err != nil {
 			return err
 		}
@@ -94,12 +224,26 @@ func (s *UserService) GetReview(ctx context.Context, userID string) (*api.GetRev
 			return err
 		}
 
+		resp.MaxOpenReviews = user.MaxOpenReviews
+		if resp.MaxOpenReviews == nil {
+			settings, err := s.teamIDProvider.GetSettings(ctx, user.TeamID)
+			if err != nil {
+				return err
+			}
+			resp.MaxOpenReviews = settings.MaxOpenReviews
+		}
+
 		for _, pr := range prs {
+			if pr.Status == prservice.StatusOpen {
+				resp.OpenReviews++
+			}
+
 			short := api.PullRequestShort{
 				ID:       pr.ID,
 				Name:     pr.Title,
 				AuthorID: pr.AuthorId,
 				Status:   pr.Status,
+				Labels:   pr.Labels,
 			}
 
 			resp.PullRequests = append(resp.PullRequests, short)
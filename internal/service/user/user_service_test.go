@@ -5,7 +5,9 @@ import (
 	"errors"
 	"testing"
 
+	"avito-intership-2025/internal/lib/actor"
 	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
 	"avito-intership-2025/internal/service/mocks"
 	u "avito-intership-2025/internal/service/user"
 	"github.com/stretchr/testify/assert"
@@ -26,16 +28,15 @@ func TestUserService_SetIsActive_Success(t *testing.T) {
 	userID := "u123"
 	isActive := true
 	user := &models.User{
-		ID:       userID,
-		Name:     "Alice",
-		TeamID:   42,
-		IsActive: isActive,
+		ID:     userID,
+		Name:   "Alice",
+		TeamID: 42,
 	}
 	teamName := "backend"
 
 	// Expectations inside transaction
-	mockUserChanger.On("SetIsActive", ctx, userID, isActive).Return(nil).Once()
 	mockUserChanger.On("GetById", ctx, userID).Return(user, nil).Once()
+	mockUserChanger.On("SetIsActive", ctx, userID, isActive).Return(nil).Once()
 	mockTeamIDProvider.On("GetTeamNameByID", ctx, 42).Return(teamName, nil).Once()
 
 	// Transaction manager should execute provided function and return nil
@@ -47,7 +48,7 @@ func TestUserService_SetIsActive_Success(t *testing.T) {
 		Return(nil).
 		Once()
 
-	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, mockTeamIDProvider)
+	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, mockTeamIDProvider, nil)
 	resp, err := service.SetIsActive(ctx, userID, isActive)
 
 	assert.NoError(t, err)
@@ -58,6 +59,74 @@ func TestUserService_SetIsActive_Success(t *testing.T) {
 	assert.True(t, resp.IsActive)
 }
 
+func TestUserService_SetIsActive_RecordsEvent(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "alice")
+
+	mockTRM := &mocks.MockManager{}
+	mockTRM.Test(t)
+	t.Cleanup(func() { mockTRM.AssertExpectations(t) })
+
+	mockUserChanger := mocks.NewUserChanger(t)
+	mockTeamIDProvider := mocks.NewTeamIDProvider(t)
+	mockEvents := mocks.NewEventRecorder(t)
+
+	isActive := false
+	mockUserChanger.On("GetById", ctx, "u1").Return(&models.User{ID: "u1", TeamID: 1, IsActive: true}, nil).Once()
+	mockUserChanger.On("SetIsActive", ctx, "u1", false).Return(nil).Once()
+	mockEvents.On("AddEvents", ctx, []*models.ReviewEvent{{
+		Type:     models.EventActivityChanged,
+		UserID:   "u1",
+		IsActive: &isActive,
+		Actor:    "alice",
+	}}).Return(nil).Once()
+	mockTeamIDProvider.On("GetTeamNameByID", ctx, 1).Return("backend", nil).Once()
+
+	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).
+		Return(nil).
+		Once()
+
+	service := u.NewUserService(mockTRM, nil, mockUserChanger, mockTeamIDProvider, mockEvents)
+	resp, err := service.SetIsActive(ctx, "u1", false)
+
+	assert.NoError(t, err)
+	assert.False(t, resp.IsActive)
+}
+
+func TestUserService_SetIsActive_UnchangedSkipsWriteAndEvent(t *testing.T) {
+	ctx := context.Background()
+
+	mockTRM := &mocks.MockManager{}
+	mockTRM.Test(t)
+	t.Cleanup(func() { mockTRM.AssertExpectations(t) })
+
+	mockUserChanger := mocks.NewUserChanger(t)
+	mockTeamIDProvider := mocks.NewTeamIDProvider(t)
+	mockEvents := mocks.NewEventRecorder(t)
+
+	mockUserChanger.On("GetById", ctx, "u1").Return(&models.User{ID: "u1", TeamID: 1, IsActive: true}, nil).Once()
+	mockTeamIDProvider.On("GetTeamNameByID", ctx, 1).Return("backend", nil).Once()
+
+	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).
+		Return(nil).
+		Once()
+
+	service := u.NewUserService(mockTRM, nil, mockUserChanger, mockTeamIDProvider, mockEvents)
+	resp, err := service.SetIsActive(ctx, "u1", true)
+
+	assert.NoError(t, err)
+	assert.True(t, resp.IsActive)
+	mockUserChanger.AssertNotCalled(t, "SetIsActive", mock.Anything, mock.Anything, mock.Anything)
+	mockEvents.AssertNotCalled(t, "AddEvents", mock.Anything, mock.Anything)
+}
+
 func TestUserService_SetIsActive_SetIsActiveError(t *testing.T) {
 	ctx := context.Background()
 
@@ -71,7 +140,7 @@ func TestUserService_SetIsActive_SetIsActiveError(t *testing.T) {
 	isActive := false
 	dbErr := errors.New("failed to update")
 
-	// First call in tx fails
+	mockUserChanger.On("GetById", ctx, userID).Return(&models.User{ID: userID, IsActive: true}, nil).Once()
 	mockUserChanger.On("SetIsActive", ctx, userID, isActive).Return(dbErr).Once()
 
 	// Transaction should propagate error from inner function
@@ -85,7 +154,7 @@ func TestUserService_SetIsActive_SetIsActiveError(t *testing.T) {
 		Return(dbErr).
 		Once()
 
-	service := u.NewUserService(mockTRM, nil, mockUserChanger, nil)
+	service := u.NewUserService(mockTRM, nil, mockUserChanger, nil, nil)
 	resp, err := service.SetIsActive(ctx, userID, isActive)
 
 	assert.Nil(t, resp)
@@ -106,7 +175,6 @@ func TestUserService_SetIsActive_GetByIdError(t *testing.T) {
 	isActive := true
 	dbErr := errors.New("user not found")
 
-	mockUserChanger.On("SetIsActive", ctx, userID, isActive).Return(nil).Once()
 	mockUserChanger.On("GetById", ctx, userID).Return((*models.User)(nil), dbErr).Once()
 
 	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
@@ -119,7 +187,7 @@ func TestUserService_SetIsActive_GetByIdError(t *testing.T) {
 		Return(dbErr).
 		Once()
 
-	service := u.NewUserService(mockTRM, nil, mockUserChanger, nil)
+	service := u.NewUserService(mockTRM, nil, mockUserChanger, nil, nil)
 	resp, err := service.SetIsActive(ctx, userID, isActive)
 
 	assert.Nil(t, resp)
@@ -142,10 +210,10 @@ func TestUserService_SetIsActive_GetTeamNameByIDError(t *testing.T) {
 	teamID := 100
 	dbErr := errors.New("team not found")
 
-	mockUserChanger.On("SetIsActive", ctx, userID, isActive).Return(nil).Once()
 	mockUserChanger.On("GetById", ctx, userID).
-		Return(&models.User{ID: userID, Name: "Bob", TeamID: teamID, IsActive: isActive}, nil).
+		Return(&models.User{ID: userID, Name: "Bob", TeamID: teamID}, nil).
 		Once()
+	mockUserChanger.On("SetIsActive", ctx, userID, isActive).Return(nil).Once()
 	mockTeamIDProvider.On("GetTeamNameByID", ctx, teamID).Return("", dbErr).Once()
 
 	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
@@ -158,7 +226,7 @@ func TestUserService_SetIsActive_GetTeamNameByIDError(t *testing.T) {
 		Return(dbErr).
 		Once()
 
-	service := u.NewUserService(mockTRM, nil, mockUserChanger, mockTeamIDProvider)
+	service := u.NewUserService(mockTRM, nil, mockUserChanger, mockTeamIDProvider, nil)
 	resp, err := service.SetIsActive(ctx, userID, isActive)
 
 	assert.Nil(t, resp)
@@ -193,8 +261,12 @@ func TestUserService_GetReview_Success_WithPRs(t *testing.T) {
 	}
 
 	// Expectations inside transaction
-	mockUserChanger.On("GetById", ctx, userID).Return(&models.User{ID: userID}, nil).Once()
+	mockTeamIDProvider := mocks.NewTeamIDProvider(t)
+	maxOpen := 3
+
+	mockUserChanger.On("GetById", ctx, userID).Return(&models.User{ID: userID, TeamID: 1}, nil).Once()
 	mockPrProvider.On("GetUserReviews", ctx, userID).Return(prs, nil).Once()
+	mockTeamIDProvider.On("GetSettings", ctx, 1).Return(&models.TeamSettings{TeamID: 1, MaxOpenReviews: &maxOpen}, nil).Once()
 
 	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
 		Run(func(args mock.Arguments) {
@@ -204,13 +276,16 @@ func TestUserService_GetReview_Success_WithPRs(t *testing.T) {
 		Return(nil).
 		Once()
 
-	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, nil)
+	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, mockTeamIDProvider, nil)
 	resp, err := service.GetReview(ctx, userID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, userID, resp.UserID)
 	assert.Len(t, resp.PullRequests, 2)
+	// нагрузка - только открытые PR, лимит берется из команды
+	assert.Equal(t, 1, resp.OpenReviews)
+	assert.Equal(t, &maxOpen, resp.MaxOpenReviews)
 
 	assert.Equal(t, "pr-1", resp.PullRequests[0].ID)
 	assert.Equal(t, "Fix login", resp.PullRequests[0].Name)
@@ -234,7 +309,10 @@ func TestUserService_GetReview_Success_NoPRs(t *testing.T) {
 
 	userID := "u789"
 
-	mockUserChanger.On("GetById", ctx, userID).Return(&models.User{ID: userID}, nil).Once()
+	ownLimit := 2
+
+	// личный лимит перекрывает командный, настройки команды не нужны
+	mockUserChanger.On("GetById", ctx, userID).Return(&models.User{ID: userID, MaxOpenReviews: &ownLimit}, nil).Once()
 	mockPrProvider.On("GetUserReviews", ctx, userID).Return([]*models.PullRequest{}, nil).Once()
 
 	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
@@ -245,13 +323,15 @@ func TestUserService_GetReview_Success_NoPRs(t *testing.T) {
 		Return(nil).
 		Once()
 
-	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, nil)
+	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, nil, nil)
 	resp, err := service.GetReview(ctx, userID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, userID, resp.UserID)
 	assert.Empty(t, resp.PullRequests)
+	assert.Zero(t, resp.OpenReviews)
+	assert.Equal(t, &ownLimit, resp.MaxOpenReviews)
 }
 
 func TestUserService_GetReview_GetByIdError(t *testing.T) {
@@ -279,7 +359,7 @@ func TestUserService_GetReview_GetByIdError(t *testing.T) {
 		Return(dbErr).
 		Once()
 
-	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, nil)
+	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, nil, nil)
 	resp, err := service.GetReview(ctx, userID)
 
 	assert.Nil(t, resp)
@@ -313,10 +393,77 @@ func TestUserService_GetReview_PrProviderError(t *testing.T) {
 		Return(prErr).
 		Once()
 
-	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, nil)
+	service := u.NewUserService(mockTRM, mockPrProvider, mockUserChanger, nil, nil)
 	resp, err := service.GetReview(ctx, userID)
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, prErr)
 }
+
+func TestUserService_SetSkills_Success(t *testing.T) {
+	ctx := context.Background()
+
+	mockTRM := &mocks.MockManager{}
+	mockTRM.Test(t)
+	t.Cleanup(func() { mockTRM.AssertExpectations(t) })
+
+	mockUserChanger := mocks.NewUserChanger(t)
+	mockTeamIDProvider := mocks.NewTeamIDProvider(t)
+
+	userID := "u123"
+	user := &models.User{
+		ID:       userID,
+		Name:     "Alice",
+		TeamID:   42,
+		IsActive: true,
+		Skills:   []string{"go", "postgres"},
+	}
+
+	// навыки нормализуются перед сохранением
+	mockUserChanger.On("SetSkills", ctx, userID, []string{"go", "postgres"}).Return(nil).Once()
+	mockUserChanger.On("GetById", ctx, userID).Return(user, nil).Once()
+	mockTeamIDProvider.On("GetTeamNameByID", ctx, 42).Return("backend", nil).Once()
+
+	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.NoError(t, fn(ctx))
+		}).
+		Return(nil).
+		Once()
+
+	service := u.NewUserService(mockTRM, nil, mockUserChanger, mockTeamIDProvider, nil)
+	resp, err := service.SetSkills(ctx, userID, []string{"Postgres ", "go", ""})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, []string{"go", "postgres"}, resp.Skills)
+	assert.Equal(t, "backend", resp.TeamName)
+}
+
+func TestUserService_SetSkills_NotFound(t *testing.T) {
+	ctx := context.Background()
+
+	mockTRM := &mocks.MockManager{}
+	mockTRM.Test(t)
+	t.Cleanup(func() { mockTRM.AssertExpectations(t) })
+
+	mockUserChanger := mocks.NewUserChanger(t)
+
+	mockUserChanger.On("SetSkills", ctx, "u404", []string{"go"}).Return(repo.ErrNotFound).Once()
+
+	mockTRM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), repo.ErrNotFound)
+		}).
+		Return(repo.ErrNotFound).
+		Once()
+
+	service := u.NewUserService(mockTRM, nil, mockUserChanger, nil, nil)
+	resp, err := service.SetSkills(ctx, "u404", []string{"go"})
+
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	assert.Nil(t, resp)
+}
@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/signature"
+	"avito-intership-2025/internal/lib/sl"
+	"avito-intership-2025/internal/lib/worker"
+	"avito-intership-2025/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Заголовки запроса вебхука.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature-256"
+)
+
+// maxErrorLen ограничивает текст ошибки, сохраняемый в доставке.
+const maxErrorLen = 500
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=DeliveryQueue
+type DeliveryQueue interface {
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id int64, attempts int) error
+	MarkFailed(ctx context.Context, id int64, attempts int, nextAttemptAt *time.Time, lastError string) error
+}
+
+// RetryPolicy задает повторные попытки доставки: задержка удваивается после каждой
+// неудачи, начиная с InitialBackoff и не превышая MaxBackoff. После MaxAttempts
+// неудачных попыток доставка переходит в DEAD.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff возвращает задержку перед следующей попыткой после attempt неудачных.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+	return delay
+}
+
+// Dispatcher рассылает события из outbox подписчикам. Доставка выполняется хотя бы раз:
+// при сбое между отправкой и отметкой событие уйдет повторно.
+type Dispatcher struct {
+	log       *slog.Logger
+	queue     DeliveryQueue
+	client    *http.Client
+	policy    RetryPolicy
+	batchSize int
+	interval  time.Duration
+}
+
+func NewDispatcher(
+	log *slog.Logger,
+	queue DeliveryQueue,
+	policy RetryPolicy,
+	batchSize int,
+	timeout time.Duration,
+	interval time.Duration,
+) *Dispatcher {
+	return &Dispatcher{
+		log:       log.With(slog.String("worker", "webhook_dispatcher")),
+		queue:     queue,
+		client:    &http.Client{Timeout: timeout},
+		policy:    policy,
+		batchSize: batchSize,
+		interval:  interval,
+	}
+}
+
+// Run блокируется до отмены ctx. Первая рассылка выполняется сразу при запуске.
+func (d *Dispatcher) Run(ctx context.Context) {
+	worker.Every(ctx, d.interval, d.tick)
+	d.log.Info("webhook dispatcher stopped")
+}
+
+func (d *Dispatcher) tick(ctx context.Context) {
+	if _, err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+		d.log.Error("failed to dispatch webhooks", sl.Err(err))
+	}
+}
+
+// DispatchDue рассылает доставки, время которых наступило, пока очередь не опустеет.
+// Возвращает число выполненных попыток.
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	// пока пачка рассылается, другие экземпляры ее не берут
+	lease := d.client.Timeout * time.Duration(d.batchSize+1)
+
+	processed := 0
+	for ctx.Err() == nil {
+		deliveries, err := d.queue.ClaimDueDeliveries(ctx, d.batchSize, lease)
+		if err != nil {
+			return processed, err
+		}
+
+		var errs []error
+		for _, del := range deliveries {
+			if err := d.attempt(ctx, del); err != nil {
+				errs = append(errs, fmt.Errorf("delivery %d: %w", del.ID, err))
+			}
+			processed++
+		}
+		if err := errors.Join(errs...); err != nil {
+			return processed, err
+		}
+
+		if len(deliveries) < d.batchSize {
+			break
+		}
+	}
+
+	return processed, nil
+}
+
+// attempt отправляет доставку и записывает результат попытки.
+func (d *Dispatcher) attempt(ctx context.Context, del *models.WebhookDelivery) error {
+	attempts := del.Attempts + 1
+
+	sendErr := d.send(ctx, del)
+	if sendErr == nil {
+		return d.queue.MarkDelivered(ctx, del.ID, attempts)
+	}
+
+	log := d.log.With(
+		slog.Int64("delivery_id", del.ID),
+		slog.String("url", del.URL),
+		slog.Int("attempts", attempts),
+		sl.Err(sendErr),
+	)
+
+	var next *time.Time
+	if attempts < d.policy.MaxAttempts {
+		at := time.Now().Add(d.policy.Backoff(attempts))
+		next = &at
+		log.Warn("webhook delivery failed, will retry", slog.Time("next_attempt_at", at))
+	} else {
+		log.Error("webhook delivery failed, giving up")
+	}
+
+	lastError := sendErr.Error()
+	if len(lastError) > maxErrorLen {
+		lastError = lastError[:maxErrorLen]
+	}
+	return d.queue.MarkFailed(ctx, del.ID, attempts, next, lastError)
+}
+
+func (d *Dispatcher) send(ctx context.Context, del *models.WebhookDelivery) error {
+	body, err := json.Marshal(api.WebhookEvent{
+		EventID:   del.EventID,
+		EventType: del.EventType,
+		CreatedAt: del.EventCreatedAt,
+		Data:      del.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, del.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, del.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(del.ID, 10))
+	req.Header.Set(HeaderSignature, signature.Sign(del.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// тело ответа не нужно, но дочитываем его, чтобы соединение переиспользовалось
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
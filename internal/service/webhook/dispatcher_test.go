@@ -0,0 +1,126 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib/signature"
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var policy = webhook.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute, MaxBackoff: time.Hour}
+
+func newDispatcher(queue webhook.DeliveryQueue) *webhook.Dispatcher {
+	return webhook.NewDispatcher(slog.New(slog.DiscardHandler), queue, policy, 10, time.Second, time.Minute)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := webhook.RetryPolicy{MaxAttempts: 10, InitialBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, p.Backoff(1))
+	assert.Equal(t, time.Minute, p.Backoff(2))
+	assert.Equal(t, 4*time.Minute, p.Backoff(4))
+	assert.Equal(t, 5*time.Minute, p.Backoff(5))
+	assert.Equal(t, 5*time.Minute, p.Backoff(60))
+}
+
+func TestDispatcher_DispatchDue_Delivered(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	var received api.WebhookEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, models.WebhookPrMerged, r.Header.Get(webhook.HeaderEvent))
+		assert.Equal(t, "7", r.Header.Get(webhook.HeaderDelivery))
+		assert.True(t, signature.Verify("s3cret", body, r.Header.Get(webhook.HeaderSignature)))
+		assert.NoError(t, json.Unmarshal(body, &received))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	queue := mocks.NewDeliveryQueue(t)
+	queue.On("ClaimDueDeliveries", ctx, 10, 11*time.Second).Return([]*models.WebhookDelivery{{
+		ID:             7,
+		EventID:        3,
+		EventType:      models.WebhookPrMerged,
+		Payload:        []byte(`{"pull_request":{"pull_request_id":"pr1"}}`),
+		EventCreatedAt: createdAt,
+		URL:            srv.URL,
+		Secret:         "s3cret",
+	}}, nil).Once()
+	queue.On("MarkDelivered", ctx, int64(7), 1).Return(nil).Once()
+
+	processed, err := newDispatcher(queue).DispatchDue(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, int64(3), received.EventID)
+	assert.Equal(t, models.WebhookPrMerged, received.EventType)
+	assert.True(t, createdAt.Equal(received.CreatedAt))
+	assert.JSONEq(t, `{"pull_request":{"pull_request_id":"pr1"}}`, string(received.Data))
+}
+
+func TestDispatcher_DispatchDue_RetryAndDeadLetter(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	queue := mocks.NewDeliveryQueue(t)
+	queue.On("ClaimDueDeliveries", ctx, 10, 11*time.Second).Return([]*models.WebhookDelivery{
+		{ID: 1, EventType: models.WebhookPrCreated, Payload: []byte(`{}`), URL: srv.URL, Attempts: 1},
+		// последняя попытка
+		{ID: 2, EventType: models.WebhookPrCreated, Payload: []byte(`{}`), URL: srv.URL, Attempts: 2},
+	}, nil).Once()
+
+	before := time.Now()
+	queue.On("MarkFailed", ctx, int64(1), 2, mock.MatchedBy(func(next *time.Time) bool {
+		// вторая неудача - задержка удваивается
+		return next != nil && !next.Before(before.Add(2*time.Minute)) && next.Before(time.Now().Add(2*time.Minute))
+	}), "unexpected response status 503").Return(nil).Once()
+	queue.On("MarkFailed", ctx, int64(2), 3, (*time.Time)(nil), "unexpected response status 503").Return(nil).Once()
+
+	processed, err := newDispatcher(queue).DispatchDue(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, processed)
+}
+
+func TestDispatcher_DispatchDue_Unreachable(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	queue := mocks.NewDeliveryQueue(t)
+	queue.On("ClaimDueDeliveries", ctx, 10, 11*time.Second).Return([]*models.WebhookDelivery{
+		{ID: 1, EventType: models.WebhookPrCreated, Payload: []byte(`{}`), URL: url},
+	}, nil).Once()
+	queue.On("MarkFailed", ctx, int64(1), 1, mock.AnythingOfType("*time.Time"),
+		mock.MatchedBy(func(msg string) bool { return msg != "" })).Return(nil).Once()
+
+	processed, err := newDispatcher(queue).DispatchDue(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/lib"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// deliveriesLimit - сколько последних доставок возвращает ListDeliveries.
+const deliveriesLimit = 100
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=SubscriptionStore
+type SubscriptionStore interface {
+	CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int) error
+	ListDeliveries(ctx context.Context, status string, subscriptionID int, limit int) ([]*models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, id int64) error
+	ReplayDead(ctx context.Context, subscriptionID int) (int, error)
+}
+
+type WebhookService struct {
+	store SubscriptionStore
+	trm   service.TransactionManager
+}
+
+func NewWebhookService(trm service.TransactionManager, store SubscriptionStore) *WebhookService {
+	return &WebhookService{
+		trm:   trm,
+		store: store,
+	}
+}
+
+// AddSubscription регистрирует адрес для рассылки событий. Без секрета он генерируется;
+// секрет возвращается только в ответе на создание.
+func (s *WebhookService) AddSubscription(
+	ctx context.Context,
+	input *api.WebhookSubscriptionSchema,
+) (*api.WebhookSubscriptionSchema, error) {
+	u, err := url.Parse(input.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) url", repo.ErrInvalidSubscription)
+	}
+
+	eventTypes := lib.NormalizeTags(input.EventTypes)
+	for _, t := range eventTypes {
+		if !slices.Contains(models.WebhookEventTypes, t) {
+			return nil, fmt.Errorf("%w: unknown event type %q", repo.ErrInvalidSubscription, t)
+		}
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		secret, err = newSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *api.WebhookSubscriptionSchema
+
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		sub, err := s.store.CreateSubscription(ctx, &models.WebhookSubscription{
+			URL:        input.URL,
+			Secret:     secret,
+			EventTypes: eventTypes,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp = toSubscriptionSchema(sub)
+		resp.Secret = sub.Secret
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]api.WebhookSubscriptionSchema, error) {
+	var resp []api.WebhookSubscriptionSchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = s.listSubscriptions(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с недоставленными событиями и возвращает оставшиеся подписки.
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id int) ([]api.WebhookSubscriptionSchema, error) {
+	var resp []api.WebhookSubscriptionSchema
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := s.store.DeleteSubscription(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		resp, err = s.listSubscriptions(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListDeliveries возвращает последние доставки, при необходимости по статусу и подписке.
+func (s *WebhookService) ListDeliveries(
+	ctx context.Context,
+	status string,
+	subscriptionID int,
+) ([]api.WebhookDeliverySchema, error) {
+	if status != "" && !slices.Contains([]string{
+		models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead,
+	}, status) {
+		return nil, fmt.Errorf("%w: unknown delivery status %q", repo.ErrInvalidSubscription, status)
+	}
+
+	resp := []api.WebhookDeliverySchema{}
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		deliveries, err := s.store.ListDeliveries(ctx, status, subscriptionID, deliveriesLimit)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range deliveries {
+			resp = append(resp, toDeliverySchema(d))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Replay ставит в очередь заново доставку deliveryID в любом статусе, а без нее -
+// все доставки в статусе DEAD подписки subscriptionID (всех подписок, если она не задана).
+// Возвращает число поставленных в очередь доставок.
+func (s *WebhookService) Replay(ctx context.Context, deliveryID int64, subscriptionID int) (int, error) {
+	replayed := 0
+
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if deliveryID != 0 {
+			if err := s.store.ReplayDelivery(ctx, deliveryID); err != nil {
+				return err
+			}
+			replayed = 1
+			return nil
+		}
+
+		var err error
+		replayed, err = s.store.ReplayDead(ctx, subscriptionID)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return replayed, nil
+}
+
+func (s *WebhookService) listSubscriptions(ctx context.Context) ([]api.WebhookSubscriptionSchema, error) {
+	subs, err := s.store.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]api.WebhookSubscriptionSchema, 0, len(subs))
+	for _, sub := range subs {
+		res = append(res, *toSubscriptionSchema(sub))
+	}
+	return res, nil
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// toSubscriptionSchema не переносит секрет: он отдается только при создании подписки.
+func toSubscriptionSchema(sub *models.WebhookSubscription) *api.WebhookSubscriptionSchema {
+	eventTypes := []string(sub.EventTypes)
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return &api.WebhookSubscriptionSchema{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  sub.CreatedAt,
+	}
+}
+
+func toDeliverySchema(d *models.WebhookDelivery) api.WebhookDeliverySchema {
+	res := api.WebhookDeliverySchema{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		SubscriptionID: d.SubscriptionID,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == models.DeliveryPending {
+		res.NextAttemptAt = &d.NextAttemptAt
+	}
+	return res
+}
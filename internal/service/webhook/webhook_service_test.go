@@ -0,0 +1,126 @@
+package webhook_test
+
+import (
+	"context"
+	"testing"
+
+	"avito-intership-2025/internal/http/api"
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/mocks"
+	"avito-intership-2025/internal/service/webhook"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDoMock(t *testing.T, ctx context.Context, wantErr error) *mocks.MockManager {
+	trm := &mocks.MockManager{}
+	trm.Test(t)
+	t.Cleanup(func() { trm.AssertExpectations(t) })
+
+	trm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			assert.ErrorIs(t, fn(ctx), wantErr)
+		}).Return(wantErr).Once()
+
+	return trm
+}
+
+func TestWebhookService_AddSubscription_GeneratesSecret(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewSubscriptionStore(t)
+	trm := newDoMock(t, ctx, nil)
+
+	store.On("CreateSubscription", ctx, mock.MatchedBy(func(sub *models.WebhookSubscription) bool {
+		return sub.URL == "https://ci.example.com/hooks" && len(sub.Secret) == 64 &&
+			assert.ObjectsAreEqual(pq.StringArray{models.WebhookPrMerged}, sub.EventTypes)
+	})).Return(func(_ context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+		created := *sub
+		created.ID = 1
+		return &created, nil
+	}).Once()
+
+	svc := webhook.NewWebhookService(trm, store)
+	resp, err := svc.AddSubscription(ctx, &api.WebhookSubscriptionSchema{
+		URL:        "https://ci.example.com/hooks",
+		EventTypes: []string{" Pull_Request.Merged "},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.ID)
+	assert.Len(t, resp.Secret, 64)
+	assert.Equal(t, []string{models.WebhookPrMerged}, resp.EventTypes)
+}
+
+func TestWebhookService_AddSubscription_Invalid(t *testing.T) {
+	svc := webhook.NewWebhookService(nil, nil)
+
+	cases := []*api.WebhookSubscriptionSchema{
+		{URL: "ftp://example.com/hooks"},
+		{URL: "/hooks"},
+		{URL: "https://example.com/hooks", EventTypes: []string{"pull_request.deleted"}},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.AddSubscription(context.Background(), tc)
+		assert.ErrorIs(t, err, repo.ErrInvalidSubscription, "%+v", tc)
+	}
+}
+
+func TestWebhookService_ListSubscriptions_HidesSecret(t *testing.T) {
+	ctx := context.Background()
+
+	store := mocks.NewSubscriptionStore(t)
+	trm := newDoMock(t, ctx, nil)
+
+	store.On("ListSubscriptions", ctx).Return([]*models.WebhookSubscription{
+		{ID: 1, URL: "https://ci.example.com/hooks", Secret: "s3cret"},
+	}, nil).Once()
+
+	svc := webhook.NewWebhookService(trm, store)
+	resp, err := svc.ListSubscriptions(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.WebhookSubscriptionSchema{
+		{ID: 1, URL: "https://ci.example.com/hooks", EventTypes: []string{}},
+	}, resp)
+}
+
+func TestWebhookService_Replay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("single delivery", func(t *testing.T) {
+		store := mocks.NewSubscriptionStore(t)
+		trm := newDoMock(t, ctx, repo.ErrNotFound)
+
+		store.On("ReplayDelivery", ctx, int64(42)).Return(repo.ErrNotFound).Once()
+
+		_, err := webhook.NewWebhookService(trm, store).Replay(ctx, 42, 0)
+
+		assert.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("dead deliveries of subscription", func(t *testing.T) {
+		store := mocks.NewSubscriptionStore(t)
+		trm := newDoMock(t, ctx, nil)
+
+		store.On("ReplayDead", ctx, 3).Return(5, nil).Once()
+
+		replayed, err := webhook.NewWebhookService(trm, store).Replay(ctx, 0, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, replayed)
+	})
+}
+
+func TestWebhookService_ListDeliveries_InvalidStatus(t *testing.T) {
+	svc := webhook.NewWebhookService(nil, nil)
+
+	_, err := svc.ListDeliveries(context.Background(), "FAILED", 0)
+
+	assert.ErrorIs(t, err, repo.ErrInvalidSubscription)
+}
@@ -0,0 +1,39 @@
+package simulate
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteText печатает отчет таблицами: итог, нагрузка по ревьюерам и нехватка кандидатов по командам.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "strategy:\t%s\n", r.Strategy)
+	if r.RotationWindow > 0 {
+		fmt.Fprintf(tw, "rotation window:\t%d PRs\n", r.RotationWindow)
+	}
+	fmt.Fprintf(tw, "pull requests:\t%d (skipped %d)\n", r.PullRequests, r.Skipped)
+	fmt.Fprintf(tw, "assignments:\t%d\n", r.Assignments)
+	fmt.Fprintf(tw, "load max/min:\t%d/%d\n", r.MaxLoad, r.MinLoad)
+	fmt.Fprintf(tw, "gini:\t%.3f\n", r.Gini)
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "REVIEWER\tTEAM\tASSIGNED\tPEAK OPEN")
+	for _, rv := range r.Reviewers {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\n", rv.UserID, rv.TeamName, rv.Assigned, rv.PeakOpen)
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "TEAM\tPRS\tUNDERSTAFFED\tUNASSIGNED\tRATE")
+	for _, t := range r.Teams {
+		rate := 0.0
+		if t.PullRequests > 0 {
+			rate = float64(t.Understaffed) / float64(t.PullRequests)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.1f%%\n", t.TeamName, t.PullRequests, t.Understaffed, t.Unassigned, rate*100)
+	}
+
+	return tw.Flush()
+}
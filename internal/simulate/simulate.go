@@ -0,0 +1,203 @@
+// Package simulate воспроизводит создание PR из снапшота под выбранной стратегией назначения
+// и считает, насколько равномерно распределилась нагрузка на ревьюеров.
+package simulate
+
+import (
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/pr"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// StrategyHistory - случайный выбор с ротацией пар автор-ревьюер по последним PR автора.
+const StrategyHistory = "history"
+
+// DefaultRotationWindow - окно ротации стратегии history, если оно не задано.
+const DefaultRotationWindow = 5
+
+// Strategies - стратегии, которые умеет воспроизводить симулятор.
+var Strategies = []string{
+	pr.StrategyRandom,
+	pr.StrategyRoundRobin,
+	pr.StrategyLeastLoaded,
+	pr.StrategyWeightedRandom,
+	StrategyHistory,
+}
+
+type Options struct {
+	Strategy string
+	// RotationWindow - сколько последних PR автора учитывает стратегия history
+	RotationWindow int
+}
+
+type Report struct {
+	Strategy       string `json:"strategy"`
+	RotationWindow int    `json:"rotation_window,omitempty"`
+	PullRequests   int    `json:"pull_requests"`
+	// Skipped - PR, которые не удалось воспроизвести: автора нет в снапшоте или id повторяется
+	Skipped     int            `json:"skipped"`
+	Assignments int            `json:"assignments"`
+	MaxLoad     int            `json:"max_load"`
+	MinLoad     int            `json:"min_load"`
+	Gini        float64        `json:"gini"`
+	Reviewers   []ReviewerLoad `json:"reviewers"`
+	Teams       []TeamReport   `json:"teams"`
+}
+
+// ReviewerLoad - сколько ревью получил пользователь за все время и сколько максимум держал открытыми одновременно.
+type ReviewerLoad struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	Assigned int    `json:"assigned"`
+	PeakOpen int    `json:"peak_open"`
+}
+
+// TeamReport - сколько PR команды получили меньше ревьюеров, чем положено, из-за нехватки кандидатов.
+type TeamReport struct {
+	TeamName     string `json:"team_name"`
+	PullRequests int    `json:"pull_requests"`
+	Understaffed int    `json:"understaffed"`
+	// Unassigned - PR совсем без ревьюеров
+	Unassigned int `json:"unassigned"`
+}
+
+// Run прогоняет PR снапшота в порядке создания через настоящий сервис PR, подменив хранилища памятью.
+// Перед каждым PR закрываются те, что в истории были смержены или закрыты к моменту его создания,
+// поэтому лимиты открытых ревью и стратегии по нагрузке видят ту же картину, что и в жизни.
+// Отпуска и правила конфликта интересов не учитываются.
+func Run(ctx context.Context, snapshot *models.Snapshot, opts Options) (*Report, error) {
+	strategy := opts.Strategy
+	report := &Report{Strategy: strategy}
+
+	var rotation *int
+	if strategy == StrategyHistory {
+		window := cmp.Or(opts.RotationWindow, DefaultRotationWindow)
+		rotation = &window
+		report.RotationWindow = window
+		strategy = pr.StrategyRandom
+	}
+
+	st := newStore()
+	dir := newDirectory(snapshot, rotation)
+
+	selector, err := pr.NewSelector(strategy, st)
+	if err != nil {
+		return nil, err
+	}
+	service := pr.NewPullRequestService(noTx{}, st, st, dir, dir, selector, pr.Options{Capacities: dir})
+
+	teamNames := make(map[int]string, len(snapshot.Teams))
+	teams := make(map[int]*TeamReport, len(snapshot.Teams))
+	for _, t := range snapshot.Teams {
+		teamNames[t.ID] = t.Name
+		teams[t.ID] = &TeamReport{TeamName: t.Name}
+	}
+
+	prs := slices.Clone(snapshot.PullRequests)
+	slices.SortStableFunc(prs, func(a, b models.SnapshotPR) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	assigned := map[string]int{}
+	var pending []models.SnapshotPR
+	for _, p := range prs {
+		pending = slices.DeleteFunc(pending, func(c models.SnapshotPR) bool {
+			if c.ClosedAt.After(p.CreatedAt) {
+				return false
+			}
+			st.close(c.ID, pr.StatusClosed)
+			return true
+		})
+		st.now = p.CreatedAt
+
+		resp, err := service.Create(ctx, p.ID, p.ID, p.AuthorID, nil, p.Labels)
+		if errors.Is(err, repo.ErrNotFound) || errors.Is(err, repo.ErrPRExists) {
+			report.Skipped++
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("replay PR %s: %w", p.ID, err)
+		}
+
+		report.PullRequests++
+		report.Assignments += len(resp.AssignedReviewers)
+		for _, id := range resp.AssignedReviewers {
+			assigned[id]++
+		}
+
+		if team, ok := teams[dir.users[p.AuthorID].TeamID]; ok {
+			team.PullRequests++
+			if resp.NeedMoreReviewers {
+				team.Understaffed++
+			}
+			if len(resp.AssignedReviewers) == 0 {
+				team.Unassigned++
+			}
+		}
+
+		if p.ClosedAt != nil {
+			pending = append(pending, p)
+		}
+	}
+
+	// в распределение попадают все, кто мог ревьюить, в том числе не получившие ни одного PR
+	for _, u := range snapshot.Users {
+		if !u.IsActive && assigned[u.ID] == 0 {
+			continue
+		}
+		report.Reviewers = append(report.Reviewers, ReviewerLoad{
+			UserID:   u.ID,
+			TeamName: teamNames[u.TeamID],
+			Assigned: assigned[u.ID],
+			PeakOpen: st.peak[u.ID],
+		})
+	}
+	slices.SortFunc(report.Reviewers, func(a, b ReviewerLoad) int {
+		return cmp.Or(cmp.Compare(b.Assigned, a.Assigned), cmp.Compare(a.UserID, b.UserID))
+	})
+
+	loads := make([]int, 0, len(report.Reviewers))
+	for _, r := range report.Reviewers {
+		loads = append(loads, r.Assigned)
+	}
+	if len(loads) > 0 {
+		report.MaxLoad = slices.Max(loads)
+		report.MinLoad = slices.Min(loads)
+	}
+	report.Gini = Gini(loads)
+
+	for _, t := range snapshot.Teams {
+		report.Teams = append(report.Teams, *teams[t.ID])
+	}
+	slices.SortFunc(report.Teams, func(a, b TeamReport) int { return cmp.Compare(a.TeamName, b.TeamName) })
+
+	return report, nil
+}
+
+// Gini - коэффициент Джини нагрузки: 0 - все получили поровну, ближе к 1 - ревью достаются немногим.
+func Gini(loads []int) float64 {
+	sorted := slices.Sorted(slices.Values(loads))
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += float64(v)
+		weighted += float64(i+1) * float64(v)
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	n := float64(len(sorted))
+	return 2*weighted/(n*sum) - (n+1)/n
+}
+
+// noTx выполняет функцию без транзакции: в памяти откатывать нечего.
+type noTx struct{}
+
+func (noTx) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
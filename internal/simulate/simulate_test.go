@@ -0,0 +1,167 @@
+package simulate_test
+
+import (
+	"avito-intership-2025/internal/models"
+	"avito-intership-2025/internal/simulate"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func team(id int, name string, reviewers int) models.SnapshotTeam {
+	return models.SnapshotTeam{ID: id, Name: name, ReviewersCount: reviewers}
+}
+
+func users(teamID int, ids ...string) []models.SnapshotUser {
+	res := make([]models.SnapshotUser, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, models.SnapshotUser{ID: id, TeamID: teamID, IsActive: true})
+	}
+	return res
+}
+
+func openPR(id, author string, hour int) models.SnapshotPR {
+	return models.SnapshotPR{ID: id, AuthorID: author, CreatedAt: start.Add(time.Duration(hour) * time.Hour)}
+}
+
+func closedPR(id, author string, hour, closedHour int) models.SnapshotPR {
+	p := openPR(id, author, hour)
+	p.ClosedAt = ptr(start.Add(time.Duration(closedHour) * time.Hour))
+	return p
+}
+
+func loadsByUser(r *simulate.Report) map[string]int {
+	loads := map[string]int{}
+	for _, rv := range r.Reviewers {
+		loads[rv.UserID] = rv.Assigned
+	}
+	return loads
+}
+
+func TestGini(t *testing.T) {
+	assert.Equal(t, 0.0, simulate.Gini(nil))
+	assert.Equal(t, 0.0, simulate.Gini([]int{0, 0, 0}))
+	assert.InDelta(t, 0.0, simulate.Gini([]int{3, 3, 3}), 1e-9)
+	assert.InDelta(t, 0.75, simulate.Gini([]int{0, 10, 0, 0}), 1e-9)
+}
+
+func TestRun_LeastLoaded_SpreadsEvenly(t *testing.T) {
+	snapshot := &models.Snapshot{
+		Teams: []models.SnapshotTeam{team(1, "backend", 1)},
+		Users: users(1, "a", "b", "c", "d"),
+	}
+	for i, id := range []string{"p1", "p2", "p3", "p4", "p5", "p6"} {
+		snapshot.PullRequests = append(snapshot.PullRequests, openPR(id, "a", i))
+	}
+
+	report, err := simulate.Run(context.Background(), snapshot, simulate.Options{Strategy: "least_loaded"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, report.PullRequests)
+	assert.Equal(t, 6, report.Assignments)
+	assert.Equal(t, map[string]int{"a": 0, "b": 2, "c": 2, "d": 2}, loadsByUser(report))
+	assert.Equal(t, 2, report.MaxLoad)
+	assert.Equal(t, 0, report.MinLoad)
+	assert.InDelta(t, 0.25, report.Gini, 1e-9)
+	assert.Equal(t, []simulate.TeamReport{{TeamName: "backend", PullRequests: 6}}, report.Teams)
+}
+
+func TestRun_CountsUnderstaffedPerTeam(t *testing.T) {
+	snapshot := &models.Snapshot{
+		Teams: []models.SnapshotTeam{team(1, "backend", 2), team(2, "frontend", 2)},
+		Users: append(users(1, "a", "b"), users(2, "x", "y", "z")...),
+		PullRequests: []models.SnapshotPR{
+			openPR("p1", "a", 1),
+			openPR("p2", "x", 2),
+			openPR("p3", "b", 3),
+		},
+	}
+
+	report, err := simulate.Run(context.Background(), snapshot, simulate.Options{Strategy: "random"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []simulate.TeamReport{
+		{TeamName: "backend", PullRequests: 2, Understaffed: 2},
+		{TeamName: "frontend", PullRequests: 1},
+	}, report.Teams)
+}
+
+func TestRun_ClosedPRsFreeCapacity(t *testing.T) {
+	backend := team(1, "backend", 1)
+	backend.MaxOpenReviews = ptr(1)
+
+	snapshot := &models.Snapshot{
+		Teams: []models.SnapshotTeam{backend},
+		Users: users(1, "a", "b"),
+		PullRequests: []models.SnapshotPR{
+			closedPR("p1", "a", 1, 2),
+			// p1 уже смержен, b снова свободен
+			openPR("p2", "a", 3),
+			// p2 еще открыт, b упирается в лимит
+			openPR("p3", "a", 4),
+		},
+	}
+
+	report, err := simulate.Run(context.Background(), snapshot, simulate.Options{Strategy: "random"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, loadsByUser(report)["b"])
+	assert.Equal(t, []simulate.TeamReport{
+		{TeamName: "backend", PullRequests: 3, Understaffed: 1, Unassigned: 1},
+	}, report.Teams)
+	for _, rv := range report.Reviewers {
+		if rv.UserID == "b" {
+			assert.Equal(t, 1, rv.PeakOpen)
+		}
+	}
+}
+
+func TestRun_History_RotatesPairs(t *testing.T) {
+	snapshot := &models.Snapshot{
+		Teams: []models.SnapshotTeam{team(1, "backend", 1)},
+		Users: users(1, "a", "b", "c", "d"),
+		PullRequests: []models.SnapshotPR{
+			openPR("p1", "a", 1),
+			openPR("p2", "a", 2),
+			openPR("p3", "a", 3),
+		},
+	}
+
+	report, err := simulate.Run(context.Background(), snapshot, simulate.Options{Strategy: "history", RotationWindow: 3})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.RotationWindow)
+	assert.Equal(t, map[string]int{"a": 0, "b": 1, "c": 1, "d": 1}, loadsByUser(report))
+}
+
+func TestRun_SkipsUnknownAuthors(t *testing.T) {
+	snapshot := &models.Snapshot{
+		Teams: []models.SnapshotTeam{team(1, "backend", 1)},
+		Users: users(1, "a", "b"),
+		PullRequests: []models.SnapshotPR{
+			openPR("p1", "ghost", 1),
+			openPR("p2", "a", 2),
+			openPR("p2", "a", 3),
+		},
+	}
+
+	report, err := simulate.Run(context.Background(), snapshot, simulate.Options{Strategy: "round_robin"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.PullRequests)
+	assert.Equal(t, 2, report.Skipped)
+}
+
+func TestRun_UnknownStrategy(t *testing.T) {
+	_, err := simulate.Run(context.Background(), &models.Snapshot{}, simulate.Options{Strategy: "lottery"})
+	require.Error(t, err)
+}
@@ -0,0 +1,392 @@
+package simulate
+
+import (
+	"avito-intership-2025/internal/models"
+	repo "avito-intership-2025/internal/repository"
+	"avito-intership-2025/internal/service/pr"
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+var errUnsupported = errors.New("not supported by simulator")
+
+// directory - команды и пользователи снапшота вместо репозиториев пользователей и настроек.
+type directory struct {
+	users    map[string]*models.SnapshotUser
+	members  map[int][]string
+	settings map[int]*models.TeamSettings
+}
+
+// store - PR и ревьюеры в памяти вместо репозитория PR. Держит нагрузку ревьюеров
+// по ходу воспроизведения и ничего не пишет в базу.
+type store struct {
+	prs map[string]*simPR
+	// authored - PR автора в порядке создания
+	authored map[string][]string
+
+	open map[string]int
+	peak map[string]int
+	// now - время создания воспроизводимого PR
+	now time.Time
+}
+
+type simPR struct {
+	pr        *models.PullRequest
+	reviewers []string
+}
+
+func newStore() *store {
+	return &store{
+		prs:      make(map[string]*simPR),
+		authored: make(map[string][]string),
+		open:     make(map[string]int),
+		peak:     make(map[string]int),
+	}
+}
+
+// newDirectory раскладывает снапшот по командам. rotationPRs задает окно ротации всем командам, nil - без ротации.
+func newDirectory(snapshot *models.Snapshot, rotationPRs *int) *directory {
+	d := &directory{
+		users:    make(map[string]*models.SnapshotUser, len(snapshot.Users)),
+		members:  make(map[int][]string),
+		settings: make(map[int]*models.TeamSettings, len(snapshot.Teams)),
+	}
+
+	for _, t := range snapshot.Teams {
+		d.settings[t.ID] = &models.TeamSettings{
+			TeamID:                 t.ID,
+			ReviewersCount:         t.ReviewersCount,
+			MaxOpenReviews:         t.MaxOpenReviews,
+			MinSeniorReviewers:     t.MinSeniorReviewers,
+			MinJuniorReviewers:     t.MinJuniorReviewers,
+			MaxJuniorReviewers:     t.MaxJuniorReviewers,
+			SeniorForJuniorAuthors: t.SeniorForJuniorAuthors,
+			RotationWindowPRs:      rotationPRs,
+		}
+	}
+
+	for i := range snapshot.Users {
+		u := &snapshot.Users[i]
+		d.users[u.ID] = u
+		d.members[u.TeamID] = append(d.members[u.TeamID], u.ID)
+	}
+
+	return d
+}
+
+// close освобождает ревьюеров PR, как при мерже или закрытии.
+func (s *store) close(prID string, status string) {
+	p, ok := s.prs[prID]
+	if !ok || p.pr.Status != pr.StatusOpen {
+		return
+	}
+
+	p.pr.Status = status
+	for _, id := range p.reviewers {
+		s.open[id]--
+	}
+}
+
+func (s *store) assign(p *simPR, userID string) {
+	p.reviewers = append(p.reviewers, userID)
+	if p.pr.Status != pr.StatusOpen {
+		return
+	}
+
+	s.open[userID]++
+	s.peak[userID] = max(s.peak[userID], s.open[userID])
+}
+
+func (s *store) unassign(p *simPR, userID string) {
+	p.reviewers = slices.DeleteFunc(p.reviewers, func(id string) bool { return id == userID })
+	if p.pr.Status == pr.StatusOpen {
+		s.open[userID]--
+	}
+}
+
+func (s *store) getPR(prID string) (*simPR, error) {
+	p, ok := s.prs[prID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return p, nil
+}
+
+func (s *store) Create(_ context.Context, p *models.PullRequest) (string, error) {
+	if _, ok := s.prs[p.ID]; ok {
+		return "", repo.ErrPRExists
+	}
+
+	created, now := *p, s.now
+	created.CreatedAt = &now
+	s.prs[p.ID] = &simPR{pr: &created}
+	s.authored[p.AuthorId] = append(s.authored[p.AuthorId], p.ID)
+
+	return p.ID, nil
+}
+
+func (s *store) GetById(_ context.Context, prID string) (*models.PullRequest, error) {
+	p, err := s.getPR(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := *p.pr
+	return &res, nil
+}
+
+func (s *store) MarkAsMerged(_ context.Context, prID string) error {
+	if _, err := s.getPR(prID); err != nil {
+		return err
+	}
+	s.close(prID, pr.StatusMerged)
+	return nil
+}
+
+func (s *store) SetStatus(_ context.Context, prID, status string) error {
+	p, err := s.getPR(prID)
+	if err != nil {
+		return err
+	}
+
+	if status != pr.StatusOpen {
+		s.close(prID, status)
+		return nil
+	}
+	if p.pr.Status != pr.StatusOpen {
+		p.pr.Status = status
+		for _, id := range p.reviewers {
+			s.open[id]++
+			s.peak[id] = max(s.peak[id], s.open[id])
+		}
+	}
+	return nil
+}
+
+func (s *store) GetUnderstaffed(_ context.Context) ([]*models.PullRequest, error) {
+	res := []*models.PullRequest{}
+	for _, p := range s.prs {
+		if p.pr.Status == pr.StatusOpen && p.pr.NeedMoreReviewers {
+			res = append(res, p.pr)
+		}
+	}
+	return res, nil
+}
+
+func (s *store) SetNeedMoreReviewers(_ context.Context, prID string, need bool) error {
+	p, err := s.getPR(prID)
+	if err != nil {
+		return err
+	}
+	p.pr.NeedMoreReviewers = need
+	return nil
+}
+
+func (s *store) SetLabels(_ context.Context, prID string, labels []string) error {
+	p, err := s.getPR(prID)
+	if err != nil {
+		return err
+	}
+	p.pr.Labels = labels
+	return nil
+}
+
+func (s *store) List(context.Context, *models.PrListFilter) ([]*models.PullRequestListItem, string, error) {
+	return nil, "", errUnsupported
+}
+
+func (s *store) Search(context.Context, *models.PrSearchFilter) ([]*models.PullRequestSearchItem, error) {
+	return nil, errUnsupported
+}
+
+func (s *store) GetPrReviewers(_ context.Context, prID string) ([]string, error) {
+	p, err := s.getPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(p.reviewers), nil
+}
+
+func (s *store) AssignReviewer(_ context.Context, prID, userID string) error {
+	p, err := s.getPR(prID)
+	if err != nil {
+		return err
+	}
+	s.assign(p, userID)
+	return nil
+}
+
+func (s *store) ReassignReviewer(_ context.Context, prID, oldUserID, newUserID string) error {
+	p, err := s.getPR(prID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(p.reviewers, oldUserID) {
+		return repo.ErrNotAssigned
+	}
+
+	s.unassign(p, oldUserID)
+	s.assign(p, newUserID)
+	return nil
+}
+
+func (s *store) DeleteReviewer(_ context.Context, prID, userID string) error {
+	p, err := s.getPR(prID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(p.reviewers, userID) {
+		return repo.ErrNotAssigned
+	}
+
+	s.unassign(p, userID)
+	return nil
+}
+
+func (s *store) GetOpenReviewsCount(_ context.Context, userIDs []string) (map[string]int, error) {
+	loads := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		loads[id] = s.open[id]
+	}
+	return loads, nil
+}
+
+func (s *store) GetOpenReviewsByUsers(context.Context, []string) ([]*models.ReviewAssignment, error) {
+	return nil, errUnsupported
+}
+
+// SetAssignmentReason ничего не делает: причины назначения на нагрузку не влияют.
+func (s *store) SetAssignmentReason(context.Context, string, string, string) error {
+	return nil
+}
+
+func (s *store) GetPendingReviews(context.Context, time.Duration) ([]*models.PendingReview, error) {
+	return nil, errUnsupported
+}
+
+func (s *store) AddReview(context.Context, *models.Review) error {
+	return errUnsupported
+}
+
+func (s *store) GetLatestReviews(context.Context, string) ([]*models.Review, error) {
+	return nil, errUnsupported
+}
+
+func (s *store) GetPairingsInLastPRs(
+	_ context.Context,
+	authorID string,
+	reviewerIDs []string,
+	lastPRs int,
+) (map[string]int, error) {
+	ids := s.authored[authorID]
+	return s.countPairs(ids[max(0, len(ids)-lastPRs):], reviewerIDs), nil
+}
+
+func (s *store) GetPairingsSince(
+	_ context.Context,
+	authorID string,
+	reviewerIDs []string,
+	since time.Time,
+) (map[string]int, error) {
+	ids := slices.DeleteFunc(slices.Clone(s.authored[authorID]), func(id string) bool {
+		return s.prs[id].pr.CreatedAt.Before(since)
+	})
+	return s.countPairs(ids, reviewerIDs), nil
+}
+
+func (s *store) countPairs(prIDs, reviewerIDs []string) map[string]int {
+	pairs := map[string]int{}
+	for _, id := range prIDs {
+		for _, r := range s.prs[id].reviewers {
+			if slices.Contains(reviewerIDs, r) {
+				pairs[r]++
+			}
+		}
+	}
+	return pairs
+}
+
+func (d *directory) GetActiveUsersIDInTeam(_ context.Context, teamID int) ([]string, error) {
+	active := []string{}
+	for _, id := range d.members[teamID] {
+		if d.users[id].IsActive {
+			active = append(active, id)
+		}
+	}
+	return active, nil
+}
+
+func (d *directory) GetById(_ context.Context, userID string) (*models.User, error) {
+	u, ok := d.users[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return toUser(u), nil
+}
+
+func (d *directory) GetSkills(_ context.Context, userIDs []string) (map[string][]string, error) {
+	skills := make(map[string][]string, len(userIDs))
+	for _, id := range userIDs {
+		if u, ok := d.users[id]; ok {
+			skills[id] = u.Skills
+		}
+	}
+	return skills, nil
+}
+
+// GetSeniority возвращает уровни пользователей, без уровня в снапшоте - middle, как в базе по умолчанию.
+func (d *directory) GetSeniority(_ context.Context, userIDs []string) (map[string]string, error) {
+	levels := make(map[string]string, len(userIDs))
+	for _, id := range userIDs {
+		u, ok := d.users[id]
+		if !ok {
+			continue
+		}
+		levels[id] = u.Seniority
+		if u.Seniority == "" {
+			levels[id] = models.SeniorityMiddle
+		}
+	}
+	return levels, nil
+}
+
+func (d *directory) GetTeamMembers(_ context.Context, teamID int) ([]*models.User, error) {
+	members := []*models.User{}
+	for _, id := range d.members[teamID] {
+		members = append(members, toUser(d.users[id]))
+	}
+	return members, nil
+}
+
+func (d *directory) GetSettings(_ context.Context, teamID int) (*models.TeamSettings, error) {
+	settings, ok := d.settings[teamID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+
+	res := *settings
+	return &res, nil
+}
+
+func (d *directory) GetMaxOpenReviews(_ context.Context, userIDs []string) (map[string]int, error) {
+	limits := map[string]int{}
+	for _, id := range userIDs {
+		if u, ok := d.users[id]; ok && u.MaxOpenReviews != nil {
+			limits[id] = *u.MaxOpenReviews
+		}
+	}
+	return limits, nil
+}
+
+func toUser(u *models.SnapshotUser) *models.User {
+	return &models.User{
+		ID:             u.ID,
+		TeamID:         u.TeamID,
+		IsActive:       u.IsActive,
+		Skills:         u.Skills,
+		Seniority:      u.Seniority,
+		MaxOpenReviews: u.MaxOpenReviews,
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_requests_need_more_reviewers;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS need_more_reviewers;
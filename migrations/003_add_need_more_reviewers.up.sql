@@ -0,0 +1,4 @@
+ALTER TABLE pull_requests ADD COLUMN need_more_reviewers BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_pull_requests_need_more_reviewers ON pull_requests(need_more_reviewers)
+WHERE need_more_reviewers AND status = 'OPEN';
@@ -0,0 +1,7 @@
+CREATE TABLE team_settings (
+    team_id INTEGER PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,
+    reviewers_count INTEGER NOT NULL DEFAULT 2 CHECK (reviewers_count >= 0),
+    -- NULL - без ограничения
+    max_open_reviews INTEGER DEFAULT NULL CHECK (max_open_reviews > 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
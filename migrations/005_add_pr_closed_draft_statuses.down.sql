@@ -0,0 +1,16 @@
+-- откат невозможен, пока в таблице есть PR в новых статусах: удалять их нельзя,
+-- а без этих статусов они не проходят проверку, поэтому падаем явно
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pull_requests WHERE status IN ('DRAFT', 'CLOSED')) THEN
+        RAISE EXCEPTION 'cannot roll back: pull_requests contains DRAFT or CLOSED rows, resolve them manually first';
+    END IF;
+END
+$$;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_at;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_status_check
+CHECK (status IN ('OPEN', 'MERGED'));
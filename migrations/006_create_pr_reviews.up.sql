@@ -0,0 +1,13 @@
+CREATE TABLE pr_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    verdict TEXT NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED')),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pr_reviews_pr_user_created ON pr_reviews(pull_request_id, user_id, created_at DESC);
+
+-- NULL - мерж без требований к числу апрувов
+ALTER TABLE team_settings ADD COLUMN required_approvals INTEGER DEFAULT NULL CHECK (required_approvals > 0);
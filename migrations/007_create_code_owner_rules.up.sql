@@ -0,0 +1,10 @@
+CREATE TABLE code_owner_rules (
+    id SERIAL PRIMARY KEY,
+    pattern TEXT NOT NULL UNIQUE,
+    -- владельцы: отдельные пользователи и/или команды целиком
+    user_ids TEXT[] NOT NULL DEFAULT '{}',
+    team_ids INTEGER[] NOT NULL DEFAULT '{}',
+    -- порядок как в CODEOWNERS: для файла действует последнее совпавшее правило
+    position INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS labels;
+
+ALTER TABLE users DROP COLUMN IF EXISTS skills;
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN skills TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pull_requests ADD COLUMN labels TEXT[] NOT NULL DEFAULT '{}';
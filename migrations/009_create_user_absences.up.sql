@@ -0,0 +1,20 @@
+CREATE TABLE user_absences (
+    id SERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- обе даты включительно
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    -- UID события из .ics: повторный импорт того же календаря обновляет отсутствие
+    external_uid TEXT DEFAULT NULL,
+    -- снять пользователя с открытых ревью, когда отсутствие начнется
+    reassign_reviews BOOLEAN NOT NULL DEFAULT FALSE,
+    reviews_reassigned_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_on >= starts_on)
+);
+
+CREATE INDEX idx_user_absences_user_dates ON user_absences(user_id, starts_on, ends_on);
+
+CREATE UNIQUE INDEX idx_user_absences_user_uid ON user_absences(user_id, external_uid)
+    WHERE external_uid IS NOT NULL;
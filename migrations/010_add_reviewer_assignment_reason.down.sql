@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pr_reviewers_assigned_at;
+
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS assignment_reason;
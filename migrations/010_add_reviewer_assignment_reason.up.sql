@@ -0,0 +1,4 @@
+-- почему ревьюер назначен взамен другого (например, предыдущий не уложился в SLA)
+ALTER TABLE pr_reviewers ADD COLUMN assignment_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_pr_reviewers_assigned_at ON pr_reviewers(assigned_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS review_events;
+DROP FUNCTION IF EXISTS review_events_append_only();
@@ -0,0 +1,35 @@
+-- журнал изменений ревью. Только добавление записей: ссылок на PR и пользователей нет,
+-- чтобы история переживала их удаление
+CREATE TABLE review_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type TEXT NOT NULL CHECK (
+        event_type IN ('ASSIGNED', 'REASSIGNED', 'REMOVED', 'STATUS_CHANGED', 'ACTIVITY_CHANGED')
+    ),
+    pull_request_id TEXT NOT NULL DEFAULT '',
+    -- пользователь, у которого изменилась активность
+    user_id TEXT NOT NULL DEFAULT '',
+    old_reviewer_id TEXT NOT NULL DEFAULT '',
+    new_reviewer_id TEXT NOT NULL DEFAULT '',
+    -- новый статус PR для STATUS_CHANGED
+    status TEXT NOT NULL DEFAULT '',
+    -- новое значение активности для ACTIVITY_CHANGED
+    is_active BOOLEAN DEFAULT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_review_events_pr ON review_events(pull_request_id, id);
+CREATE INDEX idx_review_events_user ON review_events(user_id, id) WHERE user_id <> '';
+CREATE INDEX idx_review_events_old_reviewer ON review_events(old_reviewer_id, id) WHERE old_reviewer_id <> '';
+CREATE INDEX idx_review_events_new_reviewer ON review_events(new_reviewer_id, id) WHERE new_reviewer_id <> '';
+
+CREATE FUNCTION review_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'review_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER review_events_append_only
+    BEFORE UPDATE OR DELETE ON review_events
+    FOR EACH ROW EXECUTE FUNCTION review_events_append_only();
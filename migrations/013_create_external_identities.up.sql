@@ -0,0 +1,11 @@
+-- учетные записи пользователей во внешних системах: по ним вебхуки находят автора PR
+CREATE TABLE external_identities (
+    provider TEXT NOT NULL CHECK (provider IN ('github')),
+    -- логин хранится в нижнем регистре: GitHub сравнивает логины без учета регистра
+    login TEXT NOT NULL,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX idx_external_identities_user ON external_identities(user_id);
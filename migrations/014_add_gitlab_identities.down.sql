@@ -0,0 +1,13 @@
+-- откат невозможен, пока есть привязки GitLab: удалять их нельзя,
+-- а без провайдера gitlab они не проходят проверку, поэтому падаем явно
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM external_identities WHERE provider = 'gitlab') THEN
+        RAISE EXCEPTION 'cannot roll back: external_identities contains gitlab rows, remove them manually first';
+    END IF;
+END
+$$;
+
+ALTER TABLE external_identities DROP CONSTRAINT external_identities_provider_check;
+ALTER TABLE external_identities ADD CONSTRAINT external_identities_provider_check
+    CHECK (provider IN ('github'));
@@ -0,0 +1,3 @@
+ALTER TABLE external_identities DROP CONSTRAINT external_identities_provider_check;
+ALTER TABLE external_identities ADD CONSTRAINT external_identities_provider_check
+    CHECK (provider IN ('github', 'gitlab'));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS exclusion_group_members;
+DROP TABLE IF EXISTS exclusion_groups;
+DROP TABLE IF EXISTS review_exclusions;
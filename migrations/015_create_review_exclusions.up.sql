@@ -0,0 +1,25 @@
+-- пары, которым нельзя ревьюить друг друга: reviewer_id не назначается на PR автора author_id
+CREATE TABLE review_exclusions (
+    author_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (author_id, reviewer_id),
+    CHECK (author_id <> reviewer_id)
+);
+
+-- подгруппы, внутри которых участники не ревьюят друг друга (например, соавторы фичи)
+CREATE TABLE exclusion_groups (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE exclusion_group_members (
+    group_id INT NOT NULL REFERENCES exclusion_groups(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX idx_exclusion_group_members_user ON exclusion_group_members(user_id);
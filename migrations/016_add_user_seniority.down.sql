@@ -0,0 +1,6 @@
+ALTER TABLE team_settings DROP COLUMN IF EXISTS senior_for_junior_authors;
+ALTER TABLE team_settings DROP COLUMN IF EXISTS max_junior_reviewers;
+ALTER TABLE team_settings DROP COLUMN IF EXISTS min_junior_reviewers;
+ALTER TABLE team_settings DROP COLUMN IF EXISTS min_senior_reviewers;
+
+ALTER TABLE users DROP COLUMN IF EXISTS seniority;
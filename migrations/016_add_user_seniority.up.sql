@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN seniority TEXT NOT NULL DEFAULT 'middle'
+    CHECK (seniority IN ('junior', 'middle', 'senior'));
+
+-- правила состава ревьюеров, NULL - правило не действует
+ALTER TABLE team_settings ADD COLUMN min_senior_reviewers INTEGER DEFAULT NULL CHECK (min_senior_reviewers > 0);
+ALTER TABLE team_settings ADD COLUMN min_junior_reviewers INTEGER DEFAULT NULL CHECK (min_junior_reviewers > 0);
+ALTER TABLE team_settings ADD COLUMN max_junior_reviewers INTEGER DEFAULT NULL CHECK (max_junior_reviewers >= 0);
+-- PR джуна получает хотя бы одного сеньора
+ALTER TABLE team_settings ADD COLUMN senior_for_junior_authors BOOLEAN NOT NULL DEFAULT FALSE;
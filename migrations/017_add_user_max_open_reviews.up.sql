@@ -0,0 +1,2 @@
+-- личный лимит открытых ревью, NULL - действует лимит команды
+ALTER TABLE users ADD COLUMN max_open_reviews INTEGER DEFAULT NULL CHECK (max_open_reviews > 0);
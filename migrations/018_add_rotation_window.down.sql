@@ -0,0 +1,3 @@
+ALTER TABLE team_settings DROP CONSTRAINT IF EXISTS team_settings_single_rotation_window;
+ALTER TABLE team_settings DROP COLUMN IF EXISTS rotation_window_days;
+ALTER TABLE team_settings DROP COLUMN IF EXISTS rotation_window_prs;
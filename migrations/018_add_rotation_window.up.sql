@@ -0,0 +1,5 @@
+-- окно ротации ревьюеров: последние N PR автора или последние N дней, NULL - ротация выключена
+ALTER TABLE team_settings ADD COLUMN rotation_window_prs INTEGER DEFAULT NULL CHECK (rotation_window_prs > 0);
+ALTER TABLE team_settings ADD COLUMN rotation_window_days INTEGER DEFAULT NULL CHECK (rotation_window_days > 0);
+ALTER TABLE team_settings ADD CONSTRAINT team_settings_single_rotation_window
+    CHECK (rotation_window_prs IS NULL OR rotation_window_days IS NULL);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_users_team_id;
+
+DROP INDEX IF EXISTS idx_pr_reviewers_pull_request_id;
+
+DROP INDEX IF EXISTS idx_pull_requests_merged;
+DROP INDEX IF EXISTS idx_pull_requests_author_created;
+DROP INDEX IF EXISTS idx_pull_requests_status_created;
+DROP INDEX IF EXISTS idx_pull_requests_created;
@@ -0,0 +1,11 @@
+-- индексы списка PR: сортировка по (created_at, id) или (merged_at, id) вместе с частыми фильтрами
+CREATE INDEX idx_pull_requests_created ON pull_requests(created_at, id);
+CREATE INDEX idx_pull_requests_status_created ON pull_requests(status, created_at, id);
+CREATE INDEX idx_pull_requests_author_created ON pull_requests(author_id, created_at, id);
+CREATE INDEX idx_pull_requests_merged ON pull_requests(merged_at, id) WHERE merged_at IS NOT NULL;
+
+-- ревьюеры строки списка и фильтр по ревьюеру ищутся по pull_request_id,
+-- первичный ключ (user_id, pull_request_id) для этого не подходит
+CREATE INDEX idx_pr_reviewers_pull_request_id ON pr_reviewers(pull_request_id);
+
+CREATE INDEX idx_users_team_id ON users(team_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pull_requests_title_tsv;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS title_tsv;
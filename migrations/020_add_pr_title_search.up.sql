@@ -0,0 +1,7 @@
+-- полнотекстовый поиск по названию PR. Колонка вычисляемая: при добавлении заполняется
+-- для всех существующих PR и дальше обновляется вместе с title. Конфигурация simple без стемминга,
+-- формы слов покрывает поиск по префиксу.
+ALTER TABLE pull_requests ADD COLUMN title_tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', coalesce(title, ''))) STORED;
+
+CREATE INDEX idx_pull_requests_title_tsv ON pull_requests USING GIN (title_tsv);